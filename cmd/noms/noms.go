@@ -15,21 +15,31 @@ import (
 
 	"github.com/attic-labs/noms/cmd/noms/splore"
 	"github.com/attic-labs/noms/cmd/util"
+	// Registers the merges of the CRDT structs, for noms merge and friends.
+	_ "github.com/attic-labs/noms/go/crdt"
 	"github.com/attic-labs/noms/go/util/profile"
 	"github.com/attic-labs/noms/go/util/verbose"
 )
 
 var kingpinCommands = []util.KingpinCommand{
+	nomsBlame,
 	nomsBlob,
+	nomsCherryPick,
 	nomsCommit,
+	nomsConflicts,
 	nomsConfig,
 	nomsDiff,
 	nomsDs,
+	nomsEdit,
 	nomsList,
 	nomsLog,
 	nomsMerge,
+	nomsMigrate,
 	nomsJSON,
 	nomsMap,
+	nomsPatch,
+	nomsRebase,
+	nomsRevert,
 	nomsRoot,
 	nomsServe,
 	nomsSet,
@@ -0,0 +1,81 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/attic-labs/kingpin"
+
+	"github.com/attic-labs/noms/cmd/util"
+	"github.com/attic-labs/noms/go/config"
+	"github.com/attic-labs/noms/go/d"
+	"github.com/attic-labs/noms/go/datas"
+	"github.com/attic-labs/noms/go/spec"
+	"github.com/attic-labs/noms/go/types"
+)
+
+func nomsBlame(noms *kingpin.Application) (*kingpin.CmdClause, util.KingpinHandler) {
+	cmd := noms.Command("blame", "Shows the commit that last changed a value, e.g. a map entry or a struct field, and its meta.")
+	allKeys := cmd.Flag("all-keys", "show the commit that last changed each entry of the map at the path").Bool()
+	path := cmd.Arg("path", "path of the value in a dataset or commit, e.g. db::prices.value[\"apple\"] - see Spelling Values at https://github.com/attic-labs/noms/blob/master/doc/spelling.md").Required().String()
+
+	return cmd, func(input string) int {
+		cfg := config.NewResolver()
+		sp, err := spec.ForPath(cfg.ResolvePathSpec(*path))
+		d.CheckErrorNoUsage(err)
+		defer sp.Close()
+
+		pinned, ok := sp.Pin()
+		checkIfTrue(!ok, "Cannot resolve spec: %s", *path)
+		db := pinned.GetDatabase()
+
+		commit, ok := db.ReadValue(pinned.Path.Hash).(types.Struct)
+		checkIfTrue(!ok || !datas.IsCommit(commit), "%s does not reference a Commit object", *path)
+		head := types.NewRef(commit)
+		valuePath := pinned.Path.Path
+		if len(valuePath) == 0 {
+			valuePath = types.MustParsePath(".value")
+		}
+
+		if *allKeys {
+			blames, err := datas.BlameMapKeys(db, head, valuePath)
+			d.CheckErrorNoUsage(err)
+			for _, b := range blames {
+				writeBlame(os.Stdout, db, b.Commit, types.EncodedValue(b.Key))
+			}
+			return 0
+		}
+
+		r, err := datas.BlamePath(db, head, valuePath)
+		d.CheckErrorNoUsage(err)
+		writeBlame(os.Stdout, db, r, valuePath.String())
+		return 0
+	}
+}
+
+// writeBlame writes a line with the hash of the Commit at |r|, |label| and the
+// fields of the Commit's meta, other than the schema that every Commit to a
+// dataset with one inherits.
+func writeBlame(w io.Writer, vr types.ValueReader, r types.Ref, label string) {
+	fields := []string{}
+	if meta, ok := r.TargetValue(vr).(types.Struct).MaybeGet(datas.MetaField); ok {
+		meta.(types.Struct).IterFields(func(name string, v types.Value) bool {
+			if name == datas.SchemaField {
+				return false
+			}
+			fields = append(fields, fmt.Sprintf("%s: %s", name, types.EncodedValue(v)))
+			return false
+		})
+	}
+	if len(fields) == 0 {
+		fmt.Fprintf(w, "%s %s\n", r.TargetHash(), label)
+		return
+	}
+	fmt.Fprintf(w, "%s %s (%s)\n", r.TargetHash(), label, strings.Join(fields, ", "))
+}
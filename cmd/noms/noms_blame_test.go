@@ -0,0 +1,58 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/attic-labs/noms/go/datas"
+	"github.com/attic-labs/noms/go/spec"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/attic-labs/noms/go/util/clienttest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestNomsBlame(t *testing.T) {
+	suite.Run(t, &nomsBlameTestSuite{})
+}
+
+type nomsBlameTestSuite struct {
+	clienttest.ClientTestSuite
+}
+
+func (s *nomsBlameTestSuite) TestBlame() {
+	sp, err := spec.ForDatabase(spec.CreateDatabaseSpecString("nbs", s.DBDir))
+	s.NoError(err)
+	defer sp.Close()
+	db := sp.GetDatabase()
+
+	ds := db.GetDataset("prices")
+	commit := func(message string, kv ...types.Value) types.Ref {
+		meta := types.NewStruct("Meta", types.StructData{"message": types.String(message)})
+		ds, err = db.Commit(ds, types.NewMap(db, kv...), datas.CommitOptions{Meta: meta})
+		s.NoError(err)
+		return ds.HeadRef()
+	}
+	apple, pear := types.String("apple"), types.String("pear")
+	c1 := commit("initial prices", apple, types.Number(1), pear, types.Number(1))
+	// The schema that later Commits inherit isn't shown.
+	ds, err = datas.SetSchema(ds, types.MakeMapType(types.StringType, types.NumberType), types.NewStruct("Meta", types.StructData{"message": types.String("schema")}))
+	s.NoError(err)
+	c2 := commit("apples are up", apple, types.Number(2), pear, types.Number(1))
+	commit("no change", apple, types.Number(2), pear, types.Number(1))
+
+	dsSpec := spec.CreateValueSpecString("nbs", s.DBDir, "prices")
+	stdout, stderr := s.MustRun(main, []string{"blame", dsSpec + `.value["apple"]`})
+	s.Equal("", stderr)
+	s.Equal(fmt.Sprintf("%s .value[\"apple\"] (message: \"apples are up\")\n", c2.TargetHash()), stdout)
+
+	stdout, stderr = s.MustRun(main, []string{"blame", "--all-keys", dsSpec})
+	s.Equal("", stderr)
+	s.Equal(fmt.Sprintf("%s \"apple\" (message: \"apples are up\")\n%s \"pear\" (message: \"initial prices\")\n", c2.TargetHash(), c1.TargetHash()), stdout)
+
+	_, stderr, _ = s.Run(main, []string{"blame", dsSpec + `.value["kiwi"]`})
+	s.Contains(stderr, `No value at .value["kiwi"]`)
+}
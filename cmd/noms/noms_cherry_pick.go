@@ -0,0 +1,64 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/attic-labs/kingpin"
+
+	"github.com/attic-labs/noms/cmd/util"
+	"github.com/attic-labs/noms/go/config"
+	"github.com/attic-labs/noms/go/d"
+	"github.com/attic-labs/noms/go/datas"
+	"github.com/attic-labs/noms/go/spec"
+	"github.com/attic-labs/noms/go/types"
+)
+
+func nomsCherryPick(noms *kingpin.Application) (*kingpin.CmdClause, util.KingpinHandler) {
+	cmd := noms.Command("cherry-pick", "Applies the changes introduced by a commit to a dataset.")
+	resolver := cmd.Flag("policy", "conflict resolution policy - 'n' (no resolution, the default), 'l' (keep the dataset's value), 'r' (take the commit's value) or 'p' (prompt)").Default("n").String()
+	message := cmd.Flag("message", "commit message - defaults to the meta of the picked commit").String()
+	commit := cmd.Arg("commit", "absolute path to the commit to apply - see Spelling Objects at https://github.com/attic-labs/noms/blob/master/doc/spelling.md").Required().String()
+	ds := cmd.Arg("dataset", "dataset spec to apply the commit to - see Spelling Datasets at https://github.com/attic-labs/noms/blob/master/doc/spelling.md").Required().String()
+
+	return cmd, func(input string) int {
+		cfg := config.NewResolver()
+		db, ds, err := cfg.GetDataset(*ds)
+		d.CheckError(err)
+		defer db.Close()
+
+		commitRef := resolveCommitRef(db, *commit)
+		var meta types.Struct
+		if *message != "" {
+			meta, err = spec.CreateCommitMetaStruct(db, "", *message, nil, nil)
+			d.CheckErrorNoUsage(err)
+		}
+
+		oldCommitRef, ok := ds.MaybeHeadRef()
+		checkIfTrue(!ok, "Dataset %s has no data", ds.ID())
+		ds, err = datas.CherryPick(db, ds, commitRef, decideResolveFunc(*resolver), meta)
+		d.CheckErrorNoUsage(err)
+
+		fmt.Printf("New head #%v (was #%v)\n", ds.HeadRef().TargetHash().String(), oldCommitRef.TargetHash().String())
+		return 0
+	}
+}
+
+// resolveCommitRef resolves the absolute path |path| in |db| and returns a
+// Ref to it, exiting if it does not name a Commit.
+func resolveCommitRef(db datas.Database, path string) types.Ref {
+	absPath, err := spec.NewAbsolutePath(path)
+	d.CheckErrorNoUsage(err)
+
+	v := absPath.Resolve(db)
+	if v == nil {
+		d.CheckErrorNoUsage(fmt.Errorf("Error resolving value: %s", path))
+	}
+	if !datas.IsCommit(v) {
+		d.CheckErrorNoUsage(fmt.Errorf("%s is not a commit", path))
+	}
+	return types.NewRef(v)
+}
@@ -0,0 +1,90 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package main
+
+import (
+	"testing"
+
+	"github.com/attic-labs/noms/go/datas"
+	"github.com/attic-labs/noms/go/spec"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/attic-labs/noms/go/util/clienttest"
+	"github.com/stretchr/testify/suite"
+)
+
+type nomsHistoryTestSuite struct {
+	clienttest.ClientTestSuite
+}
+
+func TestNomsHistory(t *testing.T) {
+	suite.Run(t, &nomsHistoryTestSuite{})
+}
+
+// setupHistory creates a dataset "base" holding {a: 1} and, branching from
+// it, a dataset "feature" which adds b and then c in two commits.
+func (s *nomsHistoryTestSuite) setupHistory() (db datas.Database, base, feature datas.Dataset) {
+	sp, err := spec.ForDatabase(spec.CreateDatabaseSpecString("nbs", s.DBDir))
+	s.NoError(err)
+	db = sp.GetDatabase()
+
+	base, err = db.CommitValue(db.GetDataset("base"), types.NewMap(db, types.String("a"), types.Number(1)))
+	s.NoError(err)
+	feature, err = db.SetHead(db.GetDataset("feature"), base.HeadRef())
+	s.NoError(err)
+	feature, err = db.CommitValue(feature, types.NewMap(db, types.String("a"), types.Number(1), types.String("b"), types.Number(2)))
+	s.NoError(err)
+	feature, err = db.CommitValue(feature, types.NewMap(db, types.String("a"), types.Number(1), types.String("b"), types.Number(2), types.String("c"), types.Number(3)))
+	s.NoError(err)
+	return
+}
+
+func (s *nomsHistoryTestSuite) headValue(ds string) types.Value {
+	sp, err := spec.ForDataset(spec.CreateValueSpecString("nbs", s.DBDir, ds))
+	s.NoError(err)
+	defer sp.Close()
+	return sp.GetDataset().HeadValue()
+}
+
+func (s *nomsHistoryTestSuite) TestNomsCherryPickAndRevert() {
+	db, _, feature := s.setupHistory()
+	commit := "#" + feature.HeadRef().TargetHash().String()
+	db.Close()
+
+	baseSpec := spec.CreateValueSpecString("nbs", s.DBDir, "base")
+	stdout, _ := s.MustRun(main, []string{"cherry-pick", commit, baseSpec})
+	s.Contains(stdout, "New head #")
+	s.Equal(`map {
+  "a": 1,
+  "c": 3,
+}`, types.EncodedValue(s.headValue("base")))
+
+	stdout, _ = s.MustRun(main, []string{"revert", "--message", "undo c", commit, baseSpec})
+	s.Contains(stdout, "New head #")
+	s.Equal(`map {
+  "a": 1,
+}`, types.EncodedValue(s.headValue("base")))
+
+	_, _, recovered := s.Run(main, []string{"cherry-pick", "#" + types.String("nope").Hash().String(), baseSpec})
+	s.IsType(clienttest.ExitError{}, recovered)
+}
+
+func (s *nomsHistoryTestSuite) TestNomsRebase() {
+	db, base, _ := s.setupHistory()
+	_, err := db.CommitValue(base, types.NewMap(db, types.String("a"), types.Number(10)))
+	s.NoError(err)
+	db.Close()
+
+	featureSpec := spec.CreateValueSpecString("nbs", s.DBDir, "feature")
+	stdout, _ := s.MustRun(main, []string{"rebase", featureSpec, "base"})
+	s.Contains(stdout, "New head #")
+	s.Equal(`map {
+  "a": 10,
+  "b": 2,
+  "c": 3,
+}`, types.EncodedValue(s.headValue("feature")))
+
+	stdout, _ = s.MustRun(main, []string{"rebase", featureSpec, "base"})
+	s.Equal("feature is up to date with base\n", stdout)
+}
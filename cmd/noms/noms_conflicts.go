@@ -0,0 +1,134 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/attic-labs/kingpin"
+
+	"github.com/attic-labs/noms/cmd/util"
+	"github.com/attic-labs/noms/go/config"
+	"github.com/attic-labs/noms/go/d"
+	"github.com/attic-labs/noms/go/datas"
+	"github.com/attic-labs/noms/go/spec"
+	"github.com/attic-labs/noms/go/types"
+)
+
+func nomsConflicts(noms *kingpin.Application) (*kingpin.CmdClause, util.KingpinHandler) {
+	conflicts := noms.Command("conflicts", "Inspect and resolve the conflicts of a merge written by 'noms merge --record-conflicts'.")
+
+	list := conflicts.Command("list", "lists the conflicts of a merge in progress")
+	listCommit := list.Arg("commit", "absolute path to the merge in progress - see Spelling Objects at https://github.com/attic-labs/noms/blob/master/doc/spelling.md").Required().String()
+
+	resolve := conflicts.Command("resolve", "resolves a conflict and writes the updated merge in progress")
+	resolveCommit := resolve.Arg("commit", "absolute path to the merge in progress").Required().String()
+	resolvePath := resolve.Arg("path", "path of the conflict, as shown by 'noms conflicts list'").Required().String()
+	resolveValue := resolve.Arg("value", "'ours', 'theirs' or 'base' to take one of the conflicting values, 'none' to remove the value, or a value in the same syntax as 'noms map set'").Required().String()
+
+	commit := conflicts.Command("commit", "writes the merge commit once all conflicts are resolved")
+	commitCommit := commit.Arg("commit", "absolute path to the merge in progress").Required().String()
+	message := commit.Flag("message", "commit message").String()
+
+	return conflicts, func(input string) int {
+		switch input {
+		case list.FullCommand():
+			return nomsConflictsList(*listCommit)
+		case resolve.FullCommand():
+			return nomsConflictsResolve(*resolveCommit, *resolvePath, *resolveValue)
+		case commit.FullCommand():
+			return nomsConflictsCommit(*commitCommit, *message)
+		}
+		d.Panic("notreached")
+		return 1
+	}
+}
+
+func nomsConflictsList(commit string) int {
+	db, mip := readMergeInProgress(commit)
+	defer db.Close()
+
+	describe := func(v types.Value) string {
+		if v == nil {
+			return "<none>"
+		}
+		return types.EncodedValue(v)
+	}
+	for _, c := range mip.Conflicts {
+		if c.Resolved {
+			fmt.Printf("%s (resolved)\n", c.Path)
+		} else {
+			fmt.Printf("%s (unresolved)\n", c.Path)
+		}
+		fmt.Printf("  base:   %s\n  ours:   %s\n  theirs: %s\n", describe(c.Base), describe(c.Ours), describe(c.Theirs))
+		if c.Resolved {
+			fmt.Printf("  resolution: %s\n", describe(c.Resolution))
+		}
+	}
+	fmt.Printf("%d of %d conflicts unresolved\n", mip.Unresolved(), len(mip.Conflicts))
+	return 0
+}
+
+func nomsConflictsResolve(commit, pathStr, valueStr string) int {
+	db, mip := readMergeInProgress(commit)
+	defer db.Close()
+
+	path, err := types.ParsePath(pathStr)
+	d.CheckErrorNoUsage(err)
+
+	var v types.Value
+	switch valueStr {
+	case "ours", "theirs", "base":
+		found := false
+		for _, c := range mip.Conflicts {
+			if c.Path.String() == path.String() {
+				v = map[string]types.Value{"ours": c.Ours, "theirs": c.Theirs, "base": c.Base}[valueStr]
+				found = true
+				break
+			}
+		}
+		checkIfTrue(!found, "No conflict at %s", pathStr)
+	case "none":
+	default:
+		v, err = argumentToValue(valueStr, db)
+		d.CheckErrorNoUsage(err)
+	}
+
+	mip, err = mip.Resolve(db, path, v)
+	d.CheckErrorNoUsage(err)
+	r := mip.Write(db)
+	db.Flush()
+	fmt.Println(r.TargetHash())
+	return 0
+}
+
+func nomsConflictsCommit(commit, message string) int {
+	db, mip := readMergeInProgress(commit)
+	defer db.Close()
+
+	var meta types.Struct
+	if message != "" {
+		var err error
+		meta, err = spec.CreateCommitMetaStruct(db, "", message, nil, nil)
+		d.CheckErrorNoUsage(err)
+	}
+	r, err := mip.Finish(db, meta)
+	checkIfTrue(err == datas.ErrUnresolvedConflicts, "%d conflicts are unresolved", mip.Unresolved())
+	d.CheckErrorNoUsage(err)
+	db.Flush()
+	fmt.Println(r.TargetHash())
+	return 0
+}
+
+func readMergeInProgress(commit string) (datas.Database, datas.MergeInProgress) {
+	cfg := config.NewResolver()
+	db, v, err := cfg.GetPath(commit)
+	d.CheckErrorNoUsage(err)
+	checkIfTrue(v == nil, "Error resolving value: %s", commit)
+
+	mip, err := datas.ReadMergeInProgress(db, types.NewRef(v))
+	d.CheckErrorNoUsage(err)
+	return db, mip
+}
@@ -0,0 +1,53 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package main
+
+import (
+	"strings"
+
+	"github.com/attic-labs/noms/go/spec"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/attic-labs/noms/go/util/clienttest"
+)
+
+func (s *nomsMergeTestSuite) TestNomsConflicts() {
+	left, right := "left", "right"
+	parentSpec := s.spec("parent")
+	defer parentSpec.Close()
+	leftSpec := s.spec(left)
+	defer leftSpec.Close()
+	rightSpec := s.spec(right)
+	defer rightSpec.Close()
+
+	p := s.setupMergeDataset(parentSpec, types.StructData{"num": types.Number(42), "str": types.String("foo")}, types.NewSet(parentSpec.GetDatabase()))
+	l := s.setupMergeDataset(leftSpec, types.StructData{"num": types.Number(43), "str": types.String("bar")}, types.NewSet(leftSpec.GetDatabase(), p))
+	r := s.setupMergeDataset(rightSpec, types.StructData{"num": types.Number(44), "str": types.String("foo")}, types.NewSet(rightSpec.GetDatabase(), p))
+
+	stdout, stderr := s.MustRun(main, []string{"merge", "-q", "--record-conflicts", s.DBDir, left, right})
+	s.Contains(stderr, "Recorded 1 conflicts")
+	mip := spec.CreateValueSpecString("nbs", s.DBDir, "#"+strings.TrimSpace(stdout))
+
+	stdout, _ = s.MustRun(main, []string{"conflicts", "list", mip})
+	s.Equal(".num (unresolved)\n  base:   42\n  ours:   43\n  theirs: 44\n1 of 1 conflicts unresolved\n", stdout)
+
+	_, _, recovered := s.Run(main, []string{"conflicts", "commit", mip})
+	s.IsType(clienttest.ExitError{}, recovered)
+	_, _, recovered = s.Run(main, []string{"conflicts", "resolve", mip, ".str", "ours"})
+	s.IsType(clienttest.ExitError{}, recovered)
+
+	stdout, _ = s.MustRun(main, []string{"conflicts", "resolve", mip, ".num", "theirs"})
+	mip = spec.CreateValueSpecString("nbs", s.DBDir, "#"+strings.TrimSpace(stdout))
+	stdout, _ = s.MustRun(main, []string{"conflicts", "list", mip})
+	s.Contains(stdout, ".num (resolved)\n")
+	s.Contains(stdout, "  resolution: 44\n")
+
+	stdout, _ = s.MustRun(main, []string{"conflicts", "commit", "--message", "merged", mip})
+	s.validateOutput(stdout, types.NewStruct("", types.StructData{"num": types.Number(44), "str": types.String("bar")}), l, r)
+
+	// Without conflicts, the merge commit is written straight away.
+	stdout, stderr = s.MustRun(main, []string{"merge", "-q", "--record-conflicts", s.DBDir, left, "parent"})
+	s.Equal("", stderr)
+	s.validateOutput(stdout, types.NewStruct("", types.StructData{"num": types.Number(43), "str": types.String("bar")}), l, p)
+}
@@ -5,19 +5,33 @@
 package main
 
 import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
 
 	"github.com/attic-labs/kingpin"
 	"github.com/attic-labs/noms/cmd/util"
 	"github.com/attic-labs/noms/go/config"
 	"github.com/attic-labs/noms/go/d"
 	"github.com/attic-labs/noms/go/diff"
+	"github.com/attic-labs/noms/go/types"
+	nomsjson "github.com/attic-labs/noms/go/util/json"
 	"github.com/attic-labs/noms/go/util/outputpager"
 )
 
 func nomsDiff(noms *kingpin.Application) (*kingpin.CmdClause, util.KingpinHandler) {
 	cmd := noms.Command("diff", "Shows the difference between two values.")
 	stat := cmd.Flag("stat", "writes a summary of the changes instead").Bool()
+	format := cmd.Flag("format", "output format - json, ndjson and csv write a record for each difference, or the summary with --stat").Default("text").Enum("text", "json", "ndjson", "csv")
+	moves := cmd.Flag("moves", "shows values that moved to another key, index or field as moves rather than removals and additions").Bool()
+	moveSimilarity := cmd.Flag("move-similarity", "with --moves, also shows structs with this fraction of equal fields as moves").Default("0").Float64()
+	patchOut := cmd.Flag("patch-out", "writes the changes as a JSON patch to this file, or to stdout with --patch-out=- - see noms patch apply").String()
+	patchCommit := cmd.Flag("patch-commit", "commits the changes as a patch value to this dataset, which should be in the same database as val2 - see noms patch apply").String()
 	o1 := cmd.Arg("val1", "first value - see Spelling Values at https://github.com/attic-labs/noms/blob/master/doc/spelling.md").Required().String()
 	o2 := cmd.Arg("val2", "second value - see Spelling Values at https://github.com/attic-labs/noms/blob/master/doc/spelling.md").Required().String()
 	outputpager.RegisterOutputpagerFlags(cmd)
@@ -39,14 +53,163 @@ func nomsDiff(noms *kingpin.Application) (*kingpin.CmdClause, util.KingpinHandle
 		defer db2.Close()
 
 		if *stat {
-			diff.Summary(value1, value2)
+			if *format == "text" {
+				diff.Summary(value1, value2)
+			} else {
+				d.CheckErrorNoUsage(writeDiffStats(os.Stdout, *format, diff.Summarize(value1, value2)))
+			}
+			return 0
+		}
+
+		if *patchOut != "" || *patchCommit != "" {
+			patch := diff.NewPatch(value1, value2)
+			if *patchOut != "" {
+				w := io.Writer(os.Stdout)
+				if *patchOut != "-" {
+					f, err := os.Create(*patchOut)
+					d.CheckErrorNoUsage(err)
+					defer f.Close()
+					w = f
+				}
+				d.CheckErrorNoUsage(diff.WritePatchJSON(w, patch))
+			}
+			if *patchCommit != "" {
+				db, ds, err := cfg.GetDataset(*patchCommit)
+				d.CheckErrorNoUsage(err)
+				defer db.Close()
+				_, err = db.CommitValue(ds, diff.PatchToValue(db, patch))
+				d.CheckErrorNoUsage(err)
+			}
 			return 0
 		}
 
 		pgr := outputpager.Start()
 		defer pgr.Stop()
 
-		diff.PrintDiff(pgr.Writer, value1, value2, false)
+		opts := diff.Options{DetectMoves: *moves, MoveSimilarity: *moveSimilarity}
+		if *format == "text" {
+			diff.PrintDiffWithOptions(pgr.Writer, value1, value2, opts)
+		} else {
+			d.CheckErrorNoUsage(writeDiffRecords(pgr.Writer, *format, value1, value2, opts))
+		}
 		return 0
 	}
 }
+
+// diffRecord is what the json, ndjson and csv formats write for each
+// Difference. Values are JSON, or Noms text format strings if they can't be
+// written as JSON, e.g. because they're named structs. FromPath is only set
+// for moves.
+type diffRecord struct {
+	Path       string          `json:"path"`
+	ChangeType string          `json:"changeType"`
+	OldValue   json.RawMessage `json:"oldValue,omitempty"`
+	NewValue   json.RawMessage `json:"newValue,omitempty"`
+	FromPath   string          `json:"fromPath,omitempty"`
+}
+
+func writeDiffRecords(w io.Writer, format string, v1, v2 types.Value, opts diff.Options) error {
+	dChan := make(chan diff.Difference, 16)
+	stopChan := make(chan struct{})
+	go func() {
+		diff.DiffWithOptions(v1, v2, dChan, stopChan, opts)
+		close(dChan)
+	}()
+	stopDiff := func() {
+		close(stopChan)
+		for range dChan {
+		}
+	}
+
+	var write func(r diffRecord) error
+	var finish func() error
+	switch format {
+	case "json":
+		sep := "["
+		write = func(r diffRecord) error {
+			b, err := json.Marshal(r)
+			if err == nil {
+				_, err = fmt.Fprintf(w, "%s\n  %s", sep, b)
+			}
+			sep = ","
+			return err
+		}
+		finish = func() error {
+			if sep == "[" {
+				_, err := io.WriteString(w, "[]\n")
+				return err
+			}
+			_, err := io.WriteString(w, "\n]\n")
+			return err
+		}
+	case "ndjson":
+		enc := json.NewEncoder(w)
+		write = func(r diffRecord) error { return enc.Encode(r) }
+		finish = func() error { return nil }
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"path", "changeType", "oldValue", "newValue", "fromPath"})
+		write = func(r diffRecord) error {
+			return cw.Write([]string{r.Path, r.ChangeType, string(r.OldValue), string(r.NewValue), r.FromPath})
+		}
+		finish = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	}
+
+	for dif := range dChan {
+		r := diffRecord{Path: dif.Path.String(), ChangeType: diff.ChangeTypeString(dif.ChangeType)}
+		if dif.ChangeType == types.DiffChangeMoved {
+			r.FromPath = dif.FromPath.String()
+		}
+		if dif.OldValue != nil {
+			r.OldValue = diffRecordValue(dif.OldValue)
+		}
+		if dif.NewValue != nil {
+			r.NewValue = diffRecordValue(dif.NewValue)
+		}
+		if err := write(r); err != nil {
+			stopDiff()
+			return err
+		}
+	}
+	return finish()
+}
+
+func diffRecordValue(v types.Value) json.RawMessage {
+	buf := &bytes.Buffer{}
+	opts := nomsjson.ToOptions{Lists: true, Maps: true, Sets: true, Structs: true, Tuples: true}
+	if err := nomsjson.ToJSON(v, buf, opts); err == nil {
+		return bytes.TrimSpace(buf.Bytes())
+	}
+	b, err := json.Marshal(types.EncodedValue(v))
+	d.PanicIfError(err)
+	return b
+}
+
+func writeDiffStats(w io.Writer, format string, stats diff.Stats) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(stats)
+	case "ndjson":
+		return json.NewEncoder(w).Encode(stats)
+	}
+
+	paths := make([]string, 0, len(stats.Paths))
+	for p := range stats.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"path", "adds", "removes", "changes"})
+	for _, p := range paths {
+		ps := stats.Paths[p]
+		cw.Write([]string{p, strconv.FormatUint(ps.Adds, 10), strconv.FormatUint(ps.Removes, 10), strconv.FormatUint(ps.Changes, 10)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
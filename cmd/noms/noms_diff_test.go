@@ -73,3 +73,64 @@ func (s *nomsDiffTestSuite) TestNomsDiffStat() {
 	out, _ = s.MustRun(main, []string{"diff", "--stat", r3, r4})
 	s.Contains(out, "1 insertion (25.00%), 2 deletions (50.00%), 0 changes (0.00%), (4 values vs 3 values)")
 }
+
+func (s *nomsDiffTestSuite) TestNomsDiffFormats() {
+	sp, err := spec.ForDataset(spec.CreateValueSpecString("nbs", s.DBDir, "diffFormatTest"))
+	s.NoError(err)
+	defer sp.Close()
+
+	db := sp.GetDatabase()
+	ds, err := db.CommitValue(sp.GetDataset(), types.NewStruct("", types.StructData{
+		"a": types.Number(1),
+		"l": types.NewList(db, types.String("x"), types.String("y")),
+		"p": types.NewStruct("Person", types.StructData{"name": types.String("bob")}),
+	}))
+	s.NoError(err)
+	r1 := spec.CreateHashSpecString("nbs", s.DBDir, ds.HeadRef().TargetHash()) + ".value"
+
+	ds, err = db.CommitValue(ds, types.NewStruct("", types.StructData{
+		"a": types.Number(2),
+		"l": types.NewList(db, types.String("x"), types.String("y"), types.String("z")),
+		"q": types.NewStruct("Person", types.StructData{"name": types.String("bob")}),
+	}))
+	s.NoError(err)
+	r2 := spec.CreateHashSpecString("nbs", s.DBDir, ds.HeadRef().TargetHash()) + ".value"
+
+	out, _ := s.MustRun(main, []string{"diff", "--format", "json", r1, r2})
+	s.Equal(`[
+  {"path":".a","changeType":"modified","oldValue":1,"newValue":2},
+  {"path":".l[2]","changeType":"added","newValue":"z"},
+  {"path":".p","changeType":"removed","oldValue":"struct Person {\n  name: \"bob\",\n}"},
+  {"path":".q","changeType":"added","newValue":"struct Person {\n  name: \"bob\",\n}"}
+]
+`, out)
+
+	out, _ = s.MustRun(main, []string{"diff", "--format", "ndjson", r1, r2})
+	s.Equal(`{"path":".a","changeType":"modified","oldValue":1,"newValue":2}
+{"path":".l[2]","changeType":"added","newValue":"z"}
+{"path":".p","changeType":"removed","oldValue":"struct Person {\n  name: \"bob\",\n}"}
+{"path":".q","changeType":"added","newValue":"struct Person {\n  name: \"bob\",\n}"}
+`, out)
+
+	out, _ = s.MustRun(main, []string{"diff", "--format", "csv", r1, r2})
+	s.True(strings.HasPrefix(out, "path,changeType,oldValue,newValue,fromPath\n.a,modified,1,2,\n.l[2],added,,\"\"\"z\"\"\",\n"), out)
+
+	out, _ = s.MustRun(main, []string{"diff", "--format", "json", r1, r1})
+	s.Equal("[]\n", out)
+
+	out, _ = s.MustRun(main, []string{"diff", "--moves", "--format", "ndjson", r1, r2})
+	s.Equal(`{"path":".a","changeType":"modified","oldValue":1,"newValue":2}
+{"path":".l[2]","changeType":"added","newValue":"z"}
+{"path":".q","changeType":"moved","oldValue":"struct Person {\n  name: \"bob\",\n}","newValue":"struct Person {\n  name: \"bob\",\n}","fromPath":".p"}
+`, out)
+
+	out, _ = s.MustRun(main, []string{"diff", "--moves", r1, r2})
+	s.Contains(out, ">   p -> q: struct Person {\n")
+
+	out, _ = s.MustRun(main, []string{"diff", "--stat", "--format", "ndjson", r1, r2})
+	s.Equal(`{"adds":1,"removes":1,"changes":2,"oldSize":3,"newSize":3,"paths":{"":{"adds":1,"removes":1,"changes":1},".l":{"adds":1,"removes":0,"changes":0}}}
+`, out)
+
+	out, _ = s.MustRun(main, []string{"diff", "--stat", "--format", "csv", r1, r2})
+	s.Equal("path,adds,removes,changes\n,1,1,1\n.l,1,0,0\n", out)
+}
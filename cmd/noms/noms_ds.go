@@ -11,17 +11,45 @@ import (
 	"github.com/attic-labs/noms/cmd/util"
 	"github.com/attic-labs/noms/go/config"
 	"github.com/attic-labs/noms/go/d"
+	"github.com/attic-labs/noms/go/datas"
+	"github.com/attic-labs/noms/go/nomdl"
+	"github.com/attic-labs/noms/go/spec"
 	"github.com/attic-labs/noms/go/types"
 )
 
 func nomsDs(noms *kingpin.Application) (*kingpin.CmdClause, util.KingpinHandler) {
 	cmd := noms.Command("ds", "Dataset management.")
 	del := cmd.Flag("delete", "delete a dataset").Short('d').Bool()
+	schema := cmd.Flag("schema", "show the schema of a dataset or, if a type is given, set it").Bool()
+	message := cmd.Flag("message", "commit message to use when setting a schema").String()
 	name := cmd.Arg("name", "name of the database to list or dataset to delete - see Spelling Objects at https://github.com/attic-labs/noms/blob/master/doc/spelling.md").String()
+	typ := cmd.Arg("type", "nomdl type that the dataset's values must conform to (with --schema), e.g. 'Map<String, Number>'").String()
 
 	return cmd, func(input string) int {
 		cfg := config.NewResolver()
-		if *del {
+		if *schema {
+			db, set, err := cfg.GetDataset(*name)
+			d.CheckError(err)
+			defer db.Close()
+
+			if *typ == "" {
+				t, ok := set.MaybeSchema()
+				if !ok {
+					d.CheckErrorNoUsage(fmt.Errorf("Dataset %v has no schema", set.ID()))
+				}
+				fmt.Println(t.Describe())
+				return 0
+			}
+
+			t, err := nomdl.ParseType(*typ)
+			d.CheckErrorNoUsage(err)
+			meta, err := spec.CreateCommitMetaStruct(db, "", *message, nil, nil)
+			d.CheckErrorNoUsage(err)
+			set, err = datas.SetSchema(set, t, meta)
+			d.CheckErrorNoUsage(err)
+
+			fmt.Printf("Set schema of %v (new head #%v)\n", set.ID(), set.HeadRef().TargetHash().String())
+		} else if *del {
 			db, set, err := cfg.GetDataset(*name)
 			d.CheckError(err)
 			defer db.Close()
@@ -83,3 +83,31 @@ func (s *nomsDsTestSuite) TestNomsDs() {
 	rtnVal, _ = s.MustRun(main, []string{"ds", dbSpec})
 	s.Equal("", rtnVal)
 }
+
+func (s *nomsDsTestSuite) TestNomsDsSchema() {
+	dir := s.DBDir
+
+	cs := nbs.NewLocalStore(dir, clienttest.DefaultMemTableSize)
+	db := datas.NewDatabase(cs)
+
+	id := "testdataset"
+	set := db.GetDataset(id)
+	set, err := db.CommitValue(set, types.NewList(db, types.Number(1)))
+	s.NoError(err)
+	s.NoError(db.Close())
+
+	datasetName := spec.CreateValueSpecString("nbs", dir, id)
+
+	_, _, recovered := s.Run(main, []string{"ds", "--schema", datasetName})
+	s.IsType(clienttest.ExitError{}, recovered)
+
+	rtnVal, _ := s.MustRun(main, []string{"ds", "--schema", datasetName, "List<Number>"})
+	s.Contains(rtnVal, "Set schema of testdataset")
+
+	rtnVal, _ = s.MustRun(main, []string{"ds", "--schema", datasetName})
+	s.Equal("List<Number>\n", rtnVal)
+
+	_, stderr, recovered := s.Run(main, []string{"ds", "--schema", datasetName, "List<String>"})
+	s.IsType(clienttest.ExitError{}, recovered)
+	s.Contains(stderr, "value does not match schema List<String> at .value[0]")
+}
@@ -0,0 +1,107 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/attic-labs/kingpin"
+
+	"github.com/attic-labs/noms/cmd/util"
+	"github.com/attic-labs/noms/go/config"
+	"github.com/attic-labs/noms/go/d"
+	"github.com/attic-labs/noms/go/datas"
+	"github.com/attic-labs/noms/go/spec"
+	"github.com/attic-labs/noms/go/types"
+)
+
+type pathEditFunc func(root types.Value, p types.Path, db datas.Database) (types.Value, error)
+
+func nomsEdit(noms *kingpin.Application) (*kingpin.CmdClause, util.KingpinHandler) {
+	edit := noms.Command("edit", "Edits the value at a path and commits the result.")
+	message := edit.Flag("message", "commit message").String()
+	date := edit.Flag("date", "commit date formatted as 2019-08-08T21:52:46Z - defaults to current date").String()
+
+	editSet := edit.Command("set", "sets the value at a path, e.g. db::ds.value.people[0].name")
+	setSpec := editSet.Arg("spec", "path spec of the value to set - see Spelling Values at https://github.com/attic-labs/noms/blob/master/doc/spelling.md").Required().String()
+	setValue := editSet.Arg("value", "the new value").Required().String()
+
+	editRemove := edit.Command("remove", "removes the value at a path, e.g. db::ds.value.people[0]")
+	removeSpec := editRemove.Arg("spec", "path spec of the value to remove - see Spelling Values at https://github.com/attic-labs/noms/blob/master/doc/spelling.md").Required().String()
+
+	return edit, func(input string) int {
+		switch input {
+		case editSet.FullCommand():
+			return nomsEditPath(*setSpec, *message, *date, func(root types.Value, p types.Path, db datas.Database) (types.Value, error) {
+				nv, err := argumentToValue(*setValue, db)
+				if err != nil {
+					return nil, err
+				}
+				return types.SetAtPath(root, p, nv, db)
+			})
+		case editRemove.FullCommand():
+			return nomsEditPath(*removeSpec, *message, *date, func(root types.Value, p types.Path, db datas.Database) (types.Value, error) {
+				return types.RemoveAtPath(root, p, db)
+			})
+		}
+		d.Panic("notreached")
+		return 1
+	}
+}
+
+// nomsEditPath applies edit to the value spec at specStr. If the spec is rooted
+// at a dataset, the edited value is committed as its new head. If it's rooted
+// at a hash, the edited root is written and its new path is printed.
+func nomsEditPath(specStr, message, date string, edit pathEditFunc) int {
+	cfg := config.NewResolver()
+	sp, err := spec.ForPath(cfg.ResolvePathSpec(specStr))
+	d.CheckErrorNoUsage(err)
+	defer sp.Close()
+
+	db := sp.GetDatabase()
+	absPath := sp.Path
+
+	if absPath.Dataset == "" {
+		rootVal := db.ReadValue(absPath.Hash)
+		if rootVal == nil {
+			d.CheckErrorNoUsage(fmt.Errorf("No value at: %s", specStr))
+		}
+		newRootVal, err := edit(rootVal, absPath.Path, db)
+		d.CheckErrorNoUsage(err)
+		r := db.WriteValue(newRootVal)
+		db.Flush()
+		fmt.Println(spec.AbsolutePath{Hash: r.TargetHash()}.String())
+		return 0
+	}
+
+	p := absPath.Path
+	if len(p) == 0 || !isValueField(p[0]) {
+		d.CheckErrorNoUsage(fmt.Errorf("Can only edit the value of a dataset, e.g. %s.value", absPath.Dataset))
+	}
+
+	ds := db.GetDataset(absPath.Dataset)
+	oldCommitRef, ok := ds.MaybeHeadRef()
+	if !ok {
+		d.CheckErrorNoUsage(fmt.Errorf("Dataset %s has no head", absPath.Dataset))
+	}
+
+	newVal, err := edit(ds.HeadValue(), p[1:], db)
+	d.CheckErrorNoUsage(err)
+
+	meta, err := spec.CreateCommitMetaStruct(db, date, message, nil, nil)
+	d.CheckErrorNoUsage(err)
+
+	ds, err = db.Commit(ds, newVal, datas.CommitOptions{Meta: meta})
+	d.CheckErrorNoUsage(err)
+
+	fmt.Fprintf(os.Stdout, "New head #%v (was #%v)\n", ds.HeadRef().TargetHash().String(), oldCommitRef.TargetHash().String())
+	return 0
+}
+
+func isValueField(part types.PathPart) bool {
+	fp, ok := part.(types.FieldPath)
+	return ok && fp.Name == datas.ValueField
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package main
+
+import (
+	"testing"
+
+	"github.com/attic-labs/noms/go/spec"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/attic-labs/noms/go/util/clienttest"
+	"github.com/stretchr/testify/suite"
+)
+
+type nomsEditTestSuite struct {
+	clienttest.ClientTestSuite
+}
+
+func TestNomsEdit(t *testing.T) {
+	suite.Run(t, &nomsEditTestSuite{})
+}
+
+func (s *nomsEditTestSuite) setupDataset(name string) string {
+	sp, err := spec.ForDataset(spec.CreateValueSpecString("nbs", s.DBDir, name))
+	s.NoError(err)
+	defer sp.Close()
+
+	db := sp.GetDatabase()
+	v := types.NewStruct("", types.StructData{
+		"people": types.NewList(db,
+			types.NewStruct("Person", types.StructData{"name": types.String("alice"), "age": types.Number(30)}),
+			types.NewStruct("Person", types.StructData{"name": types.String("bob"), "age": types.Number(40)}),
+		),
+	})
+	_, err = db.CommitValue(sp.GetDataset(), v)
+	s.NoError(err)
+	return sp.String()
+}
+
+func (s *nomsEditTestSuite) headValue(dsSpec string) types.Value {
+	sp, err := spec.ForDataset(dsSpec)
+	s.NoError(err)
+	defer sp.Close()
+	return sp.GetDataset().HeadValue()
+}
+
+func (s *nomsEditTestSuite) TestNomsEditSet() {
+	dsSpec := s.setupDataset("editSet")
+
+	stdout, stderr := s.MustRun(main, []string{"edit", "set", "--message", "renamed", dsSpec + ".value.people[0].name", "carol"})
+	s.Empty(stderr)
+	s.Contains(stdout, "New head #")
+
+	sp, err := spec.ForDataset(dsSpec)
+	s.NoError(err)
+	defer sp.Close()
+	head := sp.GetDataset().Head()
+	s.Equal("renamed", string(head.Get("meta").(types.Struct).Get("message").(types.String)))
+	s.Equal(1, int(head.Get("parents").(types.Set).Len()))
+
+	p := types.MustParsePath(".people[0].name")
+	s.True(types.String("carol").Equals(p.Resolve(s.headValue(dsSpec), nil)))
+}
+
+func (s *nomsEditTestSuite) TestNomsEditSetMultiValued() {
+	dsSpec := s.setupDataset("editSetMulti")
+
+	s.MustRun(main, []string{"edit", "set", dsSpec + ".value.people[*].age", "50"})
+
+	v := s.headValue(dsSpec)
+	s.True(types.Number(50).Equals(types.MustParsePath(".people[0].age").Resolve(v, nil)))
+	s.True(types.Number(50).Equals(types.MustParsePath(".people[1].age").Resolve(v, nil)))
+}
+
+func (s *nomsEditTestSuite) TestNomsEditRemove() {
+	dsSpec := s.setupDataset("editRemove")
+
+	stdout, stderr := s.MustRun(main, []string{"edit", "remove", dsSpec + ".value.people[0]"})
+	s.Empty(stderr)
+	s.Contains(stdout, "New head #")
+
+	people := types.MustParsePath(".people").Resolve(s.headValue(dsSpec), nil).(types.List)
+	s.Equal(uint64(1), people.Len())
+	s.True(types.String("bob").Equals(people.Get(0).(types.Struct).Get("name")))
+}
+
+func (s *nomsEditTestSuite) TestNomsEditErrors() {
+	dsSpec := s.setupDataset("editErrors")
+
+	_, _, err := s.Run(main, []string{"edit", "remove", dsSpec + ".value.people[5]"})
+	s.NotNil(err)
+	_, _, err = s.Run(main, []string{"edit", "set", dsSpec + ".meta.date", "now"})
+	s.NotNil(err)
+	_, _, err = s.Run(main, []string{"edit", "set", dsSpec + `[?(.name == "bob")].age`, "1"})
+	s.NotNil(err)
+}
@@ -14,16 +14,23 @@ import (
 	"github.com/attic-labs/noms/cmd/util"
 	"github.com/attic-labs/noms/go/config"
 	"github.com/attic-labs/noms/go/d"
+	"github.com/attic-labs/noms/go/datas"
+	"github.com/attic-labs/noms/go/spec"
+	"github.com/attic-labs/noms/go/types"
 	"github.com/attic-labs/noms/go/util/json"
 )
 
 func nomsJSON(noms *kingpin.Application) (*kingpin.CmdClause, util.KingpinHandler) {
 	// noms json in <db-spec> <file-or->
 	// noms json out <path-spec> <file-or->
+	// noms json patch apply <dataset-spec> <file-or->
+	// noms json patch diff <path-spec> <path-spec> <file-or->
 	jsonCmd := noms.Command("json", "Import or export JSON.")
 
 	jsonIn := jsonCmd.Command("in", "imports data into Noms from JSON")
 	structsIn := jsonIn.Flag("structs", "JSON objects will be imported to structs, otherwise maps").Bool()
+	intsIn := jsonIn.Flag("ints", "JSON integers will be imported to Ints or Uints, otherwise Numbers").Bool()
+	decimalsIn := jsonIn.Flag("decimals", "JSON numbers will be imported to Decimals, otherwise Numbers").Bool()
 	toDB := jsonIn.Arg("to", "Database spec to import to").Required().String()
 	fromFile := jsonIn.Arg("from", "File to import from, or '@' to import from stdin").Required().String()
 
@@ -32,13 +39,38 @@ func nomsJSON(noms *kingpin.Application) (*kingpin.CmdClause, util.KingpinHandle
 	fromPath := jsonOut.Arg("path", "Absolute path to value to export").Required().String()
 	toFile := jsonOut.Arg("to", "File to export to, or '@' to export to stdout").Required().String()
 	indent := jsonOut.Flag("indent", "Number of spaces to indent when pretty-printing").Default("\t").String()
+	decimalStrings := jsonOut.Flag("decimal-strings", "Export Noms decimals as JSON strings, otherwise numbers").Bool()
+
+	jsonPatch := jsonCmd.Command("patch", "converts between Noms diffs and JSON Patches (RFC 6902) or Merge Patches (RFC 7386)")
+
+	patchApply := jsonPatch.Command("apply", "applies a JSON Patch to the head value of a dataset and commits the result")
+	merge := patchApply.Flag("merge", "The patch is a JSON Merge Patch, otherwise a JSON Patch").Bool()
+	structsApply := patchApply.Flag("structs", "JSON objects in the patch will be imported to structs, otherwise maps").Bool()
+	intsApply := patchApply.Flag("ints", "JSON integers in the patch will be imported to Ints or Uints, otherwise Numbers").Bool()
+	decimalsApply := patchApply.Flag("decimals", "JSON numbers in the patch will be imported to Decimals, otherwise Numbers").Bool()
+	message := patchApply.Flag("message", "commit message").String()
+	date := patchApply.Flag("date", "commit date formatted as 2019-08-08T21:52:46Z - defaults to current date").String()
+	patchDS := patchApply.Arg("dataset", "Dataset spec to apply the patch to").Required().String()
+	patchFrom := patchApply.Arg("from", "File to read the patch from, or '@' to read it from stdin").Required().String()
+
+	patchDiff := jsonPatch.Command("diff", "writes a JSON Patch that turns one value into another")
+	structsDiff := patchDiff.Flag("structs", "Enable export of Noms structs (to JSON objects)").Default("false").Bool()
+	diffIndent := patchDiff.Flag("indent", "Number of spaces to indent when pretty-printing").Default("\t").String()
+	diffDecimalStrings := patchDiff.Flag("decimal-strings", "Export Noms decimals as JSON strings, otherwise numbers").Bool()
+	diffFrom := patchDiff.Arg("val1", "Absolute path to the value to patch").Required().String()
+	diffTo := patchDiff.Arg("val2", "Absolute path to the value the patch turns val1 into").Required().String()
+	patchTo := patchDiff.Arg("to", "File to write the patch to, or '@' to write it to stdout").Required().String()
 
 	return jsonCmd, func(input string) int {
 		switch input {
 		case jsonIn.FullCommand():
-			return nomsJSONIn(*fromFile, *toDB, json.FromOptions{Structs: *structsIn})
+			return nomsJSONIn(*fromFile, *toDB, json.FromOptions{Structs: *structsIn, Ints: *intsIn, Decimals: *decimalsIn})
 		case jsonOut.FullCommand():
-			return nomsJSONOut(*fromPath, *toFile, json.ToOptions{Lists: true, Maps: true, Sets: true, Structs: *structsOut, Indent: *indent})
+			return nomsJSONOut(*fromPath, *toFile, json.ToOptions{Lists: true, Maps: true, Sets: true, Tuples: true, Structs: *structsOut, Indent: *indent, DecimalStrings: *decimalStrings})
+		case patchApply.FullCommand():
+			return nomsJSONPatchApply(*patchDS, *patchFrom, *merge, *message, *date, json.FromOptions{Structs: *structsApply, Ints: *intsApply, Decimals: *decimalsApply})
+		case patchDiff.FullCommand():
+			return nomsJSONPatchDiff(*diffFrom, *diffTo, *patchTo, json.ToOptions{Lists: true, Maps: true, Tuples: true, Structs: *structsDiff, DecimalStrings: *diffDecimalStrings}, *diffIndent)
 		}
 		d.Panic("notreached")
 		return 1
@@ -86,3 +118,80 @@ func nomsJSONOut(from, to string, opts json.ToOptions) int {
 	d.CheckErrorNoUsage(err)
 	return 0
 }
+
+func nomsJSONPatchApply(dsStr, from string, merge bool, message, date string, opts json.FromOptions) int {
+	cfg := config.NewResolver()
+	db, ds, err := cfg.GetDataset(dsStr)
+	d.CheckErrorNoUsage(err)
+	defer db.Close()
+
+	oldCommitRef, ok := ds.MaybeHeadRef()
+	if !ok {
+		d.CheckErrorNoUsage(fmt.Errorf("Dataset %s has no head", ds.ID()))
+	}
+
+	var r io.ReadCloser
+	if from == "@" {
+		r = os.Stdin
+	} else {
+		r, err = os.Open(from)
+		d.CheckErrorNoUsage(err)
+	}
+	defer r.Close()
+
+	head := ds.HeadValue()
+	var newVal types.Value
+	if merge {
+		newVal, err = json.MergePatch(db, head, r, opts)
+	} else {
+		var p json.Patch
+		if p, err = json.ReadPatch(r); err == nil {
+			newVal, err = json.ApplyPatch(db, head, p, opts)
+		}
+	}
+	d.CheckErrorNoUsage(err)
+	if newVal.Equals(head) {
+		fmt.Println("Nothing to apply")
+		return 0
+	}
+
+	meta, err := spec.CreateCommitMetaStruct(db, date, message, nil, nil)
+	d.CheckErrorNoUsage(err)
+	ds, err = db.Commit(ds, newVal, datas.CommitOptions{Meta: meta})
+	d.CheckErrorNoUsage(err)
+
+	fmt.Printf("New head #%v (was #%v)\n", ds.HeadRef().TargetHash().String(), oldCommitRef.TargetHash().String())
+	return 0
+}
+
+func nomsJSONPatchDiff(from, to, out string, opts json.ToOptions, indent string) int {
+	cfg := config.NewResolver()
+	db1, val1, err := cfg.GetPath(from)
+	d.CheckErrorNoUsage(err)
+	if val1 == nil {
+		d.CheckErrorNoUsage(fmt.Errorf("Value not found: %s", from))
+	}
+	defer db1.Close()
+
+	db2, val2, err := cfg.GetPath(to)
+	d.CheckErrorNoUsage(err)
+	if val2 == nil {
+		d.CheckErrorNoUsage(fmt.Errorf("Value not found: %s", to))
+	}
+	defer db2.Close()
+
+	p, err := json.DiffToPatch(val1, val2, opts)
+	d.CheckErrorNoUsage(err)
+
+	var w io.WriteCloser
+	if out == "@" {
+		w = os.Stdout
+	} else {
+		w, err = os.Create(out)
+		d.CheckErrorNoUsage(err)
+	}
+	defer w.Close()
+
+	d.CheckErrorNoUsage(json.WritePatch(p, w, indent))
+	return 0
+}
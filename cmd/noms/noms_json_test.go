@@ -0,0 +1,89 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/attic-labs/noms/go/spec"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/attic-labs/noms/go/util/clienttest"
+	"github.com/attic-labs/noms/go/util/json"
+	"github.com/stretchr/testify/suite"
+)
+
+type nomsJSONTestSuite struct {
+	clienttest.ClientTestSuite
+}
+
+func TestNomsJSON(t *testing.T) {
+	suite.Run(t, &nomsJSONTestSuite{})
+}
+
+func (s *nomsJSONTestSuite) commitJSON(sp spec.Spec, js string) string {
+	db := sp.GetDatabase()
+	v, err := json.FromJSON(strings.NewReader(js), db, json.FromOptions{})
+	s.NoError(err)
+	ds, err := db.CommitValue(sp.GetDataset(), v)
+	s.NoError(err)
+	return spec.CreateHashSpecString("nbs", s.DBDir, ds.HeadRef().TargetHash()) + ".value"
+}
+
+func (s *nomsJSONTestSuite) headValue(sp spec.Spec) types.Value {
+	sp2, err := spec.ForDataset(sp.String())
+	s.NoError(err)
+	defer sp2.Close()
+	return sp2.GetDataset().HeadValue()
+}
+
+func (s *nomsJSONTestSuite) TestNomsJSONPatch() {
+	src, err := spec.ForDataset(spec.CreateValueSpecString("nbs", s.DBDir, "src"))
+	s.NoError(err)
+	defer src.Close()
+	dst, err := spec.ForDataset(spec.CreateValueSpecString("nbs", s.DBDir, "dst"))
+	s.NoError(err)
+	defer dst.Close()
+
+	v1 := s.commitJSON(src, `{"name": "bob", "tags": ["a", "b", "c"]}`)
+	v2 := s.commitJSON(src, `{"name": "bob", "tags": ["a", "d", "c", "e"], "age": 30}`)
+	s.commitJSON(dst, `{"name": "bob", "tags": ["a", "b", "c"]}`)
+
+	patchFile := filepath.Join(s.TempDir, "patch.json")
+	stdout, stderr := s.MustRun(main, []string{"json", "patch", "diff", "--indent", "", v1, v2, patchFile})
+	s.Empty(stdout)
+	s.Empty(stderr)
+	b, err := ioutil.ReadFile(patchFile)
+	s.NoError(err)
+	s.Contains(string(b), `{"op":"add","path":"/age","value":30}`)
+
+	stdout, stderr = s.MustRun(main, []string{"json", "patch", "apply", dst.String(), patchFile})
+	s.Empty(stderr)
+	s.Contains(stdout, "New head #")
+	s.True(s.headValue(src).Equals(s.headValue(dst)))
+
+	mergeFile := filepath.Join(s.TempDir, "merge.json")
+	s.NoError(ioutil.WriteFile(mergeFile, []byte(`{"age": null, "name": "carol"}`), 0644))
+	stdout, _ = s.MustRun(main, []string{"json", "patch", "apply", "--merge", dst.String(), mergeFile})
+	s.Contains(stdout, "New head #")
+	s.Equal(`map {
+  "name": "carol",
+  "tags": [  // 4 items
+    "a",
+    "d",
+    "c",
+    "e",
+  ],
+}`, types.EncodedValue(s.headValue(dst)))
+
+	stdout, _ = s.MustRun(main, []string{"json", "patch", "apply", "--merge", dst.String(), mergeFile})
+	s.Equal("Nothing to apply\n", stdout)
+
+	s.NoError(ioutil.WriteFile(patchFile, []byte(`[{"op": "test", "path": "/name", "value": "bob"}]`), 0644))
+	_, _, recovered := s.Run(main, []string{"json", "patch", "apply", dst.String(), patchFile})
+	s.NotNil(recovered)
+}
@@ -11,6 +11,7 @@ import (
 	"io"
 	"math"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -55,9 +56,9 @@ func nomsLog(noms *kingpin.Application) (*kingpin.CmdClause, util.KingpinHandler
 	cmd.Flag("oneline", "show a summary of each commit on a single line").BoolVar(&o.oneline)
 	cmd.Flag("graph", "show ascii-based commit hierarchy on left side of output").BoolVar(&o.showGraph)
 	cmd.Flag("show-value", "show commit value rather than diff information").BoolVar(&o.showValue)
-	cmd.Flag("tz", "display formatted date comments in specified timezone, must be: local or utc").Default("local").StringVar(&tzName)
+	cmd.Flag("tz", "display timestamps and formatted date comments in specified timezone, must be: local or utc").Default("local").StringVar(&tzName)
 
-	cmd.Arg("value", "dataset or value to display history for").Required().StringVar(&o.path)
+	cmd.Arg("value", "dataset or value to display history for, or a range of commits: A..B lists those reachable from B but not from A, and A...B those reachable from either but not both, where B is a dataset or hash in the database of A").Required().StringVar(&o.path)
 
 	outputpager.RegisterOutputpagerFlags(cmd)
 
@@ -67,72 +68,165 @@ func nomsLog(noms *kingpin.Application) (*kingpin.CmdClause, util.KingpinHandler
 
 		o.tz, _ = locationFromTimezoneArg(tzName, nil)
 		datetime.RegisterHRSCommenter(o.tz)
+		types.SetHRSTimestampLocation(o.tz)
 
-		resolved := cfg.ResolvePathSpec(o.path)
-		sp, err := spec.ForPath(resolved)
-		d.CheckErrorNoUsage(err)
-		defer sp.Close()
+		if from, to, symmetric, ok := splitLogRange(o.path); ok {
+			return nomsLogRange(cfg, from, to, symmetric, o)
+		}
 
-		pinned, ok := sp.Pin()
-		if !ok {
-			fmt.Fprintf(os.Stderr, "Cannot resolve spec: %s\n", o.path)
+		pinned, origCommit, err := resolveLogCommit(cfg, o.path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
 			return 1
 		}
 		defer pinned.Close()
-		database := pinned.GetDatabase()
 
-		absPath := pinned.Path
-		path := absPath.Path
-		if len(path) == 0 {
-			path = types.MustParsePath(".value")
+		printLog(NewCommitIterator(pinned.GetDatabase(), origCommit).Next, logPath(pinned), pinned.GetDatabase(), o)
+		return 0
+	}
+}
+
+// splitLogRange splits |arg| into the two sides of a range of commits,
+// "A..B" or "A...B", like those of git log. The ".." of a relative path,
+// e.g. "../db::ds", doesn't start a range.
+func splitLogRange(arg string) (from, to string, symmetric, ok bool) {
+	for i := 0; i+2 < len(arg); i++ {
+		if strings.HasPrefix(arg[i:], "...") && i+3 < len(arg) && arg[i+3] != '/' {
+			return arg[:i], arg[i+3:], true, i > 0
+		}
+		if strings.HasPrefix(arg[i:], "..") && arg[i+2] != '.' && arg[i+2] != '/' {
+			return arg[:i], arg[i+2:], false, i > 0
 		}
+	}
+	return "", "", false, false
+}
 
-		origCommit, ok := database.ReadValue(absPath.Hash).(types.Struct)
-		if !ok || !datas.IsCommit(origCommit) {
-			d.CheckError(fmt.Errorf("%s does not reference a Commit object", path))
+// nomsLogRange lists the commits reachable from |to| but not from |from| or,
+// if |symmetric|, those reachable from either but not both. |to| must be in
+// the database of |from|, which it needn't name, e.g. "db::a..b".
+func nomsLogRange(cfg *config.Resolver, from, to string, symmetric bool, o opts) int {
+	if o.showGraph {
+		fmt.Fprintln(os.Stderr, "--graph cannot be used with a range of commits")
+		return 1
+	}
+
+	fromSpec, fromCommit, err := resolveLogCommit(cfg, from)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer fromSpec.Close()
+	db := fromSpec.GetDatabase()
+
+	if strings.Contains(to, spec.Separator) {
+		fromParts := strings.SplitN(cfg.ResolvePathSpec(from), spec.Separator, 2)
+		toParts := strings.SplitN(cfg.ResolvePathSpec(to), spec.Separator, 2)
+		if fromParts[0] != toParts[0] {
+			fmt.Fprintf(os.Stderr, "%s and %s are not in the same database\n", from, to)
+			return 1
 		}
+		to = toParts[1]
+	}
+	toPath, err := spec.NewAbsolutePath(to)
+	d.CheckErrorNoUsage(err)
+	toCommit, ok := toPath.Resolve(db).(types.Struct)
+	if !ok || !datas.IsCommit(toCommit) {
+		fmt.Fprintf(os.Stderr, "%s does not reference a Commit object\n", to)
+		return 1
+	}
 
-		iter := NewCommitIterator(database, origCommit)
-		displayed := 0
-		if o.maxCommits <= 0 {
-			o.maxCommits = math.MaxInt32
+	onlyFrom, onlyTo := datas.DiffHistories(types.NewRef(fromCommit), types.NewRef(toCommit), db)
+	refs := onlyTo
+	if symmetric {
+		refs = append(refs, onlyFrom...)
+		sort.Sort(sort.Reverse(types.RefByHeight(refs)))
+	}
+
+	next := func() (LogNode, bool) {
+		if len(refs) == 0 {
+			return LogNode{}, false
 		}
+		r := refs[0]
+		refs = refs[1:]
+		return LogNode{
+			cr:               r,
+			commit:           r.TargetValue(db).(types.Struct),
+			startingColCount: 1,
+			endingColCount:   1,
+			lastCommit:       len(refs) == 0,
+		}, true
+	}
+	printLog(next, logPath(fromSpec), db, o)
+	return 0
+}
 
-		bytesChan := make(chan chan []byte, parallelism)
+// resolveLogCommit pins the spec |str| and returns it along with the Commit it
+// resolves to.
+func resolveLogCommit(cfg *config.Resolver, str string) (spec.Spec, types.Struct, error) {
+	sp, err := spec.ForPath(cfg.ResolvePathSpec(str))
+	d.CheckErrorNoUsage(err)
 
-		var done = false
+	pinned, ok := sp.Pin()
+	if !ok {
+		sp.Close()
+		return spec.Spec{}, types.Struct{}, fmt.Errorf("Cannot resolve spec: %s", str)
+	}
+	commit, ok := pinned.GetDatabase().ReadValue(pinned.Path.Hash).(types.Struct)
+	if !ok || !datas.IsCommit(commit) {
+		pinned.Close()
+		d.CheckError(fmt.Errorf("%s does not reference a Commit object", logPath(pinned)))
+	}
+	return pinned, commit, nil
+}
 
-		go func() {
-			for ln, ok := iter.Next(); !done && ok && displayed < o.maxCommits; ln, ok = iter.Next() {
-				ch := make(chan []byte)
-				bytesChan <- ch
+// logPath returns the path within each commit that the log of |sp| shows.
+func logPath(sp spec.Spec) types.Path {
+	if len(sp.Path.Path) == 0 {
+		return types.MustParsePath(".value")
+	}
+	return sp.Path.Path
+}
 
-				go func(ch chan []byte, node LogNode) {
-					buff := &bytes.Buffer{}
-					printCommit(node, path, buff, database, o)
-					ch <- buff.Bytes()
-				}(ch, ln)
+// printLog prints the commits returned by |next| until it returns false, or
+// until o.maxCommits have been printed.
+func printLog(next func() (LogNode, bool), path types.Path, database datas.Database, o opts) {
+	displayed := 0
+	if o.maxCommits <= 0 {
+		o.maxCommits = math.MaxInt32
+	}
 
-				displayed++
-			}
-			close(bytesChan)
-		}()
-
-		pgr := outputpager.Start()
-		defer pgr.Stop()
-
-		for ch := range bytesChan {
-			commitBuff := <-ch
-			_, err := io.Copy(pgr.Writer, bytes.NewReader(commitBuff))
-			if err != nil {
-				done = true
-				for range bytesChan {
-					// drain the output
-				}
-			}
+	bytesChan := make(chan chan []byte, parallelism)
+
+	var done = false
+
+	go func() {
+		for ln, ok := next(); !done && ok && displayed < o.maxCommits; ln, ok = next() {
+			ch := make(chan []byte)
+			bytesChan <- ch
+
+			go func(ch chan []byte, node LogNode) {
+				buff := &bytes.Buffer{}
+				printCommit(node, path, buff, database, o)
+				ch <- buff.Bytes()
+			}(ch, ln)
+
+			displayed++
 		}
+		close(bytesChan)
+	}()
 
-		return 0
+	pgr := outputpager.Start()
+	defer pgr.Stop()
+
+	for ch := range bytesChan {
+		commitBuff := <-ch
+		_, err := io.Copy(pgr.Writer, bytes.NewReader(commitBuff))
+		if err != nil {
+			done = true
+			for range bytesChan {
+				// drain the output
+			}
+		}
 	}
 }
 
@@ -5,6 +5,7 @@
 package main
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/attic-labs/noms/go/datas"
@@ -127,6 +128,74 @@ func (s *nomsLogTestSuite) TestNArg() {
 	s.Contains(res, h1.String())
 }
 
+func (s *nomsLogTestSuite) TestRange() {
+	sp, err := spec.ForDatabase(spec.CreateDatabaseSpecString("nbs", s.DBDir))
+	s.NoError(err)
+	defer sp.Close()
+	db := sp.GetDatabase()
+
+	prod, err := addCommit(db.GetDataset("prod"), "1")
+	s.NoError(err)
+	h1 := prod.Head().Hash()
+	fork, err := addBranchedDataset(db, db.GetDataset("fork"), prod, "2")
+	s.NoError(err)
+	h2 := fork.Head().Hash()
+	fork, err = addCommit(fork, "3")
+	s.NoError(err)
+	h3 := fork.Head().Hash()
+	prod, err = addCommit(prod, "4")
+	s.NoError(err)
+	h4 := prod.Head().Hash()
+
+	prodSpec := spec.CreateValueSpecString("nbs", s.DBDir, "prod")
+	forkSpec := spec.CreateValueSpecString("nbs", s.DBDir, "fork")
+
+	// What fork has that prod lacks.
+	res, _ := s.MustRun(main, []string{"log", "--oneline", prodSpec + "..fork"})
+	s.Equal(fmt.Sprintf("%s (Parent: %s)\n%s (Parent: %s)\n", h3, h2, h2, h1), res)
+	res, _ = s.MustRun(main, []string{"log", "--oneline", prodSpec + ".." + forkSpec})
+	s.Equal(fmt.Sprintf("%s (Parent: %s)\n%s (Parent: %s)\n", h3, h2, h2, h1), res)
+
+	res, _ = s.MustRun(main, []string{"log", "--oneline", forkSpec + "..prod"})
+	s.Equal(fmt.Sprintf("%s (Parent: %s)\n", h4, h1), res)
+
+	res, _ = s.MustRun(main, []string{"log", forkSpec + "...prod"})
+	s.Contains(res, h2.String())
+	s.Contains(res, h3.String())
+	s.Contains(res, h4.String())
+	s.NotContains(res, "commit "+h1.String())
+
+	res, _ = s.MustRun(main, []string{"log", prodSpec + "..#" + h1.String()})
+	s.Empty(res)
+
+	_, stderr, _ := s.Run(main, []string{"log", "--graph", prodSpec + "..fork"})
+	s.Contains(stderr, "--graph cannot be used with a range of commits")
+}
+
+func TestSplitLogRange(t *testing.T) {
+	assert := assert.New(t)
+	cases := []struct {
+		arg, from, to string
+		symmetric, ok bool
+	}{
+		{"db::a..b", "db::a", "b", false, true},
+		{"db::a...b", "db::a", "b", true, true},
+		{"../db::a..b", "../db::a", "b", false, true},
+		{"../db::a", "", "", false, false},
+		{"db::a.value", "", "", false, false},
+		{"..b", "", "b", false, false},
+	}
+	for _, c := range cases {
+		from, to, symmetric, ok := splitLogRange(c.arg)
+		assert.Equal(c.ok, ok, c.arg)
+		if c.ok {
+			assert.Equal(c.from, from, c.arg)
+			assert.Equal(c.to, to, c.arg)
+			assert.Equal(c.symmetric, symmetric, c.arg)
+		}
+	}
+}
+
 func (s *nomsLogTestSuite) TestEmptyCommit() {
 	sp, err := spec.ForDatabase(spec.CreateDatabaseSpecString("nbs", s.DBDir))
 	s.NoError(err)
@@ -5,10 +5,12 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"regexp"
+	"strings"
 
 	"github.com/attic-labs/kingpin"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/attic-labs/noms/go/config"
 	"github.com/attic-labs/noms/go/d"
 	"github.com/attic-labs/noms/go/datas"
+	"github.com/attic-labs/noms/go/diff"
 	"github.com/attic-labs/noms/go/merge"
 	"github.com/attic-labs/noms/go/types"
 	"github.com/attic-labs/noms/go/util/status"
@@ -27,11 +30,16 @@ var (
 )
 
 func nomsMerge(noms *kingpin.Application) (*kingpin.CmdClause, util.KingpinHandler) {
-	cmd := noms.Command("merge", "Merges two datasets.")
-	resolver := cmd.Flag("policy", "Conflict resolution policy for merging - defaults to 'n', which means no resolution strategy will be applied. Supported values are 'l' (left), 'r' (right) and 'p' (prompt). 'prompt' will bring up a simple command-line prompt allowing you to resolve conflicts by choosing between 'l' or 'r' on a case-by-case basis.").Default("n").String()
+	cmd := noms.Command("merge", "Merges two or more datasets.")
+	resolver := cmd.Flag("policy", "Conflict resolution policy for merging - defaults to 'n', which means no resolution strategy will be applied. Supported values are 'l' (left), 'r' (right) and 'p' (prompt). 'prompt' will bring up a simple command-line prompt allowing you to resolve conflicts by choosing between 'l' or 'r' on a case-by-case basis. When merging more than two datasets, 'left' is the result of merging the datasets before the one being merged in.").Default("n").String()
+	policyFile := cmd.Flag("policy-file", "JSON file with the merge policies to use for conflicts at matching paths, before applying --policy, e.g. [{\"path\": \".counters[*]\", \"policy\": \"max\"}]. Supported policies are none, ours, theirs, max, min and union. A policy for the path of a List also resolves overlapping edits to the List, by applying it to the List as a whole.").String()
+	moves := cmd.Flag("moves", "merge a value that one side moved to a new key or field with the other side's changes to it at the old key, rather than reporting a conflict").Bool()
+	lines := cmd.Flag("lines", "merge concurrent edits to Strings and text Blobs line by line, unless they overlap, before applying --policy").Bool()
+	record := cmd.Flag("record-conflicts", "instead of failing on conflicts, write a merge in progress that records them, to be finished with 'noms conflicts'. Cannot be combined with --policy.").Bool()
+	dryRun := cmd.Flag("dry-run", "instead of committing the merge, report the changes it would merge and all of its conflicts. Cannot be combined with --policy, --policy-file, --lines or --record-conflicts.").Bool()
+	format := cmd.Flag("format", "output format of --dry-run").Default("text").Enum("text", "json")
 	db := cmd.Arg("db", "database to work with - see Spelling Databases at https://github.com/attic-labs/noms/blob/master/doc/spelling.md").Required().String()
-	left := cmd.Arg("left", "left dataset name").Required().String()
-	right := cmd.Arg("right", "right dataset name").Required().String()
+	datasets := cmd.Arg("datasets", "names of the datasets to merge, from left to right").Required().Strings()
 
 	return cmd, func(input string) int {
 		cfg := config.NewResolver()
@@ -40,63 +48,167 @@ func nomsMerge(noms *kingpin.Application) (*kingpin.CmdClause, util.KingpinHandl
 		d.CheckError(err)
 		defer db.Close()
 
-		leftDS, rightDS := resolveDatasets(db, *left, *right)
-		left, right, ancestor := getMergeCandidates(db, leftDS, rightDS)
-		policy := decidePolicy(*resolver)
+		checkIfTrue(len(*datasets) < 2, "At least two datasets are required to merge")
+		dss := resolveDatasets(db, *datasets...)
+		heads := getMergeHeads(dss)
+		if *dryRun {
+			checkIfTrue(*resolver != "n" || *policyFile != "" || *lines || *record, "--dry-run cannot be combined with --policy, --policy-file, --lines or --record-conflicts")
+			res, err := datas.PreviewMerge(db, heads)
+			checkIfTrue(err == datas.ErrNoCommonAncestor, "Datasets %s have no common ancestor", describeDatasets(dss))
+			d.CheckErrorNoUsage(err)
+			d.CheckErrorNoUsage(writeMergePreview(os.Stdout, *format, res))
+			return 0
+		}
+		if *record {
+			checkIfTrue(*resolver != "n" || *policyFile != "", "--record-conflicts cannot be combined with --policy or --policy-file")
+			return recordMerge(db, dss, heads)
+		}
+		resolve := decideResolveFunc(*resolver)
+		opts := merge.Options{DetectMoves: *moves}
+		if *policyFile != "" {
+			opts.Lists = readPolicyFile(*policyFile)
+			resolve = opts.Lists.ResolveFunc(resolve)
+		}
+		policy := decidePolicy(resolve, *lines, opts)
 		pc := newMergeProgressChan()
-		merged, err := policy(left, right, ancestor, db, pc)
-		d.CheckErrorNoUsage(err)
+		merged, err := datas.MergeCommits(db, heads, policy, pc)
 		close(pc)
+		checkIfTrue(err == datas.ErrNoCommonAncestor, "Datasets %s have no common ancestor", describeDatasets(dss))
+		d.CheckErrorNoUsage(err)
 
-		r := db.WriteValue(datas.NewCommit(merged, types.NewSet(db, leftDS.HeadRef(), rightDS.HeadRef()), types.EmptyStruct))
+		parents := types.NewSet(db)
+		for _, h := range heads {
+			parents = parents.Edit().Insert(h).Set()
+		}
+		r := db.WriteValue(datas.NewCommit(merged, parents, types.EmptyStruct))
 		db.Flush()
 		fmt.Println(r.TargetHash())
 		return 0
 	}
 }
 
+// recordMerge merges |heads| and writes the merge commit or, if there are
+// conflicts, a merge in progress recording them.
+func recordMerge(db datas.Database, dss []datas.Dataset, heads types.RefSlice) int {
+	pc := newMergeProgressChan()
+	mip, err := datas.StartMerge(db, heads, pc)
+	close(pc)
+	checkIfTrue(err == datas.ErrNoCommonAncestor, "Datasets %s have no common ancestor", describeDatasets(dss))
+	d.CheckErrorNoUsage(err)
+
+	var r types.Ref
+	if mip.Unresolved() == 0 {
+		r, err = mip.Finish(db, types.EmptyStruct)
+		d.CheckErrorNoUsage(err)
+	} else {
+		r = mip.Write(db)
+	}
+	db.Flush()
+	fmt.Println(r.TargetHash())
+	if n := mip.Unresolved(); n > 0 {
+		fmt.Fprintf(os.Stderr, "Recorded %d conflicts, see 'noms conflicts list'\n", n)
+	}
+	return 0
+}
+
+// mergeChangeRecord and mergeConflictRecord are what the json format of
+// --dry-run writes for each change and conflict. Values are written as by
+// 'noms diff --format json'.
+type mergeChangeRecord struct {
+	Path       string          `json:"path"`
+	ChangeType string          `json:"changeType"`
+	Source     string          `json:"source"`
+	Value      json.RawMessage `json:"value,omitempty"`
+}
+
+type mergeConflictRecord struct {
+	Path   string          `json:"path"`
+	Base   json.RawMessage `json:"base,omitempty"`
+	Ours   json.RawMessage `json:"ours,omitempty"`
+	Theirs json.RawMessage `json:"theirs,omitempty"`
+}
+
+func writeMergePreview(w io.Writer, format string, res merge.PreviewResult) error {
+	if format == "json" {
+		record := struct {
+			Value     string                `json:"value"`
+			Changes   []mergeChangeRecord   `json:"changes"`
+			Conflicts []mergeConflictRecord `json:"conflicts"`
+		}{res.Merged.Hash().String(), []mergeChangeRecord{}, []mergeConflictRecord{}}
+		value := func(v types.Value) json.RawMessage {
+			if v == nil {
+				return nil
+			}
+			return diffRecordValue(v)
+		}
+		for _, c := range res.Changes {
+			record.Changes = append(record.Changes, mergeChangeRecord{c.Path.String(), diff.ChangeTypeString(c.ChangeType), c.Source.String(), value(c.Value)})
+		}
+		for _, c := range res.Conflicts {
+			record.Conflicts = append(record.Conflicts, mergeConflictRecord{c.Path.String(), value(c.Base), value(c.Ours), value(c.Theirs)})
+		}
+		b, err := json.MarshalIndent(record, "", "  ")
+		if err == nil {
+			_, err = fmt.Fprintf(w, "%s\n", b)
+		}
+		return err
+	}
+
+	describe := func(v types.Value) string {
+		if v == nil {
+			return "<none>"
+		}
+		return types.EncodedValue(v)
+	}
+	if len(res.Changes) > 0 {
+		fmt.Fprintln(w, "Merged changes:")
+	}
+	for _, c := range res.Changes {
+		fmt.Fprintf(w, "  %-7s%-9s%s\n", c.Source, diff.ChangeTypeString(c.ChangeType), c.Path)
+	}
+	if len(res.Conflicts) > 0 {
+		fmt.Fprintln(w, "Conflicts:")
+	}
+	for _, c := range res.Conflicts {
+		fmt.Fprintf(w, "  %s\n", c.Path)
+		fmt.Fprintf(w, "    base:   %s\n    ours:   %s\n    theirs: %s\n", describe(c.Base), describe(c.Ours), describe(c.Theirs))
+	}
+	_, err := fmt.Fprintf(w, "%d changes merged, %d conflicts\n", len(res.Changes), len(res.Conflicts))
+	return err
+}
+
 func checkIfTrue(b bool, format string, args ...interface{}) {
 	if b {
 		d.CheckErrorNoUsage(fmt.Errorf(format, args...))
 	}
 }
 
-func resolveDatasets(db datas.Database, leftName, rightName string) (leftDS, rightDS datas.Dataset) {
-	makeDS := func(dsName string) datas.Dataset {
+func resolveDatasets(db datas.Database, names ...string) (dss []datas.Dataset) {
+	for _, dsName := range names {
 		if !datasetRe.MatchString(dsName) {
 			d.CheckErrorNoUsage(fmt.Errorf("Invalid dataset %s, must match %s", dsName, datas.DatasetRe.String()))
 		}
-		return db.GetDataset(dsName)
+		dss = append(dss, db.GetDataset(dsName))
 	}
-	leftDS = makeDS(leftName)
-	rightDS = makeDS(rightName)
 	return
 }
 
-func getMergeCandidates(db datas.Database, leftDS, rightDS datas.Dataset) (left, right, ancestor types.Value) {
-	leftRef, ok := leftDS.MaybeHeadRef()
-	checkIfTrue(!ok, "Dataset %s has no data", leftDS.ID())
-	rightRef, ok := rightDS.MaybeHeadRef()
-	checkIfTrue(!ok, "Dataset %s has no data", rightDS.ID())
-	ancestorCommit, ok := getCommonAncestor(leftRef, rightRef, db)
-	checkIfTrue(!ok, "Datasets %s and %s have no common ancestor", leftDS.ID(), rightDS.ID())
-
-	return leftDS.HeadValue(), rightDS.HeadValue(), ancestorCommit.Get(datas.ValueField)
+func getMergeHeads(dss []datas.Dataset) (heads types.RefSlice) {
+	for _, ds := range dss {
+		r, ok := ds.MaybeHeadRef()
+		checkIfTrue(!ok, "Dataset %s has no data", ds.ID())
+		heads = append(heads, r)
+	}
+	return
 }
 
-func getCommonAncestor(r1, r2 types.Ref, vr types.ValueReader) (a types.Struct, found bool) {
-	aRef, found := datas.FindCommonAncestor(r1, r2, vr)
-	if !found {
-		return
-	}
-	v := vr.ReadValue(aRef.TargetHash())
-	if v == nil {
-		panic(aRef.TargetHash().String() + " not found")
+// describeDatasets lists the IDs of |dss| as "a, b and c".
+func describeDatasets(dss []datas.Dataset) string {
+	ids := make([]string, len(dss))
+	for i, ds := range dss {
+		ids[i] = ds.ID()
 	}
-	if !datas.IsCommit(v) {
-		panic("Not a commit: " + types.EncodedValueMaxLines(v, 10) + "  ...")
-	}
-	return v.(types.Struct), true
+	return strings.Join(ids[:len(ids)-1], ", ") + " and " + ids[len(ids)-1]
 }
 
 func newMergeProgressChan() chan struct{} {
@@ -113,8 +225,24 @@ func newMergeProgressChan() chan struct{} {
 	return pc
 }
 
-func decidePolicy(policy string) merge.Policy {
-	var resolve merge.ResolveFunc
+func decidePolicy(resolve merge.ResolveFunc, lines bool, opts merge.Options) merge.Policy {
+	if lines {
+		return merge.NewLineMergeWithOptions(resolve, opts)
+	}
+	return merge.NewThreeWayWithOptions(resolve, opts)
+}
+
+// readPolicyFile returns the merge.PolicyTable in |path|.
+func readPolicyFile(path string) merge.PolicyTable {
+	f, err := os.Open(path)
+	d.CheckErrorNoUsage(err)
+	defer f.Close()
+	table, err := merge.ReadPolicyTable(f)
+	d.CheckErrorNoUsage(err)
+	return table
+}
+
+func decideResolveFunc(policy string) (resolve merge.ResolveFunc) {
 	switch policy {
 	case "n", "N":
 		resolve = merge.None
@@ -129,7 +257,7 @@ func decidePolicy(policy string) merge.Policy {
 	default:
 		d.CheckErrorNoUsage(fmt.Errorf("Unsupported merge policy: %s. Choices are n, l, r and a.", policy))
 	}
-	return merge.NewThreeWay(resolve)
+	return
 }
 
 func cliResolve(in io.Reader, out io.Writer, aType, bType types.DiffChangeType, a, b types.Value, path types.Path) (change types.DiffChangeType, merged types.Value, ok bool) {
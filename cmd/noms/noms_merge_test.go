@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -165,6 +166,32 @@ func (s *nomsMergeTestSuite) TestNomsMerge_Right() {
 	}
 }
 
+func (s *nomsMergeTestSuite) TestNomsMerge_Octopus() {
+	parentSpec := s.spec("parent")
+	defer parentSpec.Close()
+	db := parentSpec.GetDatabase()
+
+	p := s.setupMergeDataset(parentSpec, types.StructData{"a": types.Number(0), "b": types.Number(0), "c": types.Number(0)}, types.NewSet(db))
+	heads := []types.Value{}
+	for _, name := range []string{"a", "b", "c"} {
+		sp := s.spec(name)
+		defer sp.Close()
+		data := types.StructData{"a": types.Number(0), "b": types.Number(0), "c": types.Number(0)}
+		data[name] = types.Number(1)
+		heads = append(heads, s.setupMergeDataset(sp, data, types.NewSet(sp.GetDatabase(), p)))
+	}
+
+	expected := types.NewStruct("", types.StructData{"a": types.Number(1), "b": types.Number(1), "c": types.Number(1)})
+
+	stdout, stderr, err := s.Run(main, []string{"merge", s.DBDir, "a", "b", "c"})
+	if err == nil {
+		s.Equal("", stderr)
+		s.validateOutput(stdout, expected, heads...)
+	} else {
+		s.Fail("Run failed", "err: %v\nstdout: %s\nstderr: %s\n", err, stdout, stderr)
+	}
+}
+
 func (s *nomsMergeTestSuite) TestNomsMerge_Conflict() {
 	left, right := "left", "right"
 	parentSpec := s.spec("parent")
@@ -180,6 +207,123 @@ func (s *nomsMergeTestSuite) TestNomsMerge_Conflict() {
 	s.Panics(func() { s.MustRun(main, []string{"merge", s.DBDir, left, right}) })
 }
 
+func (s *nomsMergeTestSuite) TestNomsMerge_Lines() {
+	left, right := "left", "right"
+	parentSpec := s.spec("parent")
+	defer parentSpec.Close()
+	leftSpec := s.spec(left)
+	defer leftSpec.Close()
+	rightSpec := s.spec(right)
+	defer rightSpec.Close()
+
+	text := func(db types.ValueReadWriter, s string) types.StructData {
+		return types.StructData{"text": types.NewBlob(db, strings.NewReader(s))}
+	}
+	p := s.setupMergeDataset(parentSpec, text(parentSpec.GetDatabase(), "a\nb\nc\n"), types.NewSet(parentSpec.GetDatabase()))
+	l := s.setupMergeDataset(leftSpec, text(leftSpec.GetDatabase(), "A\nb\nc\n"), types.NewSet(leftSpec.GetDatabase(), p))
+	r := s.setupMergeDataset(rightSpec, text(rightSpec.GetDatabase(), "a\nb\nC\n"), types.NewSet(rightSpec.GetDatabase(), p))
+
+	s.Panics(func() { s.MustRun(main, []string{"merge", s.DBDir, left, right}) })
+
+	stdout, stderr := s.MustRun(main, []string{"merge", "--lines", s.DBDir, left, right})
+	s.Equal("", stderr)
+	s.validateOutput(stdout, types.NewStruct("", text(parentSpec.GetDatabase(), "A\nb\nC\n")), l, r)
+}
+
+func (s *nomsMergeTestSuite) TestNomsMerge_PolicyFile() {
+	left, right := "left", "right"
+	parentSpec := s.spec("parent")
+	defer parentSpec.Close()
+	leftSpec := s.spec(left)
+	defer leftSpec.Close()
+	rightSpec := s.spec(right)
+	defer rightSpec.Close()
+
+	data := func(config, count, other float64) types.StructData {
+		return types.StructData{"config": types.Number(config), "count": types.Number(count), "other": types.Number(other)}
+	}
+	p := s.setupMergeDataset(parentSpec, data(1, 1, 1), types.NewSet(parentSpec.GetDatabase()))
+	l := s.setupMergeDataset(leftSpec, data(2, 5, 2), types.NewSet(leftSpec.GetDatabase(), p))
+	r := s.setupMergeDataset(rightSpec, data(3, 4, 3), types.NewSet(rightSpec.GetDatabase(), p))
+
+	policyFile := filepath.Join(s.TempDir, "policy.json")
+	s.NoError(ioutil.WriteFile(policyFile, []byte(`[{"path": ".config", "policy": "theirs"}, {"path": ".count", "policy": "max"}]`), 0644))
+
+	// .other isn't covered by the policy file.
+	s.Panics(func() { s.MustRun(main, []string{"merge", "--policy-file", policyFile, s.DBDir, left, right}) })
+
+	stdout, stderr := s.MustRun(main, []string{"merge", "--policy-file", policyFile, "--policy=l", s.DBDir, left, right})
+	s.Equal("", stderr)
+	s.validateOutput(stdout, types.NewStruct("", data(3, 5, 2)), l, r)
+
+	s.NoError(ioutil.WriteFile(policyFile, []byte(`[{"path": ".config", "policy": "newest"}]`), 0644))
+	_, stderr, _ = s.Run(main, []string{"merge", "--policy-file", policyFile, s.DBDir, left, right})
+	s.Contains(stderr, `Unknown merge policy "newest"`)
+}
+
+func (s *nomsMergeTestSuite) TestNomsMerge_DryRun() {
+	left, right := "left", "right"
+	parentSpec := s.spec("parent")
+	defer parentSpec.Close()
+	leftSpec := s.spec(left)
+	defer leftSpec.Close()
+	rightSpec := s.spec(right)
+	defer rightSpec.Close()
+
+	p := s.setupMergeDataset(parentSpec, types.StructData{"num": types.Number(1), "str": types.String("a")}, types.NewSet(parentSpec.GetDatabase()))
+	l := s.setupMergeDataset(leftSpec, types.StructData{"num": types.Number(2), "str": types.String("b")}, types.NewSet(leftSpec.GetDatabase(), p))
+	s.setupMergeDataset(rightSpec, types.StructData{"num": types.Number(3), "str": types.String("a"), "new": types.Bool(true)}, types.NewSet(rightSpec.GetDatabase(), p))
+
+	stdout, stderr := s.MustRun(main, []string{"merge", "--dry-run", s.DBDir, left, right})
+	s.Equal("", stderr)
+	s.Equal(`Merged changes:
+  theirs added    .new
+  ours   modified .str
+Conflicts:
+  .num
+    base:   1
+    ours:   2
+    theirs: 3
+2 changes merged, 1 conflicts
+`, stdout)
+
+	stdout, stderr = s.MustRun(main, []string{"merge", "--dry-run", "--format", "json", s.DBDir, left, right})
+	s.Equal("", stderr)
+	merged := types.NewStruct("", types.StructData{"num": types.Number(2), "str": types.String("b"), "new": types.Bool(true)})
+	s.Equal(`{
+  "value": "`+merged.Hash().String()+`",
+  "changes": [
+    {
+      "path": ".new",
+      "changeType": "added",
+      "source": "theirs",
+      "value": true
+    },
+    {
+      "path": ".str",
+      "changeType": "modified",
+      "source": "ours",
+      "value": "b"
+    }
+  ],
+  "conflicts": [
+    {
+      "path": ".num",
+      "base": 1,
+      "ours": 2,
+      "theirs": 3
+    }
+  ]
+}
+`, stdout)
+
+	// Nothing was committed.
+	s.True(leftSpec.GetDatabase().GetDataset(left).HeadRef().Equals(l))
+
+	_, stderr, _ = s.Run(main, []string{"merge", "--dry-run", "--policy=l", s.DBDir, left, right})
+	s.Contains(stderr, "--dry-run cannot be combined with")
+}
+
 func (s *nomsMergeTestSuite) TestBadInput() {
 	sp, err := spec.ForDatabase(spec.CreateDatabaseSpecString("nbs", s.DBDir))
 	s.NoError(err)
@@ -194,6 +338,8 @@ func (s *nomsMergeTestSuite) TestBadInput() {
 		{[]string{sp.String(), l + "!!", r}, "error: Invalid dataset " + l + "!!, must match [a-zA-Z0-9\\-_/]+\n"},
 		{[]string{sp.String(), l + "2", r}, "error: Dataset " + l + "2 has no data\n"},
 		{[]string{sp.String(), l, r + "2"}, "error: Dataset " + r + "2 has no data\n"},
+		{[]string{sp.String(), l}, "error: At least two datasets are required to merge\n"},
+		{[]string{sp.String(), l, r, "unrelated"}, "error: Datasets " + l + ", " + r + " and unrelated have no common ancestor\n"},
 	}
 
 	db := sp.GetDatabase()
@@ -204,6 +350,7 @@ func (s *nomsMergeTestSuite) TestBadInput() {
 	}
 	prep(l)
 	prep(r)
+	db.CommitValue(db.GetDataset("unrelated"), types.String("unrelated"))
 
 	for _, c := range cases {
 		stdout, stderr, err := s.Run(main, append([]string{"merge"}, c.args...))
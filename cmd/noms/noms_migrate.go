@@ -0,0 +1,76 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/attic-labs/kingpin"
+
+	"github.com/attic-labs/noms/cmd/util"
+	"github.com/attic-labs/noms/go/config"
+	"github.com/attic-labs/noms/go/d"
+	"github.com/attic-labs/noms/go/datas"
+	"github.com/attic-labs/noms/go/migration"
+	"github.com/attic-labs/noms/go/nomdl"
+	"github.com/attic-labs/noms/go/spec"
+)
+
+func nomsMigrate(noms *kingpin.Application) (*kingpin.CmdClause, util.KingpinHandler) {
+	migrate := noms.Command("migrate", `Migrates the head value of a dataset and commits the result.
+
+Each rule is a single argument in one of the forms:
+  rename <Struct>.<field> <newField>
+  drop <Struct>.<field>
+  convert <path> <Kind>
+
+<Struct> can be * to match structs of any name, <path> is relative to the head value, e.g. .people[*].age, and <Kind> is one of Bool, Int, Number, String or Uint. Rules are applied in order and recorded in the "migration" field of the commit's meta.
+
+If the dataset has a schema, the migrated value must still conform to it, unless the migrated schema is given with --schema.
+`)
+	message := migrate.Flag("message", "commit message").String()
+	date := migrate.Flag("date", "commit date formatted as 2019-08-08T21:52:46Z - defaults to current date").String()
+	schema := migrate.Flag("schema", "nomdl type to record as the dataset's schema in the same commit, e.g. 'List<Struct Person {fullName: String}>'").String()
+	dsStr := migrate.Arg("dataset", "dataset spec to migrate - see Spelling Datasets at https://github.com/attic-labs/noms/blob/master/doc/spelling.md").Required().String()
+	rules := migrate.Arg("rules", "migration rules, e.g. 'rename Person.name fullName'").Required().Strings()
+
+	return migrate, func(input string) int {
+		m, err := migration.Parse(*rules...)
+		d.CheckErrorNoUsage(err)
+
+		cfg := config.NewResolver()
+		db, ds, err := cfg.GetDataset(*dsStr)
+		d.CheckError(err)
+		defer db.Close()
+
+		oldCommitRef, ok := ds.MaybeHeadRef()
+		if !ok {
+			d.CheckErrorNoUsage(fmt.Errorf("Dataset %s has no head", ds.ID()))
+		}
+
+		head := ds.HeadValue()
+		newVal, err := m.Apply(db, head)
+		d.CheckErrorNoUsage(err)
+		if newVal.Equals(head) {
+			fmt.Fprintf(os.Stdout, "Nothing to migrate\n")
+			return 0
+		}
+
+		meta, err := spec.CreateCommitMetaStruct(db, *date, *message, map[string]string{"migration": m.String()}, nil)
+		d.CheckErrorNoUsage(err)
+		if *schema != "" {
+			t, err := nomdl.ParseType(*schema)
+			d.CheckErrorNoUsage(err)
+			meta = meta.Set(datas.SchemaField, t)
+		}
+
+		ds, err = db.Commit(ds, newVal, datas.CommitOptions{Meta: meta})
+		d.CheckErrorNoUsage(err)
+
+		fmt.Fprintf(os.Stdout, "New head #%v (was #%v)\n", ds.HeadRef().TargetHash().String(), oldCommitRef.TargetHash().String())
+		return 0
+	}
+}
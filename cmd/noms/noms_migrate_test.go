@@ -0,0 +1,88 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package main
+
+import (
+	"testing"
+
+	"github.com/attic-labs/noms/go/datas"
+	"github.com/attic-labs/noms/go/nomdl"
+	"github.com/attic-labs/noms/go/spec"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/attic-labs/noms/go/util/clienttest"
+	"github.com/stretchr/testify/suite"
+)
+
+type nomsMigrateTestSuite struct {
+	clienttest.ClientTestSuite
+}
+
+func TestNomsMigrate(t *testing.T) {
+	suite.Run(t, &nomsMigrateTestSuite{})
+}
+
+func (s *nomsMigrateTestSuite) TestNomsMigrate() {
+	sp, err := spec.ForDataset(spec.CreateValueSpecString("nbs", s.DBDir, "migrate"))
+	s.NoError(err)
+	defer sp.Close()
+
+	db := sp.GetDatabase()
+	person := func(name string, age types.Value) types.Value {
+		return types.NewStruct("Person", types.StructData{"name": types.String(name), "age": age})
+	}
+	_, err = db.CommitValue(sp.GetDataset(), types.NewList(db, person("alice", types.String("30")), person("bob", types.String("40"))))
+	s.NoError(err)
+
+	stdout, stderr := s.MustRun(main, []string{"migrate", sp.String(), "rename Person.name fullName", "convert [*].age Number"})
+	s.Empty(stderr)
+	s.Contains(stdout, "New head #")
+
+	sp2, err := spec.ForDataset(sp.String())
+	s.NoError(err)
+	defer sp2.Close()
+
+	head := sp2.GetDataset().Head()
+	migrated := func(name string, age float64) types.Value {
+		return types.NewStruct("Person", types.StructData{"fullName": types.String(name), "age": types.Number(age)})
+	}
+	expected := types.NewList(db, migrated("alice", 30), migrated("bob", 40))
+	s.True(expected.Equals(head.Get("value")))
+	meta := head.Get("meta").(types.Struct)
+	s.Equal("rename Person.name fullName; convert [*].age Number", string(meta.Get("migration").(types.String)))
+
+	stdout, _ = s.MustRun(main, []string{"migrate", sp.String(), "rename Person.name fullName"})
+	s.Equal("Nothing to migrate\n", stdout)
+
+	_, _, recovered := s.Run(main, []string{"migrate", sp.String(), "split Person"})
+	s.NotNil(recovered)
+}
+
+func (s *nomsMigrateTestSuite) TestNomsMigrateSchema() {
+	sp, err := spec.ForDataset(spec.CreateValueSpecString("nbs", s.DBDir, "migrateSchema"))
+	s.NoError(err)
+	defer sp.Close()
+
+	db := sp.GetDatabase()
+	ds, err := db.CommitValue(sp.GetDataset(), types.NewList(db, types.NewStruct("Person", types.StructData{"name": types.String("alice")})))
+	s.NoError(err)
+	_, err = datas.SetSchema(ds, nomdl.MustParseType("List<Struct Person {name: String}>"), types.EmptyStruct)
+	s.NoError(err)
+
+	// The renamed field doesn't match the inherited schema.
+	_, _, recovered := s.Run(main, []string{"migrate", sp.String(), "rename Person.name fullName"})
+	s.NotNil(recovered)
+
+	newSchema := "List<Struct Person {fullName: String}>"
+	stdout, stderr := s.MustRun(main, []string{"migrate", "--schema", newSchema, sp.String(), "rename Person.name fullName"})
+	s.Empty(stderr)
+	s.Contains(stdout, "New head #")
+
+	sp2, err := spec.ForDataset(sp.String())
+	s.NoError(err)
+	defer sp2.Close()
+	schema, ok := sp2.GetDataset().MaybeSchema()
+	s.True(ok)
+	s.True(nomdl.MustParseType(newSchema).Equals(schema))
+}
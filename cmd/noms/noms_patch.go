@@ -0,0 +1,96 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/attic-labs/kingpin"
+
+	"github.com/attic-labs/noms/cmd/util"
+	"github.com/attic-labs/noms/go/config"
+	"github.com/attic-labs/noms/go/d"
+	"github.com/attic-labs/noms/go/datas"
+	"github.com/attic-labs/noms/go/diff"
+	"github.com/attic-labs/noms/go/spec"
+	"github.com/attic-labs/noms/go/types"
+)
+
+func nomsPatch(noms *kingpin.Application) (*kingpin.CmdClause, util.KingpinHandler) {
+	patch := noms.Command("patch", "Applies patches made by noms diff --patch-commit or --patch-out.")
+	apply := patch.Command("apply", "Applies a patch to the head value of a dataset and commits the result.")
+	message := apply.Flag("message", "commit message").String()
+	date := apply.Flag("date", "commit date formatted as 2019-08-08T21:52:46Z - defaults to current date").String()
+	isJSON := apply.Flag("json", "reads the patch from a JSON file made by noms diff --patch-out, or from stdin if the file is -").Bool()
+	patchStr := apply.Arg("patch", "path to a patch value made by noms diff --patch-commit, or with --json a JSON patch file - see Spelling Values at https://github.com/attic-labs/noms/blob/master/doc/spelling.md").Required().String()
+	dsStr := apply.Arg("dataset", "dataset spec to apply the patch to - see Spelling Datasets at https://github.com/attic-labs/noms/blob/master/doc/spelling.md").Required().String()
+
+	return patch, func(input string) int {
+		cfg := config.NewResolver()
+		db, ds, err := cfg.GetDataset(*dsStr)
+		d.CheckError(err)
+		defer db.Close()
+
+		oldCommitRef, ok := ds.MaybeHeadRef()
+		if !ok {
+			d.CheckErrorNoUsage(fmt.Errorf("Dataset %s has no head", ds.ID()))
+		}
+
+		pdb, p, err := readPatch(cfg, db, *patchStr, *isJSON)
+		if pdb != nil {
+			defer pdb.Close()
+		}
+		d.CheckErrorNoUsage(err)
+
+		head := ds.HeadValue()
+		newVal := diff.Apply(head, p)
+		if newVal.Equals(head) {
+			fmt.Fprintf(os.Stdout, "Nothing to apply\n")
+			return 0
+		}
+
+		meta, err := spec.CreateCommitMetaStruct(db, *date, *message, nil, nil)
+		d.CheckErrorNoUsage(err)
+
+		ds, err = db.Commit(ds, newVal, datas.CommitOptions{Meta: meta})
+		d.CheckErrorNoUsage(err)
+
+		fmt.Fprintf(os.Stdout, "New head #%v (was #%v)\n", ds.HeadRef().TargetHash().String(), oldCommitRef.TargetHash().String())
+		return 0
+	}
+}
+
+// readPatch reads the JSON patch file at |patchStr| if |isJSON| is true, and
+// otherwise decodes the patch value it spells, which should be in the same
+// database as the dataset it's applied to. The returned Database must be
+// closed once the patch has been applied, if it isn't nil.
+func readPatch(cfg *config.Resolver, vrw types.ValueReadWriter, patchStr string, isJSON bool) (datas.Database, diff.Patch, error) {
+	if isJSON {
+		r := io.Reader(os.Stdin)
+		if patchStr != "-" {
+			f, err := os.Open(patchStr)
+			if err != nil {
+				return nil, nil, err
+			}
+			defer f.Close()
+			r = f
+		}
+		p, err := diff.ReadPatchJSON(r, vrw)
+		return nil, p, err
+	}
+
+	pdb, v, err := cfg.GetPath(patchStr)
+	if err != nil {
+		return nil, nil, err
+	}
+	if v == nil {
+		pdb.Close()
+		return nil, nil, fmt.Errorf("Value not found: %s", patchStr)
+	}
+	p, err := diff.PatchFromValue(v)
+	return pdb, p, err
+}
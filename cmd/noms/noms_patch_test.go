@@ -0,0 +1,122 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/attic-labs/noms/go/diff"
+	"github.com/attic-labs/noms/go/spec"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/attic-labs/noms/go/util/clienttest"
+	"github.com/stretchr/testify/suite"
+)
+
+type nomsPatchTestSuite struct {
+	clienttest.ClientTestSuite
+}
+
+func TestNomsPatch(t *testing.T) {
+	suite.Run(t, &nomsPatchTestSuite{})
+}
+
+// setup commits |vals| to |dsName| in order, and returns the dataset's spec and
+// specs for the values of each commit.
+func (s *nomsPatchTestSuite) setup(dsName string, vals ...func(types.ValueReadWriter) types.Value) (spec.Spec, []string) {
+	sp, err := spec.ForDataset(spec.CreateValueSpecString("nbs", s.DBDir, dsName))
+	s.NoError(err)
+
+	db := sp.GetDatabase()
+	ds := sp.GetDataset()
+	valSpecs := []string{}
+	for _, v := range vals {
+		ds, err = db.CommitValue(ds, v(db))
+		s.NoError(err)
+		valSpecs = append(valSpecs, spec.CreateHashSpecString("nbs", s.DBDir, ds.HeadRef().TargetHash())+".value")
+	}
+	return sp, valSpecs
+}
+
+func (s *nomsPatchTestSuite) headValue(sp spec.Spec) types.Value {
+	sp2, err := spec.ForDataset(sp.String())
+	s.NoError(err)
+	defer sp2.Close()
+	return sp2.GetDataset().HeadValue()
+}
+
+func (s *nomsPatchTestSuite) TestNomsPatchJSON() {
+	v1 := func(vrw types.ValueReadWriter) types.Value {
+		return types.NewMap(vrw, types.String("a"), types.Number(1), types.String("b"), types.NewList(vrw, types.Number(1)))
+	}
+	v2 := func(vrw types.ValueReadWriter) types.Value {
+		return types.NewMap(vrw, types.String("b"), types.NewList(vrw, types.Number(1), types.Number(2)), types.String("c"), types.Bool(true))
+	}
+	src, vals := s.setup("src", v1, v2)
+	defer src.Close()
+	dst, _ := s.setup("dst", v1)
+	defer dst.Close()
+
+	patchFile := filepath.Join(s.TempDir, "patch.json")
+	stdout, stderr := s.MustRun(main, []string{"diff", "--patch-out", patchFile, vals[0], vals[1]})
+	s.Empty(stdout)
+	s.Empty(stderr)
+
+	stdout, stderr = s.MustRun(main, []string{"patch", "apply", "--json", patchFile, dst.String()})
+	s.Empty(stderr)
+	s.Contains(stdout, "New head #")
+	s.True(s.headValue(src).Equals(s.headValue(dst)))
+
+	stdout, _ = s.MustRun(main, []string{"diff", "--patch-out=-", vals[0], vals[1]})
+	s.Contains(stdout, `"changeType": "removed"`)
+}
+
+func (s *nomsPatchTestSuite) TestNomsPatchValue() {
+	sp, _ := s.setup("patchValue", func(vrw types.ValueReadWriter) types.Value {
+		return types.NewStruct("", types.StructData{"a": types.Number(1)})
+	})
+	defer sp.Close()
+
+	db := sp.GetDatabase()
+	patch := diff.NewPatch(sp.GetDataset().HeadValue(), types.NewStruct("", types.StructData{"a": types.Number(2), "b": types.String("x")}))
+	_, err := db.CommitValue(db.GetDataset("patch"), diff.PatchToValue(db, patch))
+	s.NoError(err)
+
+	patchSpec := spec.CreateValueSpecString("nbs", s.DBDir, "patch.value")
+	stdout, stderr := s.MustRun(main, []string{"patch", "apply", "--message", "apply patch", patchSpec, sp.String()})
+	s.Empty(stderr)
+	s.Contains(stdout, "New head #")
+	s.True(types.NewStruct("", types.StructData{"a": types.Number(2), "b": types.String("x")}).Equals(s.headValue(sp)))
+
+	_, _, recovered := s.Run(main, []string{"patch", "apply", spec.CreateValueSpecString("nbs", s.DBDir, "patchValue.value"), sp.String()})
+	s.NotNil(recovered)
+
+	// A JSON patch file is only read with --json.
+	_, _, recovered = s.Run(main, []string{"patch", "apply", patchSpec, "--json", sp.String()})
+	s.NotNil(recovered)
+}
+
+func (s *nomsPatchTestSuite) TestNomsPatchCommit() {
+	v1 := func(vrw types.ValueReadWriter) types.Value {
+		return types.NewSet(vrw, types.String("a"), types.String("b"))
+	}
+	v2 := func(vrw types.ValueReadWriter) types.Value {
+		return types.NewSet(vrw, types.String("b"), types.String("c"))
+	}
+	src, vals := s.setup("src", v1, v2)
+	defer src.Close()
+	dst, _ := s.setup("dst", v1)
+	defer dst.Close()
+
+	patchDs := spec.CreateValueSpecString("nbs", s.DBDir, "patch")
+	stdout, stderr := s.MustRun(main, []string{"diff", "--patch-commit", patchDs, vals[0], vals[1]})
+	s.Empty(stdout)
+	s.Empty(stderr)
+
+	stdout, stderr = s.MustRun(main, []string{"patch", "apply", patchDs + ".value", dst.String()})
+	s.Empty(stderr)
+	s.Contains(stdout, "New head #")
+	s.True(s.headValue(src).Equals(s.headValue(dst)))
+}
@@ -0,0 +1,48 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/attic-labs/kingpin"
+
+	"github.com/attic-labs/noms/cmd/util"
+	"github.com/attic-labs/noms/go/config"
+	"github.com/attic-labs/noms/go/d"
+	"github.com/attic-labs/noms/go/datas"
+)
+
+func nomsRebase(noms *kingpin.Application) (*kingpin.CmdClause, util.KingpinHandler) {
+	cmd := noms.Command("rebase", "Replays the commits of a dataset on top of the head of another.")
+	resolver := cmd.Flag("policy", "conflict resolution policy - 'n' (no resolution, the default), 'l' (keep the value being built up on <onto>), 'r' (take the replayed commit's value) or 'p' (prompt)").Default("n").String()
+	ds := cmd.Arg("dataset", "dataset spec to rebase - see Spelling Datasets at https://github.com/attic-labs/noms/blob/master/doc/spelling.md").Required().String()
+	onto := cmd.Arg("onto", "name of the dataset, in the same database, to rebase onto").Required().String()
+
+	return cmd, func(input string) int {
+		cfg := config.NewResolver()
+		db, ds, err := cfg.GetDataset(*ds)
+		d.CheckError(err)
+		defer db.Close()
+
+		if !datas.IsValidDatasetName(*onto) {
+			d.CheckErrorNoUsage(fmt.Errorf("Invalid dataset %s, must match %s", *onto, datas.DatasetRe.String()))
+		}
+		ontoRef, ok := db.GetDataset(*onto).MaybeHeadRef()
+		checkIfTrue(!ok, "Dataset %s has no data", *onto)
+
+		oldCommitRef, ok := ds.MaybeHeadRef()
+		checkIfTrue(!ok, "Dataset %s has no data", ds.ID())
+		ds, err = datas.RebaseOnto(db, ds, ontoRef, decideResolveFunc(*resolver))
+		d.CheckErrorNoUsage(err)
+
+		if ds.HeadRef().Equals(oldCommitRef) {
+			fmt.Printf("%s is up to date with %s\n", ds.ID(), *onto)
+		} else {
+			fmt.Printf("New head #%v (was #%v)\n", ds.HeadRef().TargetHash().String(), oldCommitRef.TargetHash().String())
+		}
+		return 0
+	}
+}
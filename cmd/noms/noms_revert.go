@@ -0,0 +1,44 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/attic-labs/kingpin"
+
+	"github.com/attic-labs/noms/cmd/util"
+	"github.com/attic-labs/noms/go/config"
+	"github.com/attic-labs/noms/go/d"
+	"github.com/attic-labs/noms/go/datas"
+	"github.com/attic-labs/noms/go/spec"
+)
+
+func nomsRevert(noms *kingpin.Application) (*kingpin.CmdClause, util.KingpinHandler) {
+	cmd := noms.Command("revert", "Undoes the changes introduced by a commit in a dataset.")
+	resolver := cmd.Flag("policy", "conflict resolution policy - 'n' (no resolution, the default), 'l' (keep the dataset's value), 'r' (take the reverted value) or 'p' (prompt)").Default("n").String()
+	message := cmd.Flag("message", "commit message").String()
+	commit := cmd.Arg("commit", "absolute path to the commit to undo - see Spelling Objects at https://github.com/attic-labs/noms/blob/master/doc/spelling.md").Required().String()
+	ds := cmd.Arg("dataset", "dataset spec to revert the commit in - see Spelling Datasets at https://github.com/attic-labs/noms/blob/master/doc/spelling.md").Required().String()
+
+	return cmd, func(input string) int {
+		cfg := config.NewResolver()
+		db, ds, err := cfg.GetDataset(*ds)
+		d.CheckError(err)
+		defer db.Close()
+
+		commitRef := resolveCommitRef(db, *commit)
+		meta, err := spec.CreateCommitMetaStruct(db, "", *message, nil, nil)
+		d.CheckErrorNoUsage(err)
+
+		oldCommitRef, ok := ds.MaybeHeadRef()
+		checkIfTrue(!ok, "Dataset %s has no data", ds.ID())
+		ds, err = datas.Revert(db, ds, commitRef, decideResolveFunc(*resolver), meta)
+		d.CheckErrorNoUsage(err)
+
+		fmt.Printf("New head #%v (was #%v)\n", ds.HeadRef().TargetHash().String(), oldCommitRef.TargetHash().String())
+		return 0
+	}
+}
@@ -9,11 +9,14 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/attic-labs/kingpin"
 	"github.com/attic-labs/noms/cmd/util"
 	"github.com/attic-labs/noms/go/config"
 	"github.com/attic-labs/noms/go/d"
+	"github.com/attic-labs/noms/go/datas"
+	"github.com/attic-labs/noms/go/spec"
 	"github.com/attic-labs/noms/go/types"
 	"github.com/attic-labs/noms/go/util/datetime"
 	"github.com/attic-labs/noms/go/util/outputpager"
@@ -23,15 +26,29 @@ func nomsShow(noms *kingpin.Application) (*kingpin.CmdClause, util.KingpinHandle
 	cmd := noms.Command("show", "Print Noms values.")
 	showRaw := cmd.Flag("raw", "dump the value in binary format").Bool()
 	showStats := cmd.Flag("stats", "report statics related to the value").Bool()
-	tzName := cmd.Flag("tz", "display formatted date comments in specified timezone, must be: local or utc").Default("local").String()
+	tzName := cmd.Flag("tz", "display timestamps and formatted date comments in specified timezone, must be: local or utc").Default("local").String()
 	path := cmd.Arg("path", "value to display - see Spelling Values at https://github.com/attic-labs/noms/blob/master/doc/spelling.md").Required().String()
 
 	return cmd, func(_ string) int {
 		cfg := config.NewResolver()
-		database, value, err := cfg.GetPath(*path)
+		sp, err := spec.ForPath(cfg.ResolvePathSpec(*path))
 		d.CheckErrorNoUsage(err)
-		defer database.Close()
+		defer sp.Close()
+		database := sp.GetDatabase()
 
+		if sp.Path.Path.IsMultiValued() && !*showRaw && !*showStats {
+			tz, _ := locationFromTimezoneArg(*tzName, nil)
+			datetime.RegisterHRSCommenter(tz)
+			types.SetHRSTimestampLocation(tz)
+
+			pgr := outputpager.Start()
+			defer pgr.Stop()
+
+			writeMatches(pgr.Writer, sp.Path, database)
+			return 0
+		}
+
+		value := sp.GetValue()
 		if value == nil {
 			fmt.Fprintf(os.Stderr, "Value not found: %s\n", *path)
 			return 0
@@ -57,6 +74,7 @@ func nomsShow(noms *kingpin.Application) (*kingpin.CmdClause, util.KingpinHandle
 
 		tz, _ := locationFromTimezoneArg(*tzName, nil)
 		datetime.RegisterHRSCommenter(tz)
+		types.SetHRSTimestampLocation(tz)
 
 		pgr := outputpager.Start()
 		defer pgr.Stop()
@@ -66,3 +84,19 @@ func nomsShow(noms *kingpin.Application) (*kingpin.CmdClause, util.KingpinHandle
 		return 0
 	}
 }
+
+// writeMatches writes the values that the multi-valued |p| matches as the
+// elements of a List, without collecting them into one.
+func writeMatches(w io.Writer, p spec.AbsolutePath, db datas.Database) {
+	fmt.Fprint(w, "[")
+	first := true
+	p.ResolveAll(db, func(_ types.Path, v types.Value) bool {
+		if first {
+			fmt.Fprintln(w)
+			first = false
+		}
+		fmt.Fprintf(w, "  %s,\n", strings.Replace(types.EncodedValue(v), "\n", "\n  ", -1))
+		return false
+	})
+	fmt.Fprintln(w, "]")
+}
@@ -78,6 +78,42 @@ func (s *nomsShowTestSuite) TestNomsShow() {
 	test.EqualsIgnoreHashes(s.T(), res5, res)
 }
 
+func (s *nomsShowTestSuite) TestNomsShowTimestamp() {
+	str := spec.CreateValueSpecString("nbs", s.DBDir, "timestamp")
+	r := s.writeTestData(str, types.Timestamp(1501801626123456789))
+	str = spec.CreateValueSpecString("nbs", s.DBDir, "#"+r.TargetHash().String())
+
+	res, _ := s.MustRun(main, []string{"show", "--tz", "utc", str})
+	s.Equal("timestamp(\"2017-08-03T23:07:06.123456789Z\")\n", res)
+}
+
+func (s *nomsShowTestSuite) TestNomsShowWildcardPath() {
+	str := spec.CreateValueSpecString("nbs", s.DBDir, "wildcard")
+	sp := s.spec(str)
+	defer sp.Close()
+
+	person := func(name string, age float64) types.Value {
+		return types.NewStruct("Person", types.StructData{"name": types.String(name), "age": types.Number(age)})
+	}
+	r := s.writeTestData(str, types.NewList(sp.GetDatabase(), person("ann", 25), person("bob", 35), person("cat", 45)))
+
+	str = spec.CreateValueSpecString("nbs", s.DBDir, "#"+r.TargetHash().String()+"[*].name")
+	res, _ := s.MustRun(main, []string{"show", str})
+	s.Equal("[\n  \"ann\",\n  \"bob\",\n  \"cat\",\n]\n", res)
+
+	str = spec.CreateValueSpecString("nbs", s.DBDir, "#"+r.TargetHash().String()+"[?(.age > 30)].name")
+	res, _ = s.MustRun(main, []string{"show", str})
+	s.Equal("[\n  \"bob\",\n  \"cat\",\n]\n", res)
+
+	str = spec.CreateValueSpecString("nbs", s.DBDir, "#"+r.TargetHash().String()+"[?(.age > 40)]")
+	res, _ = s.MustRun(main, []string{"show", str})
+	s.Equal("[\n  struct Person {\n    age: 45,\n    name: \"cat\",\n  },\n]\n", res)
+
+	str = spec.CreateValueSpecString("nbs", s.DBDir, "#"+r.TargetHash().String()+"[?(.age > 50)]")
+	res, _ = s.MustRun(main, []string{"show", str})
+	s.Equal("[]\n", res)
+}
+
 func (s *nomsShowTestSuite) TestNomsShowNotFound() {
 	str := spec.CreateValueSpecString("nbs", s.DBDir, "not-there")
 	stdout, stderr, err := s.Run(main, []string{"show", str})
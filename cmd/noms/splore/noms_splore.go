@@ -10,6 +10,7 @@ import (
 	"net"
 	"net/http"
 	"reflect"
+	"time"
 
 	"github.com/attic-labs/kingpin"
 	"github.com/attic-labs/noms/cmd/util"
@@ -154,7 +155,7 @@ func getNodeChildren(v types.Value, parentPath string) (children []nodeChild) {
 	}
 
 	switch v := v.(type) {
-	case types.Bool, types.Number, types.String:
+	case types.Bool, types.Number, types.Int, types.Uint, types.Timestamp, types.Decimal, types.String:
 		children = []nodeChild{}
 	case types.Blob:
 		children = getMetaChildren(v)
@@ -200,6 +201,13 @@ func getNodeChildren(v types.Value, parentPath string) (children []nodeChild) {
 		children = []nodeChild{{
 			Value: info(v, childPath("@target")),
 		}}
+	case types.Tuple:
+		children = make([]nodeChild, v.Len())
+		for i, vi := range v.Values() {
+			children[i] = nodeChild{
+				Value: info(vi, childPath("[%d]", i)),
+			}
+		}
 	case types.Struct:
 		children = make([]nodeChild, v.Len())
 		i := 0
@@ -246,12 +254,18 @@ func nodeName(v types.Value) string {
 	}
 
 	switch v := v.(type) {
-	case types.Bool, types.Number, types.String:
+	case types.Bool, types.Number, types.Int, types.Uint, types.String:
 		return fmt.Sprintf("%#v", v)
+	case types.Timestamp:
+		return v.Time().Format(time.RFC3339Nano)
+	case types.Decimal:
+		return v.String()
 	case types.Blob:
 		return fmt.Sprintf("%s(%s)", typeName(v), humanize.Bytes(v.Len()))
 	case types.List, types.Map, types.Set:
 		return fmt.Sprintf("%s(%d)", typeName(v), v.(types.Collection).Len())
+	case types.Tuple:
+		return fmt.Sprintf("%s(%d)", typeName(v), v.Len())
 	case types.Ref:
 		kind := v.TargetType().Desc.Kind()
 		return fmt.Sprintf("%s#%s", kind.String(), v.TargetHash().String())
@@ -310,7 +324,7 @@ func getMetaChildren(v types.Value) (children []nodeChild) {
 
 func nodeHasChildren(v types.Value) bool {
 	switch k := v.Kind(); k {
-	case types.BlobKind, types.BoolKind, types.NumberKind, types.StringKind:
+	case types.BlobKind, types.BoolKind, types.NumberKind, types.IntKind, types.UintKind, types.TimestampKind, types.DecimalKind, types.StringKind:
 		return false
 	case types.RefKind:
 		return true
@@ -318,6 +332,8 @@ func nodeHasChildren(v types.Value) bool {
 		return v.(types.Collection).Len() > 0
 	case types.StructKind:
 		return v.(types.Struct).Len() > 0
+	case types.TupleKind:
+		return v.(types.Tuple).Len() > 0
 	case types.TypeKind:
 		switch d := v.(*types.Type).Desc.(type) {
 		case types.CompoundDesc:
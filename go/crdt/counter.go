@@ -0,0 +1,147 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package crdt
+
+import (
+	"github.com/attic-labs/noms/go/types"
+)
+
+const (
+	gCounterName  = "NomsCRDT_GCounter"
+	pnCounterName = "NomsCRDT_PNCounter"
+
+	countsField = "counts"
+	incField    = "inc"
+	decField    = "dec"
+)
+
+// GCounter is a grow-only counter. It's a struct NomsCRDT_GCounter with a
+// Map from each replica to the Number it has counted:
+//
+//   struct NomsCRDT_GCounter {
+//     counts: Map<String, Number>,
+//   }
+//
+// Merging keeps the greater count of each replica.
+type GCounter struct {
+	s types.Struct
+}
+
+// NewGCounter returns a GCounter whose count is 0.
+func NewGCounter(vrw types.ValueReadWriter) GCounter {
+	return GCounter{types.NewStruct(gCounterName, types.StructData{countsField: types.NewMap(vrw)})}
+}
+
+// GCounterFromValue returns the GCounter that |v| encodes.
+func GCounterFromValue(v types.Value) (GCounter, error) {
+	if s, ok := isStruct(v, gCounterName); ok {
+		if _, ok := s.MaybeGet(countsField); ok {
+			return GCounter{s}, nil
+		}
+	}
+	return GCounter{}, wrongStruct(gCounterName, v)
+}
+
+// Inc returns c with |n| added by |replica|.
+func (c GCounter) Inc(replica string, n uint64) GCounter {
+	return GCounter{c.s.Set(countsField, addCount(c.counts(), replica, n))}
+}
+
+// Count returns the sum of the counts of all replicas.
+func (c GCounter) Count() uint64 {
+	return sumCounts(c.counts())
+}
+
+// Value returns the struct that encodes c.
+func (c GCounter) Value() types.Struct {
+	return c.s
+}
+
+func (c GCounter) counts() types.Map {
+	return c.s.Get(countsField).(types.Map)
+}
+
+func mergeGCounter(a, b types.Struct, parent types.Value, vrw types.ValueReadWriter) (types.Struct, error) {
+	ac, err := GCounterFromValue(a)
+	if err != nil {
+		return types.Struct{}, err
+	}
+	bc, err := GCounterFromValue(b)
+	if err != nil {
+		return types.Struct{}, err
+	}
+	return a.Set(countsField, mergeMax(ac.counts(), bc.counts())), nil
+}
+
+// PNCounter is a counter that can be incremented and decremented. It's a
+// struct NomsCRDT_PNCounter with the increments and the decrements of each
+// replica:
+//
+//   struct NomsCRDT_PNCounter {
+//     inc: Map<String, Number>,
+//     dec: Map<String, Number>,
+//   }
+//
+// Merging keeps the greater increments and decrements of each replica.
+type PNCounter struct {
+	s types.Struct
+}
+
+// NewPNCounter returns a PNCounter whose count is 0.
+func NewPNCounter(vrw types.ValueReadWriter) PNCounter {
+	return PNCounter{types.NewStruct(pnCounterName, types.StructData{incField: types.NewMap(vrw), decField: types.NewMap(vrw)})}
+}
+
+// PNCounterFromValue returns the PNCounter that |v| encodes.
+func PNCounterFromValue(v types.Value) (PNCounter, error) {
+	if s, ok := isStruct(v, pnCounterName); ok {
+		_, incOk := s.MaybeGet(incField)
+		_, decOk := s.MaybeGet(decField)
+		if incOk && decOk {
+			return PNCounter{s}, nil
+		}
+	}
+	return PNCounter{}, wrongStruct(pnCounterName, v)
+}
+
+// Inc returns c with |n| added by |replica|.
+func (c PNCounter) Inc(replica string, n uint64) PNCounter {
+	return PNCounter{c.s.Set(incField, addCount(c.inc(), replica, n))}
+}
+
+// Dec returns c with |n| subtracted by |replica|.
+func (c PNCounter) Dec(replica string, n uint64) PNCounter {
+	return PNCounter{c.s.Set(decField, addCount(c.dec(), replica, n))}
+}
+
+// Count returns the increments of all replicas less their decrements.
+func (c PNCounter) Count() int64 {
+	return int64(sumCounts(c.inc())) - int64(sumCounts(c.dec()))
+}
+
+// Value returns the struct that encodes c.
+func (c PNCounter) Value() types.Struct {
+	return c.s
+}
+
+func (c PNCounter) inc() types.Map {
+	return c.s.Get(incField).(types.Map)
+}
+
+func (c PNCounter) dec() types.Map {
+	return c.s.Get(decField).(types.Map)
+}
+
+func mergePNCounter(a, b types.Struct, parent types.Value, vrw types.ValueReadWriter) (types.Struct, error) {
+	ac, err := PNCounterFromValue(a)
+	if err != nil {
+		return types.Struct{}, err
+	}
+	bc, err := PNCounterFromValue(b)
+	if err != nil {
+		return types.Struct{}, err
+	}
+	return a.Set(incField, mergeMax(ac.inc(), bc.inc())).Set(decField, mergeMax(ac.dec(), bc.dec())), nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package crdt
+
+import (
+	"testing"
+
+	"github.com/attic-labs/noms/go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGCounter(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+	defer vs.Close()
+
+	parent := NewGCounter(vs).Inc("a", 1).Inc("b", 1)
+	assert.Equal(uint64(2), parent.Count())
+	a := parent.Inc("a", 2).Inc("c", 1)
+	b := parent.Inc("b", 5)
+
+	merged, err := GCounterFromValue(mergeBothWays(assert, a.Value(), b.Value(), parent.Value(), vs))
+	assert.NoError(err)
+	assert.Equal(uint64(10), merged.Count())
+
+	// Merging is idempotent.
+	again, err := GCounterFromValue(mergeBothWays(assert, merged.Inc("a", 1).Value(), b.Value(), parent.Value(), vs))
+	assert.NoError(err)
+	assert.Equal(uint64(11), again.Count())
+
+	_, err = GCounterFromValue(types.NewStruct("NomsCRDT_GCounter", nil))
+	assert.Error(err)
+	_, err = GCounterFromValue(types.Number(1))
+	assert.Error(err)
+}
+
+func TestPNCounter(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+	defer vs.Close()
+
+	parent := NewPNCounter(vs).Inc("a", 5)
+	a := parent.Dec("a", 2)
+	b := parent.Dec("b", 4).Inc("b", 1)
+	assert.Equal(int64(3), a.Count())
+	assert.Equal(int64(2), b.Count())
+
+	merged, err := PNCounterFromValue(mergeBothWays(assert, a.Value(), b.Value(), parent.Value(), vs))
+	assert.NoError(err)
+	assert.Equal(int64(0), merged.Count())
+	assert.Equal(int64(-2), merged.Dec("c", 2).Count())
+
+	// Counters added on both sides merge too.
+	merged, err = PNCounterFromValue(mergeBothWays(assert, a.Value(), b.Value(), nil, vs))
+	assert.NoError(err)
+	assert.Equal(int64(0), merged.Count())
+}
@@ -0,0 +1,68 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Package crdt implements conflict-free replicated data types as Noms structs.
+// Each type registers a merge.StructMergeFunc for its struct name, so that
+// merge.ThreeWay merges two versions of it without conflict, and any
+// replicas that have seen the same changes agree on its value, in whatever
+// order they merged them. The struct names start with NomsCRDT_, so that
+// other structs named like the types, e.g. GCounter, merge as usual. Changes
+// are made by a replica, named by a string that's unique among the replicas
+// that change a value concurrently, e.g. a hostname.
+//
+// Values are immutable; the methods that change them return a new value.
+package crdt
+
+import (
+	"fmt"
+
+	"github.com/attic-labs/noms/go/merge"
+	"github.com/attic-labs/noms/go/types"
+)
+
+func init() {
+	merge.RegisterStructMerge(gCounterName, mergeGCounter)
+	merge.RegisterStructMerge(pnCounterName, mergePNCounter)
+	merge.RegisterStructMerge(lwwRegisterName, mergeLWWRegister)
+	merge.RegisterStructMerge(orSetName, mergeORSet)
+	merge.RegisterStructMerge(rgaName, mergeRGA)
+}
+
+func wrongStruct(name string, v types.Value) error {
+	return fmt.Errorf("Expected a struct %s, not %s", name, types.TypeOf(v).Describe())
+}
+
+func isStruct(v types.Value, name string) (types.Struct, bool) {
+	s, ok := v.(types.Struct)
+	return s, ok && s.Name() == name
+}
+
+// mergeMax merges two Maps from replicas to Numbers, keeping the greater
+// Number for each replica.
+func mergeMax(a, b types.Map) types.Map {
+	me := a.Edit()
+	b.IterAll(func(k, v types.Value) {
+		if av, ok := a.MaybeGet(k); !ok || av.Less(v) {
+			me.Set(k, v)
+		}
+	})
+	return me.Map()
+}
+
+// sumCounts returns the sum of the Numbers in a Map from replicas to Numbers.
+func sumCounts(m types.Map) (sum uint64) {
+	m.IterAll(func(k, v types.Value) {
+		sum += uint64(v.(types.Number))
+	})
+	return
+}
+
+// addCount adds |n| to the Number of |replica| in |m|.
+func addCount(m types.Map, replica string, n uint64) types.Map {
+	count := uint64(0)
+	if v, ok := m.MaybeGet(types.String(replica)); ok {
+		count = uint64(v.(types.Number))
+	}
+	return m.Edit().Set(types.String(replica), types.Number(count+n)).Map()
+}
@@ -0,0 +1,91 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package crdt
+
+import (
+	"testing"
+
+	"github.com/attic-labs/noms/go/chunks"
+	"github.com/attic-labs/noms/go/datas"
+	"github.com/attic-labs/noms/go/merge"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestValueStore() *types.ValueStore {
+	return types.NewValueStore((&chunks.TestStorage{}).NewView())
+}
+
+// mergeBothWays merges |a| and |b| with merge.ThreeWay, checks that the
+// result doesn't depend on their order and returns it.
+func mergeBothWays(assert *assert.Assertions, a, b, parent types.Value, vrw types.ValueReadWriter) types.Value {
+	ab, err := merge.ThreeWay(a, b, parent, vrw, nil, nil)
+	assert.NoError(err)
+	ba, err := merge.ThreeWay(b, a, parent, vrw, nil, nil)
+	assert.NoError(err)
+	assert.True(ab.Equals(ba), "%s != %s", types.EncodedValue(ab), types.EncodedValue(ba))
+	return ab
+}
+
+func TestNestedCRDTs(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+	defer vs.Close()
+
+	parent := types.NewStruct("", types.StructData{
+		"likes": NewGCounter(vs).Value(),
+		"title": types.String("hello"),
+	})
+	a := parent.Set("likes", NewGCounter(vs).Inc("a", 2).Value())
+	b := parent.Set("likes", NewGCounter(vs).Inc("b", 3).Value()).Set("title", types.String("hi"))
+
+	merged := mergeBothWays(assert, a, b, parent, vs).(types.Struct)
+	assert.True(types.String("hi").Equals(merged.Get("title")))
+	likes, err := GCounterFromValue(merged.Get("likes"))
+	assert.NoError(err)
+	assert.Equal(uint64(5), likes.Count())
+}
+
+func TestConcurrentCommits(t *testing.T) {
+	assert := assert.New(t)
+	storage := &chunks.MemoryStorage{}
+	db := datas.NewDatabase(storage.NewView())
+	defer db.Close()
+
+	ds, err := db.CommitValue(db.GetDataset("counter"), NewPNCounter(db).Value())
+	assert.NoError(err)
+
+	opts := datas.CommitOptions{Policy: merge.NewThreeWay(nil)}
+	c, err := PNCounterFromValue(ds.HeadValue())
+	assert.NoError(err)
+	_, err = db.Commit(ds, c.Inc("a", 3).Value(), opts)
+	assert.NoError(err)
+	// ds is stale, so this commit has to be merged with the last one.
+	ds, err = db.Commit(ds, c.Dec("b", 1).Value(), opts)
+	assert.NoError(err)
+
+	c, err = PNCounterFromValue(ds.HeadValue())
+	assert.NoError(err)
+	assert.Equal(int64(2), c.Count())
+}
+
+func TestStructsThatArentCRDTs(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+	defer vs.Close()
+
+	// Structs that are only named like a CRDT merge field by field, and
+	// conflict if both sides change the same field.
+	s := func(name string, count, other float64) types.Struct {
+		return types.NewStruct(name, types.StructData{"count": types.Number(count), "other": types.Number(other)})
+	}
+	for _, name := range []string{"GCounter", gCounterName} {
+		merged := mergeBothWays(assert, s(name, 2, 1), s(name, 1, 2), s(name, 1, 1), vs)
+		assert.True(s(name, 2, 2).Equals(merged), name)
+
+		_, err := merge.ThreeWay(s(name, 2, 1), s(name, 3, 1), s(name, 1, 1), vs, nil, nil)
+		assert.IsType(&merge.ErrMergeConflict{}, err, name)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package crdt
+
+import (
+	"fmt"
+
+	"github.com/attic-labs/noms/go/types"
+)
+
+const (
+	orSetName = "NomsCRDT_ORSet"
+
+	entriesField    = "entries"
+	tombstonesField = "tombstones"
+	clockField      = "clock"
+)
+
+// ORSet is an observed-remove set, in which an element that's added
+// concurrently with its removal stays in the set. Each addition of an
+// element is tagged with a string that's unique to it, and removing the
+// element removes the tags that have been seen. It's a struct NomsCRDT_ORSet
+// with the tags of each element, the tags that have been removed and the
+// number of tags each replica has made:
+//
+//   struct NomsCRDT_ORSet {
+//     entries: Map<Value, Set<String>>,
+//     tombstones: Set<String>,
+//     clock: Map<String, Number>,
+//   }
+//
+// Merging unions the tags of each element and the removed tags, and keeps
+// the elements that have tags that weren't removed.
+type ORSet struct {
+	s   types.Struct
+	vrw types.ValueReadWriter
+}
+
+// NewORSet returns an empty ORSet.
+func NewORSet(vrw types.ValueReadWriter) ORSet {
+	return ORSet{types.NewStruct(orSetName, types.StructData{
+		entriesField:    types.NewMap(vrw),
+		tombstonesField: types.NewSet(vrw),
+		clockField:      types.NewMap(vrw),
+	}), vrw}
+}
+
+// ORSetFromValue returns the ORSet that |v| encodes. Changes to it will make
+// collections in |vrw|.
+func ORSetFromValue(vrw types.ValueReadWriter, v types.Value) (ORSet, error) {
+	if s, ok := isStruct(v, orSetName); ok {
+		entries, entriesOk := s.MaybeGet(entriesField)
+		tombstones, tombstonesOk := s.MaybeGet(tombstonesField)
+		clock, clockOk := s.MaybeGet(clockField)
+		if entriesOk && tombstonesOk && clockOk && entries.Kind() == types.MapKind && tombstones.Kind() == types.SetKind && clock.Kind() == types.MapKind {
+			return ORSet{s, vrw}, nil
+		}
+	}
+	return ORSet{}, wrongStruct(orSetName, v)
+}
+
+// Add returns s with |v| added by |replica|.
+func (s ORSet) Add(replica string, v types.Value) ORSet {
+	clock := addCount(s.clock(), replica, 1)
+	tag := types.String(fmt.Sprintf("%s/%d", replica, uint64(clock.Get(types.String(replica)).(types.Number))))
+
+	tags := s.tags(v)
+	tags = tags.Edit().Insert(tag).Set()
+	return ORSet{s.s.Set(entriesField, s.entries().Edit().Set(v, tags).Map()).Set(clockField, clock), s.vrw}
+}
+
+// Remove returns s without |v|.
+func (s ORSet) Remove(v types.Value) ORSet {
+	tags, ok := s.entries().MaybeGet(v)
+	if !ok {
+		return s
+	}
+	te := s.tombstones().Edit()
+	tags.(types.Set).IterAll(func(tag types.Value) {
+		te.Insert(tag)
+	})
+	return ORSet{s.s.Set(entriesField, s.entries().Edit().Remove(v).Map()).Set(tombstonesField, te.Set()), s.vrw}
+}
+
+// Has returns whether |v| is in s.
+func (s ORSet) Has(v types.Value) bool {
+	return s.entries().Has(v)
+}
+
+// Len returns the number of elements in s.
+func (s ORSet) Len() uint64 {
+	return s.entries().Len()
+}
+
+// Elements returns the elements of s as a Set.
+func (s ORSet) Elements() types.Set {
+	se := types.NewSet(s.vrw).Edit()
+	s.entries().IterAll(func(k, v types.Value) {
+		se.Insert(k)
+	})
+	return se.Set()
+}
+
+// Value returns the struct that encodes s.
+func (s ORSet) Value() types.Struct {
+	return s.s
+}
+
+func (s ORSet) entries() types.Map {
+	return s.s.Get(entriesField).(types.Map)
+}
+
+func (s ORSet) tombstones() types.Set {
+	return s.s.Get(tombstonesField).(types.Set)
+}
+
+func (s ORSet) clock() types.Map {
+	return s.s.Get(clockField).(types.Map)
+}
+
+// tags returns the tags of |v| in s, or an empty Set if it's not in s.
+func (s ORSet) tags(v types.Value) types.Set {
+	if tags, ok := s.entries().MaybeGet(v); ok {
+		return tags.(types.Set)
+	}
+	return types.NewSet(s.vrw)
+}
+
+func mergeORSet(a, b types.Struct, parent types.Value, vrw types.ValueReadWriter) (types.Struct, error) {
+	as, err := ORSetFromValue(vrw, a)
+	if err != nil {
+		return types.Struct{}, err
+	}
+	bs, err := ORSetFromValue(vrw, b)
+	if err != nil {
+		return types.Struct{}, err
+	}
+
+	te := as.tombstones().Edit()
+	bs.tombstones().IterAll(func(tag types.Value) {
+		te.Insert(tag)
+	})
+	tombstones := te.Set()
+
+	// Only the elements of a, and those which b has tags for that a doesn't,
+	// can change.
+	ee := as.entries().Edit()
+	merge := func(elem types.Value, tags types.Set) {
+		se := tags.Edit()
+		if bTags, ok := bs.entries().MaybeGet(elem); ok {
+			bTags.(types.Set).IterAll(func(tag types.Value) {
+				se.Insert(tag)
+			})
+		}
+		union := se.Set()
+		ue := union.Edit()
+		union.IterAll(func(tag types.Value) {
+			if tombstones.Has(tag) {
+				ue.Remove(tag)
+			}
+		})
+		if merged := ue.Set(); merged.Empty() {
+			ee.Remove(elem)
+		} else {
+			ee.Set(elem, merged)
+		}
+	}
+	as.entries().IterAll(func(elem, tags types.Value) {
+		merge(elem, tags.(types.Set))
+	})
+	bs.entries().IterAll(func(elem, tags types.Value) {
+		if !as.entries().Has(elem) {
+			merge(elem, types.NewSet(vrw))
+		}
+	})
+
+	return a.Set(entriesField, ee.Map()).Set(tombstonesField, tombstones).Set(clockField, mergeMax(as.clock(), bs.clock())), nil
+}
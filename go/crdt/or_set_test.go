@@ -0,0 +1,41 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package crdt
+
+import (
+	"testing"
+
+	"github.com/attic-labs/noms/go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestORSet(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+	defer vs.Close()
+
+	x, y, z := types.String("x"), types.String("y"), types.Number(42)
+	parent := NewORSet(vs).Add("a", x).Add("a", y)
+	assert.True(parent.Has(x))
+	assert.Equal(uint64(2), parent.Len())
+
+	// a removes x and y, while b adds x again and adds z, so x stays.
+	a := parent.Remove(x).Remove(y)
+	b := parent.Add("b", x).Add("b", z)
+	assert.False(a.Has(x))
+	assert.Equal(parent, parent.Remove(z))
+
+	merged, err := ORSetFromValue(vs, mergeBothWays(assert, a.Value(), b.Value(), parent.Value(), vs))
+	assert.NoError(err)
+	assert.True(types.NewSet(vs, x, z).Equals(merged.Elements()))
+
+	// Removing the element everywhere it was seen removes it.
+	merged, err = ORSetFromValue(vs, mergeBothWays(assert, merged.Remove(x).Value(), b.Value(), parent.Value(), vs))
+	assert.NoError(err)
+	assert.True(types.NewSet(vs, z).Equals(merged.Elements()))
+
+	_, err = ORSetFromValue(vs, NewGCounter(vs).Value())
+	assert.Error(err)
+}
@@ -0,0 +1,109 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package crdt
+
+import (
+	"time"
+
+	"github.com/attic-labs/noms/go/types"
+)
+
+const (
+	lwwRegisterName = "NomsCRDT_LWWRegister"
+
+	valueField     = "value"
+	timestampField = "timestamp"
+	replicaField   = "replica"
+)
+
+// LWWRegister is a last-writer-wins register, which holds the value that was
+// set last. It's a struct NomsCRDT_LWWRegister with the value, the time in
+// Unix nanoseconds when it was set and the replica that set it:
+//
+//   struct NomsCRDT_LWWRegister {
+//     value: Value,
+//     timestamp: Int,
+//     replica: String,
+//   }
+//
+// Merging keeps the register with the later timestamp, or if they're equal,
+// the one set by the greater replica, and then the one with the greater
+// value.
+type LWWRegister struct {
+	s types.Struct
+}
+
+// NewLWWRegister returns an LWWRegister holding |v|, set by |replica| at |t|.
+func NewLWWRegister(v types.Value, replica string, t time.Time) LWWRegister {
+	return LWWRegister{types.NewStruct(lwwRegisterName, types.StructData{
+		valueField:     v,
+		timestampField: types.Int(t.UnixNano()),
+		replicaField:   types.String(replica),
+	})}
+}
+
+// LWWRegisterFromValue returns the LWWRegister that |v| encodes.
+func LWWRegisterFromValue(v types.Value) (LWWRegister, error) {
+	if s, ok := isStruct(v, lwwRegisterName); ok {
+		_, valueOk := s.MaybeGet(valueField)
+		ts, tsOk := s.MaybeGet(timestampField)
+		replica, replicaOk := s.MaybeGet(replicaField)
+		if valueOk && tsOk && replicaOk && ts.Kind() == types.IntKind && replica.Kind() == types.StringKind {
+			return LWWRegister{s}, nil
+		}
+	}
+	return LWWRegister{}, wrongStruct(lwwRegisterName, v)
+}
+
+// Set returns r holding |v|, set by |replica| at |t|.
+func (r LWWRegister) Set(v types.Value, replica string, t time.Time) LWWRegister {
+	return NewLWWRegister(v, replica, t)
+}
+
+// Get returns the value that r holds.
+func (r LWWRegister) Get() types.Value {
+	return r.s.Get(valueField)
+}
+
+// Timestamp returns when the value that r holds was set.
+func (r LWWRegister) Timestamp() time.Time {
+	return time.Unix(0, int64(r.s.Get(timestampField).(types.Int)))
+}
+
+// Replica returns the replica that set the value that r holds.
+func (r LWWRegister) Replica() string {
+	return string(r.s.Get(replicaField).(types.String))
+}
+
+// Value returns the struct that encodes r.
+func (r LWWRegister) Value() types.Struct {
+	return r.s
+}
+
+// less returns whether r was set before |other|.
+func (r LWWRegister) less(other LWWRegister) bool {
+	for _, f := range []string{timestampField, replicaField, valueField} {
+		v1, v2 := r.s.Get(f), other.s.Get(f)
+		if !v1.Equals(v2) {
+			return v1.Less(v2)
+		}
+	}
+	return false
+}
+
+func mergeLWWRegister(a, b types.Struct, parent types.Value, vrw types.ValueReadWriter) (types.Struct, error) {
+	ar, err := LWWRegisterFromValue(a)
+	if err != nil {
+		return types.Struct{}, err
+	}
+	br, err := LWWRegisterFromValue(b)
+	if err != nil {
+		return types.Struct{}, err
+	}
+	if ar.less(br) {
+		return b, nil
+	}
+	return a, nil
+}
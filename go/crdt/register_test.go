@@ -0,0 +1,45 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package crdt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attic-labs/noms/go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLWWRegister(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+	defer vs.Close()
+
+	t0 := time.Unix(1000, 0)
+	parent := NewLWWRegister(types.String("first"), "a", t0)
+	assert.True(types.String("first").Equals(parent.Get()))
+	assert.Equal("a", parent.Replica())
+	assert.True(t0.Equal(parent.Timestamp()))
+
+	a := parent.Set(types.String("a"), "a", t0.Add(2*time.Second))
+	b := parent.Set(types.String("b"), "b", t0.Add(time.Second))
+	merged, err := LWWRegisterFromValue(mergeBothWays(assert, a.Value(), b.Value(), parent.Value(), vs))
+	assert.NoError(err)
+	assert.True(types.String("a").Equals(merged.Get()))
+
+	// Ties are broken by replica, and then by value.
+	b = parent.Set(types.String("b"), "b", t0.Add(2*time.Second))
+	merged, err = LWWRegisterFromValue(mergeBothWays(assert, a.Value(), b.Value(), parent.Value(), vs))
+	assert.NoError(err)
+	assert.True(types.String("b").Equals(merged.Get()))
+
+	b = parent.Set(types.Number(1), "a", t0.Add(2*time.Second))
+	merged, err = LWWRegisterFromValue(mergeBothWays(assert, a.Value(), b.Value(), parent.Value(), vs))
+	assert.NoError(err)
+	assert.True(types.String("a").Equals(merged.Get()))
+
+	_, err = LWWRegisterFromValue(types.NewStruct("NomsCRDT_LWWRegister", types.StructData{"value": types.Number(1)}))
+	assert.Error(err)
+}
@@ -0,0 +1,219 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package crdt
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/attic-labs/noms/go/d"
+	"github.com/attic-labs/noms/go/types"
+)
+
+const (
+	rgaName     = "NomsCRDT_RGA"
+	rgaNodeName = "NomsCRDT_RGANode"
+
+	nodesField   = "nodes"
+	afterField   = "after"
+	removedField = "removed"
+	counterField = "counter"
+)
+
+// RGA is a replicated growable array, a sequence in which concurrent
+// insertions at the same place are ordered the same way by every replica.
+// Each element is a node that's inserted after another, or at the start, and
+// is identified by a Lamport timestamp: a counter that's greater than that
+// of every node its replica had seen, and the replica. Nodes inserted after
+// the same node are ordered by their timestamps, greatest first. Removed
+// elements are kept as tombstones, so that nodes can still be inserted after
+// them. It's a struct NomsCRDT_RGA with the nodes by ID and the greatest
+// counter:
+//
+//   struct NomsCRDT_RGA {
+//     nodes: Map<String, struct NomsCRDT_RGANode {
+//       after: String,
+//       value: Value,
+//       removed: Bool,
+//       counter: Number,
+//       replica: String,
+//     }>,
+//     clock: Number,
+//   }
+//
+// where |after| is the ID of the node it's inserted after, or "" at the start.
+// Merging unions the nodes, and keeps those removed on either side removed.
+type RGA struct {
+	s   types.Struct
+	vrw types.ValueReadWriter
+}
+
+// NewRGA returns an empty RGA.
+func NewRGA(vrw types.ValueReadWriter) RGA {
+	return RGA{types.NewStruct(rgaName, types.StructData{
+		nodesField: types.NewMap(vrw),
+		clockField: types.Number(0),
+	}), vrw}
+}
+
+// RGAFromValue returns the RGA that |v| encodes. Changes to it will make
+// collections in |vrw|.
+func RGAFromValue(vrw types.ValueReadWriter, v types.Value) (RGA, error) {
+	if s, ok := isStruct(v, rgaName); ok {
+		nodes, nodesOk := s.MaybeGet(nodesField)
+		clock, clockOk := s.MaybeGet(clockField)
+		if nodesOk && clockOk && nodes.Kind() == types.MapKind && clock.Kind() == types.NumberKind {
+			return RGA{s, vrw}, nil
+		}
+	}
+	return RGA{}, wrongStruct(rgaName, v)
+}
+
+// Insert returns r with |v| inserted by |replica| at |idx|, which must be at
+// most r.Len().
+func (r RGA) Insert(replica string, idx uint64, v types.Value) RGA {
+	elems := r.elements()
+	d.PanicIfFalse(idx <= uint64(len(elems)))
+	after := ""
+	if idx > 0 {
+		after = elems[idx-1].id
+	}
+
+	counter := r.clock() + 1
+	node := types.NewStruct(rgaNodeName, types.StructData{
+		afterField:   types.String(after),
+		valueField:   v,
+		removedField: types.Bool(false),
+		counterField: types.Number(counter),
+		replicaField: types.String(replica),
+	})
+	id := types.String(fmt.Sprintf("%d@%s", counter, replica))
+	return RGA{r.s.Set(nodesField, r.nodes().Edit().Set(id, node).Map()).Set(clockField, types.Number(counter)), r.vrw}
+}
+
+// Append returns r with |v| appended by |replica|.
+func (r RGA) Append(replica string, v types.Value) RGA {
+	return r.Insert(replica, r.Len(), v)
+}
+
+// Remove returns r without the element at |idx|, which must be less than
+// r.Len().
+func (r RGA) Remove(idx uint64) RGA {
+	elems := r.elements()
+	d.PanicIfFalse(idx < uint64(len(elems)))
+	id := types.String(elems[idx].id)
+	node := r.nodes().Get(id).(types.Struct).Set(removedField, types.Bool(true))
+	return RGA{r.s.Set(nodesField, r.nodes().Edit().Set(id, node).Map()), r.vrw}
+}
+
+// Get returns the element at |idx|, which must be less than r.Len().
+func (r RGA) Get(idx uint64) types.Value {
+	elems := r.elements()
+	d.PanicIfFalse(idx < uint64(len(elems)))
+	return elems[idx].value
+}
+
+// Len returns the number of elements in r.
+func (r RGA) Len() uint64 {
+	return uint64(len(r.elements()))
+}
+
+// List returns the elements of r as a List.
+func (r RGA) List() types.List {
+	le := types.NewList(r.vrw).Edit()
+	for _, e := range r.elements() {
+		le.Append(e.value)
+	}
+	return le.List()
+}
+
+// Value returns the struct that encodes r.
+func (r RGA) Value() types.Struct {
+	return r.s
+}
+
+func (r RGA) nodes() types.Map {
+	return r.s.Get(nodesField).(types.Map)
+}
+
+func (r RGA) clock() uint64 {
+	return uint64(r.s.Get(clockField).(types.Number))
+}
+
+type rgaElement struct {
+	id      string
+	value   types.Value
+	removed bool
+	counter uint64
+	replica string
+}
+
+// elements returns the elements of r that haven't been removed, in order.
+func (r RGA) elements() []rgaElement {
+	children := map[string][]rgaElement{}
+	r.nodes().IterAll(func(k, v types.Value) {
+		node := v.(types.Struct)
+		after := string(node.Get(afterField).(types.String))
+		children[after] = append(children[after], rgaElement{
+			id:      string(k.(types.String)),
+			value:   node.Get(valueField),
+			removed: bool(node.Get(removedField).(types.Bool)),
+			counter: uint64(node.Get(counterField).(types.Number)),
+			replica: string(node.Get(replicaField).(types.String)),
+		})
+	})
+	for _, c := range children {
+		sort.Slice(c, func(i, j int) bool {
+			if c[i].counter != c[j].counter {
+				return c[i].counter > c[j].counter
+			}
+			return c[i].replica > c[j].replica
+		})
+	}
+
+	elems := []rgaElement{}
+	stack := []rgaElement{{id: "", removed: true}}
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !e.removed {
+			elems = append(elems, e)
+		}
+		c := children[e.id]
+		for i := len(c) - 1; i >= 0; i-- {
+			stack = append(stack, c[i])
+		}
+	}
+	return elems
+}
+
+func mergeRGA(a, b types.Struct, parent types.Value, vrw types.ValueReadWriter) (types.Struct, error) {
+	ar, err := RGAFromValue(vrw, a)
+	if err != nil {
+		return types.Struct{}, err
+	}
+	br, err := RGAFromValue(vrw, b)
+	if err != nil {
+		return types.Struct{}, err
+	}
+
+	ne := ar.nodes().Edit()
+	br.nodes().IterAll(func(id, v types.Value) {
+		node := v.(types.Struct)
+		if an, ok := ar.nodes().MaybeGet(id); ok {
+			if !bool(node.Get(removedField).(types.Bool)) {
+				return
+			}
+			node = an.(types.Struct).Set(removedField, types.Bool(true))
+		}
+		ne.Set(id, node)
+	})
+
+	clock := ar.clock()
+	if c := br.clock(); c > clock {
+		clock = c
+	}
+	return a.Set(nodesField, ne.Map()).Set(clockField, types.Number(clock)), nil
+}
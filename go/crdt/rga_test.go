@@ -0,0 +1,55 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package crdt
+
+import (
+	"testing"
+
+	"github.com/attic-labs/noms/go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRGA(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+	defer vs.Close()
+
+	list := func(vals ...string) types.List {
+		l := types.NewList(vs).Edit()
+		for _, v := range vals {
+			l.Append(types.String(v))
+		}
+		return l.List()
+	}
+
+	parent := NewRGA(vs).Append("a", types.String("b")).Insert("a", 0, types.String("a")).Append("a", types.String("c"))
+	assert.True(list("a", "b", "c").Equals(parent.List()))
+	assert.Equal(uint64(3), parent.Len())
+	assert.True(types.String("b").Equals(parent.Get(1)))
+
+	// Concurrent insertions at the same place are kept together, and ordered
+	// by their timestamps, here by replica since their counters are equal.
+	a := parent.Insert("a", 1, types.String("a1")).Insert("a", 2, types.String("a2"))
+	b := parent.Insert("b", 1, types.String("b1")).Remove(3)
+	assert.True(list("a", "a1", "a2", "b", "c").Equals(a.List()))
+	assert.True(list("a", "b1", "b").Equals(b.List()))
+
+	merged, err := RGAFromValue(vs, mergeBothWays(assert, a.Value(), b.Value(), parent.Value(), vs))
+	assert.NoError(err)
+	assert.True(list("a", "b1", "a1", "a2", "b").Equals(merged.List()), types.EncodedValue(merged.List()))
+
+	// An element can be inserted after one that was removed concurrently.
+	a = merged.Insert("a", 5, types.String("d"))
+	b = merged.Remove(4)
+	merged, err = RGAFromValue(vs, mergeBothWays(assert, a.Value(), b.Value(), parent.Value(), vs))
+	assert.NoError(err)
+	assert.True(list("a", "b1", "a1", "a2", "d").Equals(merged.List()))
+
+	assert.Panics(func() { merged.Insert("a", 6, types.String("x")) })
+	assert.Panics(func() { merged.Remove(5) })
+
+	_, err = RGAFromValue(vs, types.NewStruct("NomsCRDT_RGA", types.StructData{"nodes": types.NewList(vs)}))
+	assert.Error(err)
+}
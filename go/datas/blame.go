@@ -0,0 +1,162 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package datas
+
+import (
+	"fmt"
+
+	"github.com/attic-labs/noms/go/d"
+	"github.com/attic-labs/noms/go/hash"
+	"github.com/attic-labs/noms/go/types"
+)
+
+// KeyBlame is the Commit that last changed the entry at Key of a Map.
+type KeyBlame struct {
+	Key    types.Value
+	Commit types.Ref
+}
+
+// BlamePath returns the Commit that last changed the value at |path| in the
+// history of the Commit at |head|. |path| is resolved against each Commit
+// struct, e.g. `.value.prices["apple"]`. From each Commit, the walk goes on
+// to the first parent in which the value at |path| is the same, and stops at
+// the Commit that has no such parent. Values are compared by hash, part of
+// |path| at a time, so unchanged subtrees aren't read.
+func BlamePath(vr types.ValueReader, head types.Ref, path types.Path) (types.Ref, error) {
+	if !IsRefOfCommitType(types.TypeOf(head)) {
+		d.Panic("BlamePath() called on %s", types.TypeOf(head).Describe())
+	}
+	if path.Resolve(head.TargetValue(vr), vr) == nil {
+		return types.Ref{}, fmt.Errorf("No value at %s", path)
+	}
+
+	r := head
+	for {
+		commit := r.TargetValue(vr)
+		next, found := types.Ref{}, false
+		commit.(types.Struct).Get(ParentsField).(types.Set).Iter(func(v types.Value) bool {
+			p := v.(types.Ref)
+			if samePathValue(commit, p.TargetValue(vr), path, vr) {
+				next, found = p, true
+			}
+			return found
+		})
+		if !found {
+			return r, nil
+		}
+		r = next
+	}
+}
+
+// samePathValue returns whether the values at |path| in |a| and |b| are the
+// same, or both missing. It stops resolving |path| as soon as the values
+// it's resolved so far are equal.
+func samePathValue(a, b types.Value, path types.Path, vr types.ValueReader) bool {
+	for _, part := range path {
+		if a.Equals(b) {
+			return true
+		}
+		a, b = part.Resolve(a, vr), part.Resolve(b, vr)
+		if a == nil || b == nil {
+			return a == nil && b == nil
+		}
+	}
+	return a.Equals(b)
+}
+
+// BlameMapKeys returns, for each key of the Map at |path| in the Commit at
+// |head|, the Commit that last changed its entry, as BlamePath would for the
+// path of the entry. The histories of all the keys are walked together, in
+// height order, and each Commit's Map is diffed against its parents' only if
+// they differ.
+func BlameMapKeys(vr types.ValueReader, head types.Ref, path types.Path) ([]KeyBlame, error) {
+	if !IsRefOfCommitType(types.TypeOf(head)) {
+		d.Panic("BlameMapKeys() called on %s", types.TypeOf(head).Describe())
+	}
+	headMap, ok := path.Resolve(head.TargetValue(vr), vr).(types.Map)
+	if !ok {
+		return nil, fmt.Errorf("No Map at %s", path)
+	}
+	mapAt := func(commit types.Value) (types.Map, bool) {
+		m, ok := path.Resolve(commit, vr).(types.Map)
+		return m, ok
+	}
+
+	// pending holds the keys whose entries are the same in each queued
+	// Commit as in |head|.
+	pending := map[hash.Hash]map[hash.Hash]types.Value{}
+	blamed := map[hash.Hash]types.Ref{}
+	q := types.RefByHeight{head}
+	pending[head.TargetHash()] = map[hash.Hash]types.Value{}
+	headMap.IterAll(func(k, v types.Value) {
+		pending[head.TargetHash()][k.Hash()] = k
+	})
+
+	for !q.Empty() {
+		r := q.PopBack()
+		keys := pending[r.TargetHash()]
+		delete(pending, r.TargetHash())
+		commit := r.TargetValue(vr)
+		m, _ := mapAt(commit)
+
+		commit.(types.Struct).Get(ParentsField).(types.Set).Iter(func(v types.Value) bool {
+			p := v.(types.Ref)
+			pm, ok := mapAt(p.TargetValue(vr))
+			if !ok {
+				return false
+			}
+			var same map[hash.Hash]types.Value
+			if pm.Equals(m) {
+				same, keys = keys, nil
+			} else {
+				same, keys = splitChangedKeys(m, pm, keys)
+			}
+			if len(same) > 0 {
+				if _, queued := pending[p.TargetHash()]; !queued {
+					pending[p.TargetHash()] = map[hash.Hash]types.Value{}
+					pushByHeight(&q, p)
+				}
+				for h, k := range same {
+					pending[p.TargetHash()][h] = k
+				}
+			}
+			return len(keys) == 0
+		})
+		for h := range keys {
+			blamed[h] = r
+		}
+	}
+
+	result := []KeyBlame{}
+	headMap.IterAll(func(k, v types.Value) {
+		result = append(result, KeyBlame{k, blamed[k.Hash()]})
+	})
+	return result, nil
+}
+
+// splitChangedKeys splits |keys| into those whose entries are the same in
+// |m| and |parent|, and those whose entries changed.
+func splitChangedKeys(m, parent types.Map, keys map[hash.Hash]types.Value) (same, changed map[hash.Hash]types.Value) {
+	same, changed = map[hash.Hash]types.Value{}, map[hash.Hash]types.Value{}
+	for h, k := range keys {
+		same[h] = k
+	}
+
+	changes := make(chan types.ValueChanged)
+	stop := make(chan struct{})
+	go func() {
+		m.Diff(parent, changes, stop)
+		close(changes)
+	}()
+	for c := range changes {
+		h := c.Key.Hash()
+		if k, ok := same[h]; ok {
+			delete(same, h)
+			changed[h] = k
+		}
+	}
+	close(stop)
+	return
+}
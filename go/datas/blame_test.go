@@ -0,0 +1,73 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package datas
+
+import (
+	"testing"
+
+	"github.com/attic-labs/noms/go/chunks"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlame(t *testing.T) {
+	assert := assert.New(t)
+	storage := &chunks.TestStorage{}
+	db := NewDatabase(storage.NewView())
+	defer db.Close()
+
+	addCommit := func(datasetID string, kv []interface{}, parents ...types.Struct) types.Ref {
+		data := []types.Value{}
+		for i := 0; i < len(kv); i += 2 {
+			data = append(data, types.String(kv[i].(string)), types.Number(kv[i+1].(int)))
+		}
+		ds, err := db.Commit(db.GetDataset(datasetID), types.NewStruct("", types.StructData{
+			"prices": types.NewMap(db, data...),
+		}), CommitOptions{Parents: toRefSet(db, parents...)})
+		assert.NoError(err)
+		return ds.HeadRef()
+	}
+	commit := func(r types.Ref) types.Struct {
+		return r.TargetValue(db).(types.Struct)
+	}
+
+	// main: c1<-c2<-c3<-----c5
+	//            ^          /
+	// fork:      \------c4-/
+	c1 := addCommit("main", []interface{}{"apple", 1, "pear", 1})
+	c2 := addCommit("main", []interface{}{"apple", 2, "pear", 1}, commit(c1))
+	c3 := addCommit("main", []interface{}{"apple", 2, "pear", 1, "plum", 3}, commit(c2))
+	c4 := addCommit("fork", []interface{}{"apple", 2, "pear", 4}, commit(c2))
+	c5 := addCommit("main", []interface{}{"apple", 2, "pear", 4, "plum", 3}, commit(c3), commit(c4))
+
+	blamePath := func(path string) types.Ref {
+		r, err := BlamePath(db, c5, types.MustParsePath(path))
+		assert.NoError(err)
+		return r
+	}
+	assert.Equal(c2.TargetHash(), blamePath(`.value.prices["apple"]`).TargetHash())
+	assert.Equal(c4.TargetHash(), blamePath(`.value.prices["pear"]`).TargetHash())
+	assert.Equal(c3.TargetHash(), blamePath(`.value.prices["plum"]`).TargetHash())
+	assert.Equal(c5.TargetHash(), blamePath(`.value.prices`).TargetHash())
+
+	_, err := BlamePath(db, c5, types.MustParsePath(`.value.prices["kiwi"]`))
+	assert.Error(err)
+
+	blames, err := BlameMapKeys(db, c5, types.MustParsePath(".value.prices"))
+	assert.NoError(err)
+	if assert.Len(blames, 3) {
+		for i, expected := range []struct {
+			key    string
+			commit types.Ref
+		}{{"apple", c2}, {"pear", c4}, {"plum", c3}} {
+			assert.Equal(types.String(expected.key), blames[i].Key)
+			assert.Equal(expected.commit.TargetHash(), blames[i].Commit.TargetHash(), expected.key)
+			assert.Equal(blamePath(`.value.prices["`+expected.key+`"]`).TargetHash(), blames[i].Commit.TargetHash())
+		}
+	}
+
+	_, err = BlameMapKeys(db, c5, types.MustParsePath(`.value.prices["apple"]`))
+	assert.Error(err)
+}
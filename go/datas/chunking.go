@@ -0,0 +1,109 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package datas
+
+import (
+	"fmt"
+
+	"github.com/attic-labs/noms/go/d"
+	"github.com/attic-labs/noms/go/hash"
+	"github.com/attic-labs/noms/go/types"
+)
+
+// ChunkingDatasetID is the ID of the Dataset in which a Database records the
+// types.ChunkingConfig that its values are chunked with, if it doesn't use
+// types.DefaultChunkingConfig. The value of its Head is a ChunkingConfig
+// struct.
+const ChunkingDatasetID = "-/chunking"
+
+const chunkingConfigName = "ChunkingConfig"
+
+// SetChunkingConfig records |cfg| in |db| and makes |db| chunk the values
+// written to it with |cfg| from now on. Every Database that opens the same
+// store will use |cfg| too. Because the same value has a different hash if it
+// is chunked differently, the config can only be set before anything is
+// committed to |db|, and can't be changed afterwards.
+func SetChunkingConfig(db Database, cfg types.ChunkingConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if current, ok := maybeChunkingConfig(db); ok {
+		if current == cfg {
+			return nil
+		}
+		return fmt.Errorf("Database is already chunked with %s", current)
+	}
+	if !db.Datasets().Empty() {
+		return fmt.Errorf("Chunking can only be configured before anything is committed to a database")
+	}
+
+	_, err := db.CommitValue(db.GetDataset(ChunkingDatasetID), chunkingConfigToStruct(cfg))
+	if err != nil {
+		return err
+	}
+	return db.(*database).ValueStore.SetChunkingConfig(cfg)
+}
+
+// ChunkingConfig returns the config recorded in the store by
+// SetChunkingConfig, or types.DefaultChunkingConfig if there isn't one.
+func (db *database) ChunkingConfig() types.ChunkingConfig {
+	db.loadChunkingConfig(db.rt.Root())
+	return db.ValueStore.ChunkingConfig()
+}
+
+// loadChunkingConfig sets the config recorded in the Datasets at |rootHash|,
+// if there is one, on db's ValueStore. Values read from db are decoded with
+// the ValueStore, so it has to know the config before any of them is edited.
+// An empty store has no config yet, so it's loaded the first time the root
+// isn't empty.
+func (db *database) loadChunkingConfig(rootHash hash.Hash) {
+	if rootHash.IsEmpty() {
+		return
+	}
+	db.chunkingMu.Lock()
+	defer db.chunkingMu.Unlock()
+	if db.chunkingLoaded {
+		return
+	}
+	db.chunkingLoaded = true
+	if cfg, ok := chunkingConfigIn(db.ReadValue(rootHash).(types.Map), db); ok {
+		d.PanicIfError(db.ValueStore.SetChunkingConfig(cfg))
+	}
+}
+
+// maybeChunkingConfig returns the config recorded in |db|, if there is one.
+func maybeChunkingConfig(db Database) (types.ChunkingConfig, bool) {
+	return chunkingConfigIn(db.Datasets(), db)
+}
+
+func chunkingConfigIn(datasets types.Map, vr types.ValueReader) (types.ChunkingConfig, bool) {
+	r, ok := datasets.MaybeGet(types.String(ChunkingDatasetID))
+	if !ok {
+		return types.ChunkingConfig{}, false
+	}
+	s, ok := r.(types.Ref).TargetValue(vr).(types.Struct).Get(ValueField).(types.Struct)
+	d.PanicIfFalse(ok && s.Name() == chunkingConfigName)
+
+	number := func(name string) uint32 {
+		return uint32(s.Get(name).(types.Number))
+	}
+	return types.ChunkingConfig{
+		TargetSize: number("targetSize"),
+		MinSize:    number("minSize"),
+		MaxSize:    number("maxSize"),
+		Window:     number("window"),
+		Hash:       string(s.Get("hash").(types.String)),
+	}, true
+}
+
+func chunkingConfigToStruct(cfg types.ChunkingConfig) types.Struct {
+	return types.NewStruct(chunkingConfigName, types.StructData{
+		"targetSize": types.Number(cfg.TargetSize),
+		"minSize":    types.Number(cfg.MinSize),
+		"maxSize":    types.Number(cfg.MaxSize),
+		"window":     types.Number(cfg.Window),
+		"hash":       types.String(cfg.Hash),
+	})
+}
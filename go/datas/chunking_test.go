@@ -0,0 +1,81 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package datas
+
+import (
+	"testing"
+
+	"github.com/attic-labs/noms/go/chunks"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetChunkingConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	numbers := func(vrw types.ValueReadWriter) types.List {
+		vs := make([]types.Value, 10000)
+		for i := range vs {
+			vs[i] = types.Number(i)
+		}
+		return types.NewList(vrw, vs...)
+	}
+
+	storage := &chunks.TestStorage{}
+	db := NewDatabase(storage.NewView())
+	defer db.Close()
+	def := numbers(db)
+
+	cfg := types.ChunkingConfig{TargetSize: 256, MinSize: 64, MaxSize: 1024, Hash: "gear"}
+	assert.Error(SetChunkingConfig(db, types.ChunkingConfig{TargetSize: 100}))
+	assert.NoError(SetChunkingConfig(db, cfg))
+	assert.Equal(cfg, db.(*database).ChunkingConfig())
+
+	l := numbers(db)
+	assert.False(def.Equals(l))
+	ds, err := db.CommitValue(db.GetDataset("ds"), l)
+	assert.NoError(err)
+
+	// Setting the same config again is fine, but it can't be changed now.
+	assert.NoError(SetChunkingConfig(db, cfg))
+	assert.Error(SetChunkingConfig(db, types.DefaultChunkingConfig()))
+
+	// Another Database on the same store reads the config from it.
+	db2 := NewDatabase(storage.NewView())
+	defer db2.Close()
+	assert.Equal(cfg, db2.(*database).ChunkingConfig())
+	assert.True(l.Equals(numbers(db2)))
+	assert.True(l.Equals(db2.GetDataset("ds").HeadValue()))
+	assert.True(ds.HeadRef().Equals(db2.GetDataset("ds").HeadRef()))
+
+	// Values read from a reopened Database are edited with the config, even if
+	// nothing asks for it first.
+	kvs := func(v string) []types.Value {
+		kvs := []types.Value{}
+		for i := 0; i < 2000; i++ {
+			kvs = append(kvs, types.Number(i), types.String(v))
+		}
+		return kvs
+	}
+	_, err = db.CommitValue(db.GetDataset("map"), types.NewMap(db, kvs("a")...))
+	assert.NoError(err)
+	db4 := NewDatabase(storage.NewView())
+	defer db4.Close()
+	me := db4.GetDataset("map").HeadValue().(types.Map).Edit()
+	for i := 0; i < 2000; i++ {
+		me.Set(types.Number(i), types.String("b"))
+	}
+	edited := me.Map()
+	assert.True(types.NewMap(db4, kvs("b")...).Equals(edited))
+
+	// A Database that already has data can't be configured.
+	storage = &chunks.TestStorage{}
+	db3 := NewDatabase(storage.NewView())
+	defer db3.Close()
+	_, err = db3.CommitValue(db3.GetDataset("ds"), types.Number(1))
+	assert.NoError(err)
+	assert.Error(SetChunkingConfig(db3, cfg))
+	assert.Equal(types.DefaultChunkingConfig(), db3.(*database).ChunkingConfig())
+}
@@ -74,6 +74,191 @@ func FindCommonAncestor(c1, c2 types.Ref, vr types.ValueReader) (a types.Ref, ok
 	return
 }
 
+// FindMergeBases returns the best common ancestors of |refs|: the Commits
+// reachable from all of them which are not ancestors of another such Commit.
+// There is usually exactly one, but criss-cross merges in the histories can
+// produce several. The result is empty if the Commits share no history.
+func FindMergeBases(refs types.RefSlice, vr types.ValueReader) types.RefSlice {
+	for _, r := range refs {
+		if !IsRefOfCommitType(types.TypeOf(r)) {
+			d.Panic("FindMergeBases() called on %s", types.TypeOf(r).Describe())
+		}
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+
+	bases := types.RefSlice{refs[0]}
+	for _, r := range refs[1:] {
+		next := types.RefSlice{}
+		for _, b := range bases {
+			next = append(next, findMergeBases(r, types.RefSlice{b}, vr)...)
+		}
+		bases = removeRedundantRefs(next, vr)
+	}
+	return bases
+}
+
+// findMergeBases returns the best common ancestors of |one| and the union of
+// the histories of |twos|. It walks the histories in height order, painting
+// each Commit with the side(s) it is reachable from. Commits reachable from
+// both sides are candidates, and everything below a candidate is marked stale
+// so the walk can stop once only stale Commits remain.
+func findMergeBases(one types.Ref, twos types.RefSlice, vr types.ValueReader) types.RefSlice {
+	const (
+		fromOne = 1 << iota
+		fromTwo
+		stale
+	)
+	// Each Commit is queued once, since its descendants are all popped before
+	// it. live counts the queued Commits that aren't stale.
+	flags := map[hash.Hash]int{}
+	q := types.RefByHeight{}
+	live := 0
+	paint := func(r types.Ref, f int) {
+		old, queued := flags[r.TargetHash()]
+		if !queued {
+			pushByHeight(&q, r)
+		} else if old&stale == 0 {
+			live--
+		}
+		if (old|f)&stale == 0 {
+			live++
+		}
+		flags[r.TargetHash()] = old | f
+	}
+
+	paint(one, fromOne)
+	for _, r := range twos {
+		paint(r, fromTwo)
+	}
+
+	candidates := types.RefSlice{}
+	for live > 0 {
+		r := q.PopBack()
+		f := flags[r.TargetHash()]
+		if f&stale == 0 {
+			live--
+		}
+		if f == fromOne|fromTwo {
+			candidates = append(candidates, r)
+			f |= stale
+		}
+		r.TargetValue(vr).(types.Struct).Get(ParentsField).(types.Set).IterAll(func(v types.Value) {
+			p := v.(types.Ref)
+			if flags[p.TargetHash()]&f != f {
+				paint(p, f)
+			}
+		})
+	}
+	return removeRedundantRefs(candidates, vr)
+}
+
+// DiffHistories returns the Commits reachable from |a| but not from |b|, and
+// those reachable from |b| but not from |a|, each from the highest to the
+// lowest. The former are what "git log b..a" lists and the latter what
+// "git log a..b" lists; together they are "git log a...b". Like
+// FindCommonAncestor, it walks both histories in height order, so only the
+// Commits above their common history are read.
+func DiffHistories(a, b types.Ref, vr types.ValueReader) (onlyA, onlyB types.RefSlice) {
+	if !IsRefOfCommitType(types.TypeOf(a)) {
+		d.Panic("DiffHistories() called on %s", types.TypeOf(a).Describe())
+	}
+	if !IsRefOfCommitType(types.TypeOf(b)) {
+		d.Panic("DiffHistories() called on %s", types.TypeOf(b).Describe())
+	}
+
+	const (
+		fromA = 1 << iota
+		fromB
+	)
+	// A Commit's descendants are all higher than it, so by the time it's
+	// popped, it has been painted by every side it's reachable from. oneSide
+	// counts the queued Commits painted by only one side; once there are none,
+	// all that's left is common history.
+	flags := map[hash.Hash]int{}
+	q := types.RefByHeight{}
+	oneSide := 0
+	paint := func(r types.Ref, f int) {
+		old, queued := flags[r.TargetHash()]
+		if !queued {
+			pushByHeight(&q, r)
+		} else if old != fromA|fromB {
+			oneSide--
+		}
+		if old|f != fromA|fromB {
+			oneSide++
+		}
+		flags[r.TargetHash()] = old | f
+	}
+
+	paint(a, fromA)
+	paint(b, fromB)
+	for oneSide > 0 {
+		r := q.PopBack()
+		f := flags[r.TargetHash()]
+		switch f {
+		case fromA:
+			onlyA = append(onlyA, r)
+			oneSide--
+		case fromB:
+			onlyB = append(onlyB, r)
+			oneSide--
+		}
+		r.TargetValue(vr).(types.Struct).Get(ParentsField).(types.Set).IterAll(func(v types.Value) {
+			paint(v.(types.Ref), f)
+		})
+	}
+	return
+}
+
+// pushByHeight inserts |r| into |q| where it belongs in height order, so that
+// |q| needn't be sorted again after each push.
+func pushByHeight(q *types.RefByHeight, r types.Ref) {
+	i := sort.Search(len(*q), func(i int) bool { return types.HeightOrder((*q)[i], r) })
+	*q = append(*q, types.Ref{})
+	copy((*q)[i+1:], (*q)[i:])
+	(*q)[i] = r
+}
+
+// removeRedundantRefs drops duplicates and every Commit in |refs| that is an
+// ancestor of another one.
+func removeRedundantRefs(refs types.RefSlice, vr types.ValueReader) types.RefSlice {
+	unique := types.RefByHeight(refs)
+	unique.Unique()
+	result := types.RefSlice{}
+	for i, r := range unique {
+		redundant := false
+		for j, o := range unique {
+			if i != j && o.Height() > r.Height() && isAncestor(r, o, vr) {
+				redundant = true
+				break
+			}
+		}
+		if !redundant {
+			result = append(result, r)
+		}
+	}
+	sort.Sort(result)
+	return result
+}
+
+// isAncestor returns true if the Commit at |a| is reachable from the Commit at |b|.
+func isAncestor(a, b types.Ref, vr types.ValueReader) bool {
+	q := &types.RefByHeight{b}
+	for !q.Empty() && q.MaxHeight() >= a.Height() {
+		refs := q.PopRefsOfHeight(q.MaxHeight())
+		for _, r := range refs {
+			if r.TargetHash() == a.TargetHash() {
+				return true
+			}
+		}
+		parentsToQueue(refs, q, vr)
+		q.Unique()
+	}
+	return false
+}
+
 func parentsToQueue(refs types.RefSlice, q *types.RefByHeight, vr types.ValueReader) {
 	for _, r := range refs {
 		c := r.TargetValue(vr).(types.Struct)
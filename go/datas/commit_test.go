@@ -204,6 +204,114 @@ func TestFindCommonAncestor(t *testing.T) {
 	}
 }
 
+func TestFindMergeBases(t *testing.T) {
+	assert := assert.New(t)
+	storage := &chunks.TestStorage{}
+	db := NewDatabase(storage.NewView())
+	defer db.Close()
+
+	addCommit := func(datasetID string, val string, parents ...types.Struct) types.Struct {
+		ds, err := db.Commit(db.GetDataset(datasetID), types.String(val), CommitOptions{Parents: toRefSet(db, parents...)})
+		assert.NoError(err)
+		return ds.Head()
+	}
+
+	assertMergeBases := func(expected []types.Struct, commits ...types.Struct) {
+		refs := types.RefSlice{}
+		for _, c := range commits {
+			refs = append(refs, types.NewRef(c))
+		}
+		found := []string{}
+		for _, r := range FindMergeBases(refs, db) {
+			found = append(found, string(r.TargetValue(db).(types.Struct).Get(ValueField).(types.String)))
+		}
+		exp := []string{}
+		for _, c := range expected {
+			exp = append(exp, string(c.Get(ValueField).(types.String)))
+		}
+		assert.ElementsMatch(exp, found)
+	}
+
+	// Build commit DAG with a criss-cross merge between ds-a and ds-b:
+	//
+	// ds-a: a1<-a2<-a3<-a4 (a4 also has parent b3)
+	//        ^   ^
+	// ds-b:  |   \--b3<-b4 (b4 also has parent a3)
+	//        |
+	// ds-c:  \--c2
+	//
+	// ds-x: x1
+	//
+	a, b, c, x := "ds-a", "ds-b", "ds-c", "ds-x"
+	a1 := addCommit(a, "a1")
+	x1 := addCommit(x, "x1")
+	a2 := addCommit(a, "a2", a1)
+	c2 := addCommit(c, "c2", a1)
+	a3 := addCommit(a, "a3", a2)
+	b3 := addCommit(b, "b3", a2)
+	a4 := addCommit(a, "a4", a3, b3)
+	b4 := addCommit(b, "b4", b3, a3)
+
+	assertMergeBases([]types.Struct{a1}, a1, a1)
+	assertMergeBases([]types.Struct{a2}, a3, b3)
+	assertMergeBases([]types.Struct{a3, b3}, a4, b4) // Criss-cross
+	assertMergeBases([]types.Struct{a1}, a4, b4, c2)
+	assertMergeBases([]types.Struct{a2}, a3, b3, a4)
+	assertMergeBases([]types.Struct{}, a4, x1)
+}
+
+func TestDiffHistories(t *testing.T) {
+	assert := assert.New(t)
+	storage := &chunks.TestStorage{}
+	db := NewDatabase(storage.NewView())
+	defer db.Close()
+
+	addCommit := func(datasetID string, val string, parents ...types.Struct) types.Struct {
+		ds, err := db.Commit(db.GetDataset(datasetID), types.String(val), CommitOptions{Parents: toRefSet(db, parents...)})
+		assert.NoError(err)
+		return ds.Head()
+	}
+	values := func(refs types.RefSlice) (vals []string) {
+		for _, r := range refs {
+			vals = append(vals, string(r.TargetValue(db).(types.Struct).Get(ValueField).(types.String)))
+		}
+		return
+	}
+	assertDiff := func(expectedA, expectedB []string, a, b types.Struct) {
+		onlyA, onlyB := DiffHistories(types.NewRef(a), types.NewRef(b), db)
+		assert.ElementsMatch(expectedA, values(onlyA))
+		assert.ElementsMatch(expectedB, values(onlyB))
+		for _, refs := range []types.RefSlice{onlyA, onlyB} {
+			for i := 1; i < len(refs); i++ {
+				assert.True(refs[i-1].Height() >= refs[i].Height())
+			}
+		}
+	}
+
+	// ds-a: a1<-a2<-a3<-a4 (a4 also has parent b3)
+	//            ^
+	// ds-b:      \--b3<-b4<-b5
+	//
+	// ds-x: x1
+	//
+	a, b, x := "ds-a", "ds-b", "ds-x"
+	a1 := addCommit(a, "a1")
+	x1 := addCommit(x, "x1")
+	a2 := addCommit(a, "a2", a1)
+	a3 := addCommit(a, "a3", a2)
+	b3 := addCommit(b, "b3", a2)
+	a4 := addCommit(a, "a4", a3, b3)
+	b4 := addCommit(b, "b4", b3)
+	b5 := addCommit(b, "b5", b4)
+
+	assertDiff(nil, nil, a4, a4)
+	assertDiff(nil, []string{"a4", "a3", "b3"}, a2, a4)
+	assertDiff([]string{"a3"}, []string{"b3"}, a3, b3)
+	assertDiff([]string{"a4", "a3"}, []string{"b5", "b4"}, a4, b5)
+	assertDiff([]string{"b5", "b4"}, []string{"a4", "a3"}, b5, a4)
+	assertDiff([]string{"a2", "a1"}, []string{"x1"}, a2, x1)
+}
+
 func TestNewCommitRegressionTest(t *testing.T) {
 	storage := &chunks.TestStorage{}
 	db := NewDatabase(storage.NewView())
@@ -0,0 +1,73 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package datas
+
+import (
+	"fmt"
+
+	"github.com/attic-labs/noms/go/types"
+)
+
+// CommitValidator is consulted before |commit| becomes the new Head of the
+// Dataset named |datasetID|. |head| is the Commit that is about to be
+// replaced; it is the zero Struct if the Dataset does not exist yet.
+// Returning a non-nil error rejects the update, and the Database operation
+// that attempted it fails with a CommitRejectedError.
+type CommitValidator func(datasetID string, commit, head types.Struct, vr types.ValueReader) error
+
+// CommitRejectedError is returned by Commit() (et al) when a CommitValidator,
+// either registered locally or on a remote server, refuses a new Head.
+type CommitRejectedError struct {
+	DatasetID string
+	Reason    string
+}
+
+func (e CommitRejectedError) Error() string {
+	return fmt.Sprintf("Commit to dataset %s rejected: %s", e.DatasetID, e.Reason)
+}
+
+// runCommitValidators runs SchemaValidator followed by |validators|, stopping at the first rejection.
+func runCommitValidators(validators []CommitValidator, datasetID string, commit, head types.Struct, vr types.ValueReader) error {
+	if err := SchemaValidator(datasetID, commit, head, vr); err != nil {
+		return CommitRejectedError{datasetID, err.Error()}
+	}
+	for _, v := range validators {
+		if err := v(datasetID, commit, head, vr); err != nil {
+			return CommitRejectedError{datasetID, err.Error()}
+		}
+	}
+	return nil
+}
+
+// validateDatasetChanges runs |validators| against every Dataset whose Head
+// differs between |proposed| and |last|. Removed Datasets are not validated.
+func validateDatasetChanges(validators []CommitValidator, proposed, last types.Map, vr types.ValueReader) error {
+	stopChan := make(chan struct{})
+	changes := make(chan types.ValueChanged)
+	go func() {
+		defer close(changes)
+		proposed.Diff(last, changes, stopChan)
+	}()
+	defer func() {
+		close(stopChan)
+		for range changes {
+		}
+	}()
+
+	for change := range changes {
+		var head types.Struct
+		switch change.ChangeType {
+		case types.DiffChangeRemoved:
+			continue
+		case types.DiffChangeModified:
+			head = change.OldValue.(types.Ref).TargetValue(vr).(types.Struct)
+		}
+		commit := change.NewValue.(types.Ref).TargetValue(vr).(types.Struct)
+		if err := runCommitValidators(validators, string(change.Key.(types.String)), commit, head, vr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -99,6 +99,14 @@ type Database interface {
 	// Regardless, Datasets() is updated to match backing storage upon return.
 	FastForward(ds Dataset, newHeadRef types.Ref) (Dataset, error)
 
+	// AddCommitValidator registers v to be run before any operation that
+	// moves the Head of a Dataset (Commit, SetHead or FastForward) is
+	// persisted. If v returns an error, the operation fails with a
+	// CommitRejectedError and the Database is left unchanged. SchemaValidator
+	// always runs first and need not be registered. Validators should be
+	// registered before the Database is shared between goroutines.
+	AddCommitValidator(v CommitValidator)
+
 	// Stats may return some kind of struct that reports statistics about the
 	// ChunkStore that backs this Database instance. The type is
 	// implementation-dependent, and impls may return nil
@@ -7,6 +7,7 @@ package datas
 import (
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/attic-labs/noms/go/chunks"
 	"github.com/attic-labs/noms/go/d"
@@ -18,7 +19,11 @@ import (
 
 type database struct {
 	*types.ValueStore
-	rt rootTracker
+	rt           rootTracker
+	validators []CommitValidator
+
+	chunkingMu     sync.Mutex
+	chunkingLoaded bool
 }
 
 var (
@@ -39,10 +44,12 @@ func newDatabase(cs chunks.ChunkStore) *database {
 		vs.SetEnforceCompleteness(false)
 	}
 
-	return &database{
+	db := &database{
 		ValueStore: vs, // ValueStore is responsible for closing |cs|
 		rt:         vs,
 	}
+	db.loadChunkingConfig(db.rt.Root())
+	return db
 }
 
 func (db *database) chunkStore() chunks.ChunkStore {
@@ -74,6 +81,7 @@ func (db *database) Datasets() types.Map {
 		return types.NewMap(db)
 	}
 
+	db.loadChunkingConfig(rootHash)
 	return db.ReadValue(rootHash).(types.Map)
 }
 
@@ -89,6 +97,10 @@ func (db *database) GetDataset(datasetID string) Dataset {
 	return newDataset(db, datasetID, head)
 }
 
+func (db *database) AddCommitValidator(v CommitValidator) {
+	db.validators = append(db.validators, v)
+}
+
 func (db *database) Rebase() {
 	db.rt.Rebase()
 }
@@ -108,6 +120,9 @@ func (db *database) doSetHead(ds Dataset, newHeadRef types.Ref) error {
 	commit := db.validateRefAsCommit(newHeadRef)
 
 	currentRootHash, currentDatasets := db.rt.Root(), db.Datasets()
+	if err := db.validateCommit(ds.ID(), commit, currentDatasets); err != nil {
+		return err
+	}
 	commitRef := db.WriteValue(commit) // will be orphaned if the tryCommitChunks() below fails
 
 	currentDatasets = currentDatasets.Edit().Set(types.String(ds.ID()), types.ToRefOfValue(commitRef)).Map()
@@ -153,7 +168,7 @@ func (db *database) doCommit(datasetID string, commit types.Struct, mergePolicy
 	var err error
 	for err = ErrOptimisticLockFailed; err == ErrOptimisticLockFailed; {
 		currentRootHash, currentDatasets := db.rt.Root(), db.Datasets()
-		commitRef := db.WriteValue(commit) // will be orphaned if the tryCommitChunks() below fails
+		newHead, commitRef := commit, db.WriteValue(commit) // will be orphaned if the tryCommitChunks() below fails
 
 		// If there's nothing in the DB yet, skip all this logic.
 		if !currentRootHash.IsEmpty() {
@@ -181,10 +196,14 @@ func (db *database) doCommit(datasetID string, commit types.Struct, mergePolicy
 					if err != nil {
 						return err
 					}
-					commitRef = db.WriteValue(NewCommit(merged, types.NewSet(db, commitRef, currentHeadRef), types.EmptyStruct))
+					newHead = NewCommit(merged, types.NewSet(db, commitRef, currentHeadRef), inheritSchema(types.EmptyStruct, commit))
+					commitRef = db.WriteValue(newHead)
 				}
 			}
 		}
+		if err := db.validateCommit(datasetID, newHead, currentDatasets); err != nil {
+			return err
+		}
 		currentDatasets = currentDatasets.Edit().Set(types.String(datasetID), types.ToRefOfValue(commitRef)).Map()
 		err = db.tryCommitChunks(currentDatasets, currentRootHash)
 	}
@@ -226,12 +245,24 @@ func (db *database) doDelete(datasetIDstr string) error {
 func (db *database) tryCommitChunks(currentDatasets types.Map, currentRootHash hash.Hash) (err error) {
 	newRootHash := db.WriteValue(currentDatasets).TargetHash()
 
-	if !db.rt.Commit(newRootHash, currentRootHash) {
+	// A remote server may refuse the new root if one of its CommitValidators rejects a changed Dataset.
+	var committed bool
+	err = d.Try(func() { committed = db.rt.Commit(newRootHash, currentRootHash) }, CommitRejectedError{})
+	if err == nil && !committed {
 		err = ErrOptimisticLockFailed
 	}
 	return
 }
 
+// validateCommit runs the registered CommitValidators against the proposal to make |commit| the Head of |datasetID| in |currentDatasets|.
+func (db *database) validateCommit(datasetID string, commit types.Struct, currentDatasets types.Map) error {
+	var head types.Struct
+	if r, ok := currentDatasets.MaybeGet(types.String(datasetID)); ok {
+		head = r.(types.Ref).TargetValue(db).(types.Struct)
+	}
+	return runCommitValidators(db.validators, datasetID, commit, head, db)
+}
+
 func (db *database) validateRefAsCommit(r types.Ref) types.Struct {
 	v := db.ReadValue(r.TargetHash())
 
@@ -257,6 +288,9 @@ func buildNewCommit(ds Dataset, v types.Value, opts CommitOptions) types.Struct
 	if meta.IsZeroValue() {
 		meta = types.EmptyStruct
 	}
+	if head, ok := ds.MaybeHead(); ok {
+		meta = inheritSchema(meta, head)
+	}
 	return NewCommit(v, parents, meta)
 }
 
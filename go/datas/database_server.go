@@ -29,6 +29,8 @@ type RemoteDatabaseServer struct {
 	l       *net.Listener
 	csChan  chan *connectionState
 	closing bool
+	// Validators run against every Dataset Head a client proposes to change.
+	validators []CommitValidator
 	// Called just before the server is started.
 	Ready func()
 }
@@ -39,16 +41,24 @@ func NewRemoteDatabaseServer(cs chunks.ChunkStore, address string, port int) *Re
 		d.Panic("SDK version %s is incompatible with data of version %s", constants.NomsVersion, dataVersion)
 	}
 	return &RemoteDatabaseServer{
-		cs, address, port, nil, make(chan *connectionState, 16), false, func() {},
+		cs, address, port, nil, make(chan *connectionState, 16), false, nil, func() {},
 	}
 }
 
+// AddCommitValidator registers v to be run whenever a client attempts to move
+// the Head of a Dataset. It must be called before Run().
+func (s *RemoteDatabaseServer) AddCommitValidator(v CommitValidator) {
+	s.validators = append(s.validators, v)
+}
+
 // Port is the actual port used. This may be different than the port passed in to NewRemoteDatabaseServer.
 func (s *RemoteDatabaseServer) Port() int {
 	return s.port
 }
 
-func Router(cs chunks.ChunkStore, prefix string) *httprouter.Router {
+// Router returns an httprouter.Router serving the Noms HTTP API for |cs|
+// under |prefix|. Root updates are checked against |validators|.
+func Router(cs chunks.ChunkStore, prefix string, validators ...CommitValidator) *httprouter.Router {
 	router := httprouter.New()
 
 	router.POST(prefix+constants.GetRefsPath, corsHandle(makeHandle(HandleGetRefs, cs)))
@@ -57,7 +67,7 @@ func Router(cs chunks.ChunkStore, prefix string) *httprouter.Router {
 	router.POST(prefix+constants.HasRefsPath, corsHandle(makeHandle(HandleHasRefs, cs)))
 	router.OPTIONS(prefix+constants.HasRefsPath, corsHandle(noopHandle))
 	router.GET(prefix+constants.RootPath, corsHandle(makeHandle(HandleRootGet, cs)))
-	router.POST(prefix+constants.RootPath, corsHandle(makeHandle(HandleRootPost, cs)))
+	router.POST(prefix+constants.RootPath, corsHandle(makeHandle(NewRootPostHandler(validators...), cs)))
 	router.OPTIONS(prefix+constants.RootPath, corsHandle(noopHandle))
 	router.POST(prefix+constants.WriteValuePath, corsHandle(makeHandle(HandleWriteValue, cs)))
 	router.OPTIONS(prefix+constants.WriteValuePath, corsHandle(noopHandle))
@@ -85,7 +95,7 @@ func (s *RemoteDatabaseServer) Run() {
 	d.Chk.NoError(err)
 	log.Printf("Listening on  %s:%d...\n", s.address, s.port)
 
-	router := Router(s.cs, "")
+	router := Router(s.cs, "", s.validators...)
 
 	srv := &http.Server{
 		Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
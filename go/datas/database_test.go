@@ -5,6 +5,7 @@
 package datas
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/attic-labs/noms/go/chunks"
@@ -567,3 +568,37 @@ func (suite *DatabaseSuite) TestMetaOption() {
 	c := ds.Head()
 	suite.Equal(types.String("arv"), c.Get("meta").(types.Struct).Get("author"))
 }
+
+func (suite *DatabaseSuite) TestCommitValidator() {
+	var err error
+	ds := suite.db.GetDataset("ds1")
+	ds, err = suite.db.CommitValue(ds, types.String("a"))
+	suite.NoError(err)
+	aCommitRef := ds.HeadRef()
+
+	var seenHead types.Struct
+	suite.db.AddCommitValidator(func(datasetID string, commit, head types.Struct, vr types.ValueReader) error {
+		seenHead = head
+		if _, ok := commit.Get(ValueField).(types.String); !ok {
+			return errors.New("value must be a String")
+		}
+		return nil
+	})
+
+	ds, err = suite.db.CommitValue(ds, types.String("b"))
+	suite.NoError(err)
+	suite.True(seenHead.Equals(aCommitRef.TargetValue(suite.db)))
+
+	ds, err = suite.db.CommitValue(ds, types.Number(42))
+	suite.Equal(CommitRejectedError{"ds1", "value must be a String"}, err)
+	suite.True(ds.HeadValue().Equals(types.String("b")))
+
+	ds, err = suite.db.SetHead(ds, aCommitRef)
+	suite.NoError(err)
+	suite.True(ds.HeadValue().Equals(types.String("a")))
+
+	other, err := suite.db.CommitValue(suite.db.GetDataset("ds2"), types.Bool(true))
+	suite.IsType(CommitRejectedError{}, err)
+	suite.False(other.HasHead())
+	suite.True(seenHead.IsZeroValue())
+}
@@ -0,0 +1,144 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package datas
+
+import (
+	"fmt"
+
+	"github.com/attic-labs/noms/go/merge"
+	"github.com/attic-labs/noms/go/types"
+)
+
+// CherryPick applies the changes that the Commit at |commitRef| made to the
+// value of its parent onto the Head of |ds|, and commits the result on top of
+// that Head. Conflicts between those changes and the ones already in |ds| are
+// handed to |resolve|. If |meta| is the zero value, the meta of the picked
+// Commit is reused, except for its schema: the new Commit inherits the schema
+// of the Head of |ds| instead. Merge Commits, which have more than one parent, cannot be
+// picked.
+func CherryPick(db Database, ds Dataset, commitRef types.Ref, resolve merge.ResolveFunc, meta types.Struct) (Dataset, error) {
+	commit, parentValue, err := commitAndParentValue(db, commitRef)
+	if err != nil {
+		return ds, err
+	}
+	head, ok := ds.MaybeHeadValue()
+	if !ok {
+		return ds, fmt.Errorf("Dataset %s has no head", ds.ID())
+	}
+
+	merged, err := replayChange(db, head, commit.Get(ValueField), parentValue, resolve)
+	if err != nil {
+		return ds, err
+	}
+	if meta.IsZeroValue() {
+		// The schema of |commit| belongs to its own Dataset, so |ds| keeps
+		// its own instead.
+		meta = commit.Get(MetaField).(types.Struct).Delete(SchemaField)
+	}
+	return db.Commit(ds, merged, CommitOptions{Meta: meta})
+}
+
+// Revert undoes the changes that the Commit at |commitRef| made to the value
+// of its parent, and commits the result on top of the Head of |ds|. Later
+// changes to the same values are handed to |resolve| as conflicts. If |meta|
+// is the zero value, an empty meta struct is used.
+func Revert(db Database, ds Dataset, commitRef types.Ref, resolve merge.ResolveFunc, meta types.Struct) (Dataset, error) {
+	commit, parentValue, err := commitAndParentValue(db, commitRef)
+	if err != nil {
+		return ds, err
+	}
+	if parentValue == nil {
+		return ds, fmt.Errorf("Cannot revert %s, it has no parent", commitRef.TargetHash())
+	}
+	head, ok := ds.MaybeHeadValue()
+	if !ok {
+		return ds, fmt.Errorf("Dataset %s has no head", ds.ID())
+	}
+
+	merged, err := replayChange(db, head, parentValue, commit.Get(ValueField), resolve)
+	if err != nil {
+		return ds, err
+	}
+	return db.Commit(ds, merged, CommitOptions{Meta: meta})
+}
+
+// RebaseOnto replays the Commits of |ds| that are not reachable from the
+// Commit at |onto| on top of |onto|, one at a time and keeping their meta,
+// and then makes the last replayed Commit the new Head of |ds|. Conflicts are
+// handed to |resolve|. If |ds| already contains |onto|, nothing is done; if
+// |onto| contains the Head of |ds|, |ds| is fast-forwarded to it. Histories
+// containing merge Commits cannot be rebased.
+func RebaseOnto(db Database, ds Dataset, onto types.Ref, resolve merge.ResolveFunc) (Dataset, error) {
+	headRef, ok := ds.MaybeHeadRef()
+	if !ok {
+		return ds, fmt.Errorf("Dataset %s has no head", ds.ID())
+	}
+	ancestorRef, ok := FindCommonAncestor(headRef, onto, db)
+	if !ok {
+		return ds, fmt.Errorf("Dataset %s has no common ancestor with %s", ds.ID(), onto.TargetHash())
+	}
+	if ancestorRef.TargetHash() == onto.TargetHash() {
+		return ds, nil
+	}
+	if ancestorRef.TargetHash() == headRef.TargetHash() {
+		return db.FastForward(ds, onto)
+	}
+
+	// Walk back from the Head to the common ancestor, collecting the Commits to replay.
+	toReplay := []types.Struct{}
+	for r := headRef; r.TargetHash() != ancestorRef.TargetHash(); {
+		c := r.TargetValue(db).(types.Struct)
+		parents := c.Get(ParentsField).(types.Set)
+		if parents.Len() != 1 {
+			return ds, fmt.Errorf("Cannot rebase %s, it is a merge commit", r.TargetHash())
+		}
+		toReplay = append(toReplay, c)
+		r = parents.First().(types.Ref)
+	}
+
+	current, currentRef := onto.TargetValue(db).(types.Struct), onto
+	for i := len(toReplay) - 1; i >= 0; i-- {
+		c := toReplay[i]
+		parent := c.Get(ParentsField).(types.Set).First().(types.Ref).TargetValue(db).(types.Struct)
+		merged, err := replayChange(db, current.Get(ValueField), c.Get(ValueField), parent.Get(ValueField), resolve)
+		if err != nil {
+			return ds, err
+		}
+		meta := inheritSchema(c.Get(MetaField).(types.Struct), current)
+		current = NewCommit(merged, types.NewSet(db, currentRef), meta)
+		currentRef = db.WriteValue(current)
+	}
+	return db.SetHead(ds, currentRef)
+}
+
+// commitAndParentValue returns the Commit at |r| and the value of its lone
+// parent, which is nil if the Commit has no parents.
+func commitAndParentValue(vr types.ValueReader, r types.Ref) (commit types.Struct, parentValue types.Value, err error) {
+	v := r.TargetValue(vr)
+	if v == nil || !IsCommit(v) {
+		return types.Struct{}, nil, fmt.Errorf("%s is not a commit", r.TargetHash())
+	}
+	commit = v.(types.Struct)
+	parents := commit.Get(ParentsField).(types.Set)
+	switch parents.Len() {
+	case 0:
+		return commit, nil, nil
+	case 1:
+		parent := parents.First().(types.Ref).TargetValue(vr).(types.Struct)
+		return commit, parent.Get(ValueField), nil
+	}
+	return types.Struct{}, nil, fmt.Errorf("%s is a merge commit", r.TargetHash())
+}
+
+// replayChange applies the change from |base| to |changed| onto |onto|.
+func replayChange(vrw types.ValueReadWriter, onto, changed, base types.Value, resolve merge.ResolveFunc) (types.Value, error) {
+	switch {
+	case base != nil && changed.Equals(base):
+		return onto, nil
+	case base != nil && onto.Equals(base), onto.Equals(changed):
+		return changed, nil
+	}
+	return merge.ThreeWay(onto, changed, base, vrw, resolve, nil)
+}
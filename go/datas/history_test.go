@@ -0,0 +1,154 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package datas
+
+import (
+	"testing"
+
+	"github.com/attic-labs/noms/go/chunks"
+	"github.com/attic-labs/noms/go/merge"
+	"github.com/attic-labs/noms/go/nomdl"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestHistory(t *testing.T) {
+	suite.Run(t, &HistorySuite{})
+}
+
+type HistorySuite struct {
+	suite.Suite
+	db Database
+}
+
+func (suite *HistorySuite) SetupTest() {
+	storage := &chunks.MemoryStorage{}
+	suite.db = NewDatabase(storage.NewView())
+}
+
+func (suite *HistorySuite) TearDownTest() {
+	suite.db.Close()
+}
+
+func (suite *HistorySuite) commit(ds Dataset, kv ...interface{}) Dataset {
+	vals := []types.Value{}
+	for _, v := range kv {
+		switch v := v.(type) {
+		case string:
+			vals = append(vals, types.String(v))
+		case int:
+			vals = append(vals, types.Number(v))
+		}
+	}
+	ds, err := suite.db.CommitValue(ds, types.NewMap(suite.db, vals...))
+	suite.NoError(err)
+	return ds
+}
+
+// branch creates the Dataset |name| pointing at the Head of |from|.
+func (suite *HistorySuite) branch(name string, from Dataset) Dataset {
+	ds, err := suite.db.SetHead(suite.db.GetDataset(name), from.HeadRef())
+	suite.NoError(err)
+	return ds
+}
+
+func (suite *HistorySuite) assertHeadValue(ds Dataset, kv ...interface{}) {
+	expected := suite.commit(suite.db.GetDataset("expected"), kv...)
+	suite.True(expected.HeadValue().Equals(ds.HeadValue()), "%s != %s", types.EncodedValue(expected.HeadValue()), types.EncodedValue(ds.HeadValue()))
+}
+
+func (suite *HistorySuite) TestCherryPick() {
+	// base: {a: 1} <- {a: 1, b: 2} <- {a: 1, b: 2, c: 3}
+	base := suite.commit(suite.db.GetDataset("base"), "a", 1)
+	feature := suite.commit(suite.branch("feature", base), "a", 1, "b", 2)
+	feature = suite.commit(feature, "a", 1, "b", 2, "c", 3)
+
+	// Picking the commit that added 'c' onto a branch without 'b' adds just 'c'.
+	ds := suite.commit(base, "a", 10)
+	ds, err := CherryPick(suite.db, ds, feature.HeadRef(), merge.None, types.Struct{})
+	suite.NoError(err)
+	suite.assertHeadValue(ds, "a", 10, "c", 3)
+	suite.Equal(uint64(1), ds.Head().Get(ParentsField).(types.Set).Len())
+
+	// Picking the same commit again changes nothing.
+	ds, err = CherryPick(suite.db, ds, feature.HeadRef(), merge.None, types.Struct{})
+	suite.NoError(err)
+	suite.assertHeadValue(ds, "a", 10, "c", 3)
+}
+
+func (suite *HistorySuite) TestCherryPickSchema() {
+	base := suite.commit(suite.db.GetDataset("base"), "a", 1)
+	feature, err := SetSchema(suite.branch("feature", base), nomdl.MustParseType("Map<String, Number>"), types.EmptyStruct)
+	suite.NoError(err)
+	feature = suite.commit(feature, "a", 1, "b", 2)
+
+	// The schema of the picked commit isn't copied into another dataset...
+	ds, err := CherryPick(suite.db, base, feature.HeadRef(), merge.None, types.Struct{})
+	suite.NoError(err)
+	suite.assertHeadValue(ds, "a", 1, "b", 2)
+	_, ok := ds.MaybeSchema()
+	suite.False(ok)
+
+	// ...which keeps its own.
+	ds, err = SetSchema(ds, types.ValueType, types.EmptyStruct)
+	suite.NoError(err)
+	feature = suite.commit(feature, "a", 1, "b", 2, "c", 3)
+	ds, err = CherryPick(suite.db, ds, feature.HeadRef(), merge.None, types.Struct{})
+	suite.NoError(err)
+	schema, ok := ds.MaybeSchema()
+	suite.True(ok)
+	suite.True(types.ValueType.Equals(schema))
+}
+
+func (suite *HistorySuite) TestCherryPickConflict() {
+	base := suite.commit(suite.db.GetDataset("base"), "a", 1)
+	feature := suite.commit(suite.branch("feature", base), "a", 2)
+	ds := suite.commit(base, "a", 3)
+
+	_, err := CherryPick(suite.db, ds, feature.HeadRef(), merge.None, types.Struct{})
+	suite.IsType(&merge.ErrMergeConflict{}, err)
+
+	ds, err = CherryPick(suite.db, ds, feature.HeadRef(), merge.Theirs, types.Struct{})
+	suite.NoError(err)
+	suite.assertHeadValue(ds, "a", 2)
+}
+
+func (suite *HistorySuite) TestRevert() {
+	ds := suite.commit(suite.db.GetDataset("ds"), "a", 1)
+	ds = suite.commit(ds, "a", 1, "b", 2)
+	toRevert := ds.HeadRef()
+	ds = suite.commit(ds, "a", 5, "b", 2)
+
+	ds, err := Revert(suite.db, ds, toRevert, merge.None, types.Struct{})
+	suite.NoError(err)
+	suite.assertHeadValue(ds, "a", 5)
+}
+
+func (suite *HistorySuite) TestRebaseOnto() {
+	base := suite.commit(suite.db.GetDataset("base"), "a", 1)
+	ds := suite.commit(suite.branch("feature", base), "a", 1, "c", 3)
+	ds = suite.commit(ds, "a", 4, "c", 3)
+	onto := suite.commit(base, "a", 1, "b", 2)
+
+	ds, err := RebaseOnto(suite.db, ds, onto.HeadRef(), merge.None)
+	suite.NoError(err)
+	suite.assertHeadValue(ds, "a", 4, "b", 2, "c", 3)
+
+	// The replayed history sits on top of |onto|.
+	a, ok := FindCommonAncestor(ds.HeadRef(), onto.HeadRef(), suite.db)
+	suite.True(ok)
+	suite.Equal(onto.HeadRef().TargetHash(), a.TargetHash())
+	suite.Equal(onto.HeadRef().Height()+2, ds.HeadRef().Height())
+
+	// Rebasing again is a no-op, and rebasing an ancestor fast-forwards.
+	again, err := RebaseOnto(suite.db, ds, onto.HeadRef(), merge.None)
+	suite.NoError(err)
+	suite.True(ds.HeadRef().Equals(again.HeadRef()))
+
+	behind := suite.branch("behind", base)
+	ff, err := RebaseOnto(suite.db, behind, onto.HeadRef(), merge.None)
+	suite.NoError(err)
+	suite.True(onto.HeadRef().Equals(ff.HeadRef()))
+}
@@ -8,6 +8,7 @@ import (
 	"bufio"
 	"bytes"
 	"compress/gzip"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -479,6 +480,10 @@ func (hcs *httpChunkStore) Commit(current, last hash.Hash) bool {
 		success = true
 	case http.StatusConflict:
 		success = false
+	case http.StatusForbidden:
+		rejected := CommitRejectedError{}
+		d.PanicIfError(json.NewDecoder(res.Body).Decode(&rejected))
+		d.PanicIfError(rejected)
 	default:
 		buf := bytes.Buffer{}
 		buf.ReadFrom(res.Body)
@@ -0,0 +1,114 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package datas
+
+import (
+	"errors"
+
+	"github.com/attic-labs/noms/go/merge"
+	"github.com/attic-labs/noms/go/types"
+)
+
+// ErrNoCommonAncestor is returned by MergeCommits when the Commits being
+// merged do not share any history.
+var ErrNoCommonAncestor = errors.New("Commits have no common ancestor")
+
+// MergeCommits merges the values of the Commits in |heads| using |policy|,
+// and returns the merged value. The heads are merged one at a time, from left
+// to right: the i-th head is merged into the result of merging the ones
+// before it, against the best common ancestor of the i-th head and all of
+// those before it. This means that |policy| sees the value merged so far as
+// its first candidate and the i-th head's value as its second.
+//
+// When there is more than one best common ancestor, as happens after
+// criss-cross merges, the ancestors are themselves merged, recursively, and
+// the result is used as a virtual ancestor.
+//
+// The caller is responsible for committing the result, with all of |heads| as
+// parents.
+func MergeCommits(db Database, heads types.RefSlice, policy merge.Policy, progress chan struct{}) (types.Value, error) {
+	return mergeCommits(db, heads, policy, policy, progress)
+}
+
+// mergeCommits is MergeCommits, except that virtual ancestors are merged with
+// |ancestorPolicy|, e.g. so that a merge.ConflictRecorder doesn't record the
+// conflicts between ancestors along with those between |heads|.
+func mergeCommits(db Database, heads types.RefSlice, policy, ancestorPolicy merge.Policy, progress chan struct{}) (types.Value, error) {
+	if len(heads) == 0 {
+		return nil, errors.New("No commits to merge")
+	}
+
+	merged := commitValue(db, heads[0])
+	for i, h := range heads[1:] {
+		bases := findMergeBases(h, heads[:i+1], db)
+		if len(bases) == 0 {
+			return nil, ErrNoCommonAncestor
+		}
+		ancestor, err := mergeAncestors(db, bases, ancestorPolicy)
+		if err != nil {
+			return nil, err
+		}
+		merged, err = mergeValues(db, policy, merged, commitValue(db, h), ancestor, progress)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return merged, nil
+}
+
+// PreviewMerge merges the values of the Commits in |heads| as MergeCommits
+// does, using merge.Preview, and returns the merged value along with the
+// conflicts and the changes that were merged at each step. Nothing is
+// committed.
+func PreviewMerge(db Database, heads types.RefSlice) (merge.PreviewResult, error) {
+	if len(heads) == 0 {
+		return merge.PreviewResult{}, errors.New("No commits to merge")
+	}
+
+	res := merge.PreviewResult{Merged: commitValue(db, heads[0])}
+	for i, h := range heads[1:] {
+		bases := findMergeBases(h, heads[:i+1], db)
+		if len(bases) == 0 {
+			return merge.PreviewResult{}, ErrNoCommonAncestor
+		}
+		ancestor, err := mergeAncestors(db, bases, merge.NewConflictRecorder(nil).Policy())
+		if err != nil {
+			return merge.PreviewResult{}, err
+		}
+		step, err := merge.Preview(res.Merged, commitValue(db, h), ancestor, db)
+		if err != nil {
+			return merge.PreviewResult{}, err
+		}
+		res.Merged = step.Merged
+		res.Conflicts = append(res.Conflicts, step.Conflicts...)
+		res.Changes = append(res.Changes, step.Changes...)
+	}
+	return res, nil
+}
+
+// mergeAncestors returns the value of the lone Commit in |bases| or, if there
+// are several, the result of merging them.
+func mergeAncestors(db Database, bases types.RefSlice, policy merge.Policy) (types.Value, error) {
+	if len(bases) == 1 {
+		return commitValue(db, bases[0]), nil
+	}
+	return MergeCommits(db, bases, policy, nil)
+}
+
+// mergeValues merges |a| and |b| against |ancestor|, only consulting |policy|
+// if both have changed.
+func mergeValues(vrw types.ValueReadWriter, policy merge.Policy, a, b, ancestor types.Value, progress chan struct{}) (types.Value, error) {
+	switch {
+	case a.Equals(b), b.Equals(ancestor):
+		return a, nil
+	case a.Equals(ancestor):
+		return b, nil
+	}
+	return policy(a, b, ancestor, vrw, progress)
+}
+
+func commitValue(vr types.ValueReader, r types.Ref) types.Value {
+	return r.TargetValue(vr).(types.Struct).Get(ValueField)
+}
@@ -0,0 +1,99 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package datas
+
+import (
+	"testing"
+
+	"github.com/attic-labs/noms/go/chunks"
+	"github.com/attic-labs/noms/go/merge"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeCommits(t *testing.T) {
+	assert := assert.New(t)
+	storage := &chunks.TestStorage{}
+	db := NewDatabase(storage.NewView())
+	defer db.Close()
+
+	addCommit := func(datasetID string, kv []string, parents ...types.Struct) types.Struct {
+		data := types.StructData{}
+		for i := 0; i < len(kv); i += 2 {
+			data[kv[i]] = types.String(kv[i+1])
+		}
+		ds, err := db.Commit(db.GetDataset(datasetID), types.NewStruct("", data), CommitOptions{Parents: toRefSet(db, parents...)})
+		assert.NoError(err)
+		return ds.Head()
+	}
+	refs := func(commits ...types.Struct) types.RefSlice {
+		rs := types.RefSlice{}
+		for _, c := range commits {
+			rs = append(rs, types.NewRef(c))
+		}
+		return rs
+	}
+	policy := merge.NewThreeWay(merge.None)
+
+	// Octopus: three branches off of the same base each change a different field.
+	base := addCommit("base", []string{"a", "0", "b", "0", "c", "0"})
+	a := addCommit("a", []string{"a", "1", "b", "0", "c", "0"}, base)
+	b := addCommit("b", []string{"a", "0", "b", "1", "c", "0"}, base)
+	c := addCommit("c", []string{"a", "0", "b", "0", "c", "1"}, base)
+
+	merged, err := MergeCommits(db, refs(a, b, c), policy, nil)
+	assert.NoError(err)
+	assert.True(types.NewStruct("", types.StructData{"a": types.String("1"), "b": types.String("1"), "c": types.String("1")}).Equals(merged))
+
+	// A head that is already contained in another changes nothing.
+	merged, err = MergeCommits(db, refs(a, base), policy, nil)
+	assert.NoError(err)
+	assert.True(a.Get(ValueField).Equals(merged))
+
+	// Criss-cross: x2 and y2 both merged x1 and y1, then diverged again. The
+	// virtual ancestor is the merge of x1 and y1.
+	x1 := addCommit("x", []string{"a", "1", "b", "0", "c", "0"}, base)
+	y1 := addCommit("y", []string{"a", "0", "b", "1", "c", "0"}, base)
+	x2 := addCommit("x", []string{"a", "1", "b", "1", "c", "x"}, x1, y1)
+	y2 := addCommit("y", []string{"a", "1", "b", "1", "c", "0", "d", "y"}, y1, x1)
+
+	merged, err = MergeCommits(db, refs(x2, y2), policy, nil)
+	assert.NoError(err)
+	assert.True(types.NewStruct("", types.StructData{"a": types.String("1"), "b": types.String("1"), "c": types.String("x"), "d": types.String("y")}).Equals(merged))
+
+	// Conflicting changes are handed to the policy.
+	z := addCommit("z", []string{"a", "2", "b", "0", "c", "0"}, base)
+	_, err = MergeCommits(db, refs(a, b, z), policy, nil)
+	assert.IsType(&merge.ErrMergeConflict{}, err)
+	merged, err = MergeCommits(db, refs(a, b, z), merge.NewThreeWay(merge.Theirs), nil)
+	assert.NoError(err)
+	assert.Equal(types.String("2"), merged.(types.Struct).Get("a"))
+
+	// A preview reports the conflicts and merged changes of every step.
+	preview, err := PreviewMerge(db, refs(a, b, z))
+	assert.NoError(err)
+	assert.Equal(types.String("1"), preview.Merged.(types.Struct).Get("a"))
+	if assert.Len(preview.Conflicts, 1) {
+		assert.Equal(".a", preview.Conflicts[0].Path.String())
+		assert.Equal(types.String("2"), preview.Conflicts[0].Theirs)
+	}
+	// Merging b into a changes .a on our side and .b on theirs, then merging
+	// z in keeps .b from our side.
+	if assert.Len(preview.Changes, 3) {
+		assert.Equal(".a", preview.Changes[0].Path.String())
+		assert.Equal(merge.FromOurs, preview.Changes[0].Source)
+		assert.Equal(".b", preview.Changes[1].Path.String())
+		assert.Equal(merge.FromTheirs, preview.Changes[1].Source)
+		assert.Equal(".b", preview.Changes[2].Path.String())
+		assert.Equal(merge.FromOurs, preview.Changes[2].Source)
+	}
+
+	// Unrelated histories can't be merged.
+	other := addCommit("other", []string{"a", "1"})
+	_, err = MergeCommits(db, refs(a, other), policy, nil)
+	assert.Equal(ErrNoCommonAncestor, err)
+	_, err = PreviewMerge(db, refs(a, other))
+	assert.Equal(ErrNoCommonAncestor, err)
+}
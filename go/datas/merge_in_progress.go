@@ -0,0 +1,198 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package datas
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/attic-labs/noms/go/merge"
+	"github.com/attic-labs/noms/go/types"
+)
+
+// MergeInProgressName is the name of the struct stored as the value of a
+// Commit that records a merge whose conflicts have not all been resolved.
+// The struct holds the heads being merged, the value merged so far and a list
+// of Conflict structs, each with the path of the conflict and the base, ours
+// and theirs values found there (a field is absent if there was no value).
+const MergeInProgressName = "MergeInProgress"
+
+const (
+	headsField      = "heads"
+	conflictsField  = "conflicts"
+	conflictName    = "Conflict"
+	pathField       = "path"
+	baseField       = "base"
+	oursField       = "ours"
+	theirsField     = "theirs"
+	resolvedField   = "resolved"
+	resolutionField = "resolution"
+)
+
+// ErrUnresolvedConflicts is returned when finishing a MergeInProgress that
+// still has unresolved conflicts.
+var ErrUnresolvedConflicts = errors.New("Merge has unresolved conflicts")
+
+// MergeConflict is a merge.Conflict within a MergeInProgress. If Resolved is
+// true, Resolution is the value to use at Path, or nil if there should be
+// none.
+type MergeConflict struct {
+	merge.Conflict
+	Resolved   bool
+	Resolution types.Value
+}
+
+// MergeInProgress is a merge of the Commits in Heads that has recorded its
+// conflicts instead of failing. Value is the result of the merge, in which
+// unresolved conflicts are resolved in favor of "ours" (see
+// merge.ConflictRecorder).
+type MergeInProgress struct {
+	Heads     types.RefSlice
+	Value     types.Value
+	Conflicts []MergeConflict
+}
+
+// StartMerge merges the values of the Commits in |heads| the way
+// MergeCommits does, but records conflicts in the returned MergeInProgress
+// rather than failing on them.
+func StartMerge(db Database, heads types.RefSlice, progress chan struct{}) (MergeInProgress, error) {
+	r := merge.NewConflictRecorder(nil)
+	merged, err := mergeCommits(db, heads, r.Policy(), merge.NewConflictRecorder(nil).Policy(), progress)
+	if err != nil {
+		return MergeInProgress{}, err
+	}
+	mip := MergeInProgress{Heads: heads, Value: merged}
+	for _, c := range r.Conflicts {
+		mip.Conflicts = append(mip.Conflicts, MergeConflict{Conflict: c})
+	}
+	return mip, nil
+}
+
+// Unresolved returns the number of conflicts in |mip| that are not resolved.
+func (mip MergeInProgress) Unresolved() (n int) {
+	for _, c := range mip.Conflicts {
+		if !c.Resolved {
+			n++
+		}
+	}
+	return
+}
+
+// Resolve returns a copy of |mip| in which the conflicts at |path| are
+// resolved to |v|, or to there being no value at |path| if |v| is nil, and
+// Value is merged again accordingly.
+func (mip MergeInProgress) Resolve(db Database, path types.Path, v types.Value) (MergeInProgress, error) {
+	if path.IsEmpty() && v == nil {
+		return mip, errors.New("The merged value cannot be removed")
+	}
+
+	found := false
+	conflicts := make([]MergeConflict, len(mip.Conflicts))
+	resolutions := map[string]types.Value{}
+	for i, c := range mip.Conflicts {
+		if c.Path.String() == path.String() {
+			c.Resolved, c.Resolution = true, v
+			found = true
+		}
+		if c.Resolved {
+			resolutions[c.Path.String()] = c.Resolution
+		}
+		conflicts[i] = c
+	}
+	if !found {
+		return mip, fmt.Errorf("No conflict at %s", path)
+	}
+
+	merged, err := mergeCommits(db, mip.Heads, merge.NewConflictRecorder(resolutions).Policy(), merge.NewConflictRecorder(nil).Policy(), nil)
+	if err != nil {
+		return mip, err
+	}
+	return MergeInProgress{mip.Heads, merged, conflicts}, nil
+}
+
+// Write writes a Commit holding |mip|, with Heads as parents, and returns a
+// Ref to it. The Commit is not made the Head of any Dataset.
+func (mip MergeInProgress) Write(vrw types.ValueReadWriter) types.Ref {
+	heads := make(types.ValueSlice, len(mip.Heads))
+	for i, h := range mip.Heads {
+		heads[i] = h
+	}
+	conflicts := make(types.ValueSlice, len(mip.Conflicts))
+	for i, c := range mip.Conflicts {
+		data := types.StructData{
+			pathField:     types.String(c.Path.String()),
+			resolvedField: types.Bool(c.Resolved),
+		}
+		for name, v := range map[string]types.Value{baseField: c.Base, oursField: c.Ours, theirsField: c.Theirs, resolutionField: c.Resolution} {
+			if v != nil {
+				data[name] = v
+			}
+		}
+		conflicts[i] = types.NewStruct(conflictName, data)
+	}
+	v := types.NewStruct(MergeInProgressName, types.StructData{
+		headsField:     types.NewList(vrw, heads...),
+		ValueField:     mip.Value,
+		conflictsField: types.NewList(vrw, conflicts...),
+	})
+	return vrw.WriteValue(NewCommit(v, mip.parents(vrw), types.EmptyStruct))
+}
+
+// Finish writes a Commit of the merged value with Heads as parents and
+// |meta| as meta, and returns a Ref to it. It fails with
+// ErrUnresolvedConflicts if any conflict is unresolved.
+func (mip MergeInProgress) Finish(vrw types.ValueReadWriter, meta types.Struct) (types.Ref, error) {
+	if mip.Unresolved() > 0 {
+		return types.Ref{}, ErrUnresolvedConflicts
+	}
+	if meta.IsZeroValue() {
+		meta = types.EmptyStruct
+	}
+	return vrw.WriteValue(NewCommit(mip.Value, mip.parents(vrw), meta)), nil
+}
+
+func (mip MergeInProgress) parents(vrw types.ValueReadWriter) types.Set {
+	se := types.NewSet(vrw).Edit()
+	for _, h := range mip.Heads {
+		se.Insert(h)
+	}
+	return se.Set()
+}
+
+// ReadMergeInProgress reads the MergeInProgress held by the Commit at |r|.
+func ReadMergeInProgress(vr types.ValueReader, r types.Ref) (MergeInProgress, error) {
+	c := r.TargetValue(vr)
+	if c == nil || !IsCommit(c) {
+		return MergeInProgress{}, fmt.Errorf("%s is not a commit", r.TargetHash())
+	}
+	v, ok := c.(types.Struct).Get(ValueField).(types.Struct)
+	if !ok || v.Name() != MergeInProgressName {
+		return MergeInProgress{}, fmt.Errorf("%s is not a merge in progress", r.TargetHash())
+	}
+
+	mip := MergeInProgress{Value: v.Get(ValueField)}
+	v.Get(headsField).(types.List).IterAll(func(h types.Value, _ uint64) {
+		mip.Heads = append(mip.Heads, h.(types.Ref))
+	})
+	var err error
+	v.Get(conflictsField).(types.List).IterAll(func(cv types.Value, _ uint64) {
+		s := cv.(types.Struct)
+		path, perr := types.ParsePath(string(s.Get(pathField).(types.String)))
+		if perr != nil {
+			err = perr
+			return
+		}
+		get := func(name string) types.Value {
+			v, _ := s.MaybeGet(name)
+			return v
+		}
+		mip.Conflicts = append(mip.Conflicts, MergeConflict{
+			Conflict:   merge.Conflict{Path: path, Base: get(baseField), Ours: get(oursField), Theirs: get(theirsField)},
+			Resolved:   bool(s.Get(resolvedField).(types.Bool)),
+			Resolution: get(resolutionField),
+		})
+	})
+	return mip, err
+}
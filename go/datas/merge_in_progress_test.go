@@ -0,0 +1,121 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package datas
+
+import (
+	"testing"
+
+	"github.com/attic-labs/noms/go/chunks"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeInProgress(t *testing.T) {
+	assert := assert.New(t)
+	storage := &chunks.TestStorage{}
+	db := NewDatabase(storage.NewView())
+	defer db.Close()
+
+	commit := func(ds Dataset, kv ...types.Value) types.Ref {
+		ds, err := db.CommitValue(ds, types.NewMap(db, kv...))
+		assert.NoError(err)
+		return ds.HeadRef()
+	}
+	a, b, c := types.String("a"), types.String("b"), types.String("c")
+	base := commit(db.GetDataset("ours"), a, types.Number(0), b, types.Number(0))
+	theirsDS, err := db.SetHead(db.GetDataset("theirs"), base)
+	assert.NoError(err)
+	ours := commit(db.GetDataset("ours"), a, types.Number(1), b, types.Number(1))
+	theirs := commit(theirsDS, a, types.Number(2), c, types.Number(2))
+
+	mip, err := StartMerge(db, types.RefSlice{ours, theirs}, nil)
+	assert.NoError(err)
+	assert.Equal(2, mip.Unresolved())
+	assert.True(types.NewMap(db, a, types.Number(1), b, types.Number(1), c, types.Number(2)).Equals(mip.Value))
+
+	// The merge survives a round trip through the database.
+	r := mip.Write(db)
+	mip, err = ReadMergeInProgress(db, r)
+	assert.NoError(err)
+	if assert.Len(mip.Heads, 2) {
+		assert.Equal(ours.TargetHash(), mip.Heads[0].TargetHash())
+		assert.Equal(theirs.TargetHash(), mip.Heads[1].TargetHash())
+	}
+	if assert.Len(mip.Conflicts, 2) {
+		conflict := mip.Conflicts[0]
+		assert.Equal(`["a"]`, conflict.Path.String())
+		assert.True(types.Number(0).Equals(conflict.Base))
+		assert.True(types.Number(1).Equals(conflict.Ours))
+		assert.True(types.Number(2).Equals(conflict.Theirs))
+
+		// b was modified by ours and removed by theirs.
+		conflict = mip.Conflicts[1]
+		assert.Equal(`["b"]`, conflict.Path.String())
+		assert.Nil(conflict.Theirs)
+	}
+
+	_, err = mip.Finish(db, types.Struct{})
+	assert.Equal(ErrUnresolvedConflicts, err)
+	_, err = mip.Resolve(db, types.MustParsePath(`["c"]`), types.Number(3))
+	assert.Error(err)
+
+	mip, err = mip.Resolve(db, types.MustParsePath(`["a"]`), types.Number(3))
+	assert.NoError(err)
+	mip, err = mip.Resolve(db, types.MustParsePath(`["b"]`), nil)
+	assert.NoError(err)
+	assert.Equal(0, mip.Unresolved())
+	assert.True(types.NewMap(db, a, types.Number(3), c, types.Number(2)).Equals(mip.Value))
+
+	mip, err = ReadMergeInProgress(db, mip.Write(db))
+	assert.NoError(err)
+	r, err = mip.Finish(db, types.Struct{})
+	assert.NoError(err)
+	merged := r.TargetValue(db).(types.Struct)
+	assert.True(mip.Value.Equals(merged.Get(ValueField)))
+	assert.Equal(uint64(2), merged.Get(ParentsField).(types.Set).Len())
+
+	_, err = ReadMergeInProgress(db, ours)
+	assert.Error(err)
+}
+
+func TestMergeInProgressCrissCross(t *testing.T) {
+	assert := assert.New(t)
+	storage := &chunks.TestStorage{}
+	db := NewDatabase(storage.NewView())
+	defer db.Close()
+
+	a := types.String("a")
+	commit := func(datasetID string, n float64, parents ...types.Ref) types.Ref {
+		ds, err := db.Commit(db.GetDataset(datasetID), types.NewMap(db, a, types.Number(n)), CommitOptions{Parents: types.NewSet(db, refsToValues(parents)...)})
+		assert.NoError(err)
+		return ds.HeadRef()
+	}
+	base := commit("base", 0)
+	x1 := commit("x", 1, base)
+	y1 := commit("y", 2, base)
+	x2 := commit("x", 5, x1, y1)
+	y2 := commit("y", 3, y1, x1)
+
+	// The conflict between x1 and y1 in the virtual ancestor is not one of
+	// the merge's own.
+	mip, err := StartMerge(db, types.RefSlice{x2, y2}, nil)
+	assert.NoError(err)
+	assert.Len(mip.Conflicts, 1)
+	assert.Equal(`["a"]`, mip.Conflicts[0].Path.String())
+	assert.True(types.Number(3).Equals(mip.Conflicts[0].Theirs))
+
+	mip, err = mip.Resolve(db, types.MustParsePath(`["a"]`), types.Number(4))
+	assert.NoError(err)
+	assert.Equal(0, mip.Unresolved())
+	assert.True(types.NewMap(db, a, types.Number(4)).Equals(mip.Value))
+}
+
+func refsToValues(refs []types.Ref) types.ValueSlice {
+	vs := make(types.ValueSlice, len(refs))
+	for i, r := range refs {
+		vs[i] = r
+	}
+	return vs
+}
@@ -81,7 +81,7 @@ var (
 	// Chunk.
 	// TODO: Nice comment about what headers it expects/honors, payload
 	// format, and error responses.
-	HandleRootPost = createHandler(handleRootPost, true)
+	HandleRootPost = NewRootPostHandler()
 
 	// HandleBaseGet is meant to handle HTTP GET requests to the / server
 	// endpoint. This is used to give a friendly message to users.
@@ -380,7 +380,19 @@ func handleStats(w http.ResponseWriter, req *http.Request, ps URLParams, cs chun
 	w.Header().Add("content-type", "text/plain")
 }
 
-func handleRootPost(w http.ResponseWriter, req *http.Request, ps URLParams, cs chunks.ChunkStore) {
+// NewRootPostHandler returns a Handler for HTTP POST requests to the root/
+// server endpoint which, in addition to the checks done by HandleRootPost,
+// runs SchemaValidator and |validators| against every Dataset Head changed by
+// the proposed Root.
+// If any of them rejects a Head, the server responds with 403 Forbidden and a
+// JSON body describing the rejection, and the Root is left unchanged.
+func NewRootPostHandler(validators ...CommitValidator) Handler {
+	return createHandler(func(w http.ResponseWriter, req *http.Request, ps URLParams, cs chunks.ChunkStore) {
+		handleRootPost(w, req, ps, cs, validators)
+	}, true)
+}
+
+func handleRootPost(w http.ResponseWriter, req *http.Request, ps URLParams, cs chunks.ChunkStore, validators []CommitValidator) {
 	if req.Method != "POST" {
 		d.Panic("Expected post method.")
 	}
@@ -408,6 +420,14 @@ func handleRootPost(w http.ResponseWriter, req *http.Request, ps URLParams, cs c
 		assertMapOfStringToRefOfCommit(proposedMap, lastMap, vs)
 	}
 
+	if err := validateDatasetChanges(validators, proposedMap, lastMap, vs); err != nil {
+		verbose.Log("Rejected root update: %s", err)
+		w.Header().Add("content-type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(err)
+		return
+	}
+
 	// If some other client has committed to |vs| since it had |from| at the
 	// root, this call to vs.Commit() will fail. Used to be that we'd always
 	// propagate that failure back to the client and let them try again. This
@@ -7,6 +7,8 @@ package datas
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -428,3 +430,44 @@ type params map[string]string
 func (p params) ByName(k string) string {
 	return p[k]
 }
+
+func TestRootPostValidators(t *testing.T) {
+	assert := assert.New(t)
+	storage := &chunks.MemoryStorage{}
+	vs := types.NewValueStore(storage.NewView())
+	defer vs.Close()
+
+	handler := NewRootPostHandler(func(datasetID string, commit, head types.Struct, vr types.ValueReader) error {
+		if datasetID == "protected" && !head.IsZeroValue() {
+			return errors.New("dataset is frozen")
+		}
+		return nil
+	})
+
+	commit := buildTestCommit(vs, types.String("first"))
+	commitRef := vs.WriteValue(commit)
+	firstHead := types.NewMap(vs, types.String("protected"), types.ToRefOfValue(commitRef))
+	firstHeadRef := vs.WriteValue(firstHead)
+
+	commit = buildTestCommit(vs, types.String("second"), commitRef)
+	newHead := types.NewMap(vs, types.String("protected"), types.ToRefOfValue(vs.WriteValue(commit)))
+	newHeadRef := vs.WriteValue(newHead)
+	vs.Commit(vs.Root(), vs.Root())
+
+	// Creating the dataset is allowed.
+	url := buildPostRootURL(firstHeadRef.TargetHash(), hash.Hash{})
+	w := httptest.NewRecorder()
+	handler(w, newRequest("POST", "", url, nil, nil), params{}, storage.NewView())
+	assert.Equal(http.StatusOK, w.Code, "Handler error:\n%s", string(w.Body.Bytes()))
+
+	// Moving its head is not.
+	url = buildPostRootURL(newHeadRef.TargetHash(), firstHeadRef.TargetHash())
+	w = httptest.NewRecorder()
+	handler(w, newRequest("POST", "", url, nil, nil), params{}, storage.NewView())
+	assert.Equal(http.StatusForbidden, w.Code, "Handler error:\n%s", string(w.Body.Bytes()))
+
+	rejected := CommitRejectedError{}
+	assert.NoError(json.NewDecoder(w.Body).Decode(&rejected))
+	assert.Equal(CommitRejectedError{"protected", "dataset is frozen"}, rejected)
+	assert.Equal(firstHeadRef.TargetHash(), storage.NewView().Root())
+}
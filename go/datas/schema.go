@@ -0,0 +1,98 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package datas
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/attic-labs/noms/go/types"
+)
+
+// SchemaField is the name of the field in a Commit's meta struct which holds
+// the schema of its Dataset. The schema is a *types.Type that the Commit's
+// value must be a subtype of. Because it lives in the Commit, the schema is
+// synced along with the data and every change to it is part of the Dataset's
+// history.
+const SchemaField = "schema"
+
+// maxSchemaMismatches bounds the number of offending paths reported when a
+// value does not match its schema.
+const maxSchemaMismatches = 10
+
+// CommitSchema returns the schema recorded in |commit|, if there is one.
+func CommitSchema(commit types.Struct) (*types.Type, bool) {
+	meta, ok := commit.MaybeGet(MetaField)
+	if !ok {
+		return nil, false
+	}
+	s, ok := meta.(types.Struct).MaybeGet(SchemaField)
+	if !ok {
+		return nil, false
+	}
+	t, ok := s.(*types.Type)
+	return t, ok
+}
+
+// MaybeSchema returns the schema recorded in the Head Commit of this Dataset,
+// if there is one.
+func (ds Dataset) MaybeSchema() (*types.Type, bool) {
+	if c, ok := ds.MaybeHead(); ok {
+		return CommitSchema(c)
+	}
+	return nil, false
+}
+
+// SetSchema commits the current Head value of |ds| with |schema| recorded in
+// |meta|. Subsequent Commits to |ds| inherit the schema unless their meta
+// specifies a different one, and fail with a CommitRejectedError if their
+// value is not a subtype of it. Setting the schema to types.ValueType lifts
+// the constraint.
+func SetSchema(ds Dataset, schema *types.Type, meta types.Struct) (Dataset, error) {
+	v, ok := ds.MaybeHeadValue()
+	if !ok {
+		return ds, fmt.Errorf("Dataset %s has no head to attach a schema to", ds.ID())
+	}
+	if meta.IsZeroValue() {
+		meta = types.EmptyStruct
+	}
+	return ds.Database().Commit(ds, v, CommitOptions{Meta: meta.Set(SchemaField, schema)})
+}
+
+// SchemaValidator is a CommitValidator that rejects Commits whose value is
+// not a subtype of the schema recorded in the Commit or, if the Commit records
+// none, in |head|. Commits written through a Database inherit the schema of
+// the Head anyway, but Commits pushed by other clients needn't. Database and
+// RemoteDatabaseServer always run it, ahead of any registered validators.
+func SchemaValidator(datasetID string, commit, head types.Struct, vr types.ValueReader) error {
+	schema, ok := CommitSchema(commit)
+	if !ok && !head.IsZeroValue() {
+		schema, ok = CommitSchema(head)
+	}
+	if !ok {
+		return nil
+	}
+	mismatches := types.ValueSubtypeMismatches(commit.Get(ValueField), schema, maxSchemaMismatches)
+	if mismatches == nil {
+		return nil
+	}
+	paths := make([]string, len(mismatches))
+	for i, p := range mismatches {
+		paths[i] = "." + ValueField + p.String()
+	}
+	return fmt.Errorf("value does not match schema %s at %s", schema.Describe(), strings.Join(paths, ", "))
+}
+
+// inheritSchema returns |meta| with the schema of |from| added, unless |meta|
+// already specifies one.
+func inheritSchema(meta, from types.Struct) types.Struct {
+	if _, ok := meta.MaybeGet(SchemaField); ok {
+		return meta
+	}
+	if schema, ok := CommitSchema(from); ok {
+		return meta.Set(SchemaField, schema)
+	}
+	return meta
+}
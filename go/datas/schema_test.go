@@ -0,0 +1,70 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package datas
+
+import (
+	"testing"
+
+	"github.com/attic-labs/noms/go/chunks"
+	"github.com/attic-labs/noms/go/nomdl"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaEnforcement(t *testing.T) {
+	assert := assert.New(t)
+	storage := &chunks.MemoryStorage{}
+	db := NewDatabase(storage.NewView())
+	defer db.Close()
+
+	row := func(id types.Value, name string) types.Struct {
+		return types.NewStruct("Row", types.StructData{"id": id, "name": types.String(name)})
+	}
+
+	ds := db.GetDataset("ds")
+	_, err := SetSchema(ds, types.NumberType, types.EmptyStruct)
+	assert.Error(err)
+
+	ds, err = db.CommitValue(ds, types.NewList(db, row(types.Number(1), "a")))
+	assert.NoError(err)
+	_, ok := ds.MaybeSchema()
+	assert.False(ok)
+
+	schema := nomdl.MustParseType("List<Struct Row { id: Number, name: String }>")
+	ds, err = SetSchema(ds, schema, types.EmptyStruct)
+	assert.NoError(err)
+	s, ok := ds.MaybeSchema()
+	assert.True(ok)
+	assert.True(schema.Equals(s))
+
+	// Subsequent commits inherit the schema.
+	ds, err = db.CommitValue(ds, types.NewList(db, row(types.Number(1), "a"), row(types.Number(2), "b")))
+	assert.NoError(err)
+	s, ok = ds.MaybeSchema()
+	assert.True(ok)
+	assert.True(schema.Equals(s))
+
+	// ...and are rejected if they don't conform.
+	_, err = db.CommitValue(ds, types.NewList(db, row(types.Number(1), "a"), row(types.String("2"), "b")))
+	assert.Equal(CommitRejectedError{"ds", "value does not match schema List<Struct Row {\n  id: Number,\n  name: String,\n}> at .value[1].id"}, err)
+
+	// A schema the current value doesn't match can't be set.
+	_, err = SetSchema(ds, types.MakeListType(types.NumberType), types.EmptyStruct)
+	assert.IsType(CommitRejectedError{}, err)
+
+	// Commits that don't record a schema, like ones written by other clients,
+	// are held to the schema of the Head.
+	bad := NewCommit(types.NewList(db, row(types.String("1"), "a")), types.NewSet(db, ds.HeadRef()), types.EmptyStruct)
+	_, err = db.SetHead(ds, db.WriteValue(bad))
+	assert.IsType(CommitRejectedError{}, err)
+	assert.Error(SchemaValidator("ds", bad, ds.Head(), db))
+	assert.NoError(SchemaValidator("ds", bad, types.Struct{}, db))
+
+	// Relaxing the schema is just another version of it.
+	ds, err = SetSchema(ds, types.ValueType, types.EmptyStruct)
+	assert.NoError(err)
+	ds, err = db.CommitValue(ds, types.String("anything"))
+	assert.NoError(err)
+}
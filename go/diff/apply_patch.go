@@ -25,6 +25,9 @@ import (
 // one is applied in order. When done in combination with the stack, this enables
 // all Differences that change a particular node to be applied to that node
 // before it gets assigned back to it's parent.
+//
+// Moves, which DiffWithOptions can report, are applied as the removal and
+// addition that they stand for.
 func Apply(root types.Value, patch Patch) types.Value {
 	if len(patch) == 0 {
 		return root
@@ -32,6 +35,7 @@ func Apply(root types.Value, patch Patch) types.Value {
 
 	var lastPath types.Path
 	stack := patchStack{}
+	patch = expandMoves(patch)
 	sort.Sort(patch)
 
 	// Push the element on the stack that corresponds to the root
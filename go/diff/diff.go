@@ -27,6 +27,9 @@ type Difference struct {
 	// NewKeyValue is used for when elements are added to diffs with a
 	// non-primitive key. The new key must available when the map gets updated.
 	NewKeyValue types.Value
+	// FromPath is the Path that the Value was moved from, if ChangeType is
+	// DiffChangeMoved. Path is where it was moved to.
+	FromPath types.Path
 }
 
 func (dif Difference) IsEmpty() bool {
@@ -123,7 +126,7 @@ func (d differ) diffLists(p types.Path, v1, v2 types.List) (stop bool) {
 					stop = d.diff(append(p, types.NewIndexPath(idx)), lastEl, newEl)
 				} else {
 					p1 := p.Append(types.NewIndexPath(types.Number(splice.SpAt + i)))
-					dif := Difference{p1, types.DiffChangeModified, v1.Get(splice.SpAt + i), v2.Get(splice.SpFrom + i), nil}
+					dif := Difference{Path: p1, ChangeType: types.DiffChangeModified, OldValue: v1.Get(splice.SpAt + i), NewValue: v2.Get(splice.SpFrom + i)}
 					stop = !d.sendDiff(dif)
 				}
 			}
@@ -493,3 +493,65 @@ func TestNomsDiffPrintRef(t *testing.T) {
 	tf(true)
 	tf(false)
 }
+
+func TestNomsDiffPrintText(t *testing.T) {
+	assert := assert.New(t)
+
+	vs := newTestValueStore()
+	defer vs.Close()
+
+	text := func(lines ...int) string {
+		buf := &bytes.Buffer{}
+		for _, l := range lines {
+			fmt.Fprintf(buf, "line %d\n", l)
+		}
+		return buf.String()
+	}
+	s1 := createStruct("Doc",
+		"readme", types.NewBlob(vs, strings.NewReader(text(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15))),
+		"title", "a title",
+	)
+	s2 := createStruct("Doc",
+		"readme", types.NewBlob(vs, strings.NewReader(text(1, 2, 3, 4, 50, 6, 7, 8, 9, 10, 11, 12, 14, 15, 16))),
+		"title", "a title\nwith two lines",
+	)
+
+	expected := `(root) {
+    readme:
+    @@ -2,7 +2,7 @@
+    line 2
+    line 3
+    line 4
+-   line 5
++   line 50
+    line 6
+    line 7
+    line 8
+    @@ -10,6 +10,6 @@
+    line 10
+    line 11
+    line 12
+-   line 13
+    line 14
+    line 15
++   line 16
+    title:
+    @@ -1,1 +1,2 @@
+-   a title
++   a title
++   with two lines
+  }
+`
+	buf := &bytes.Buffer{}
+	PrintDiff(buf, s1, s2, false)
+	assert.Equal(expected, buf.String())
+
+	buf = &bytes.Buffer{}
+	PrintDiff(buf, types.String("a\nb\nc\n"), types.String("a\nc\nd\n"), false)
+	assert.Equal(`    @@ -1,3 +1,3 @@
+    a
+-   b
+    c
++   d
+`, buf.String())
+}
@@ -0,0 +1,198 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package diff
+
+import (
+	"github.com/attic-labs/noms/go/hash"
+	"github.com/attic-labs/noms/go/types"
+)
+
+// Options control how DiffWithOptions and PrintDiffWithOptions compare
+// values.
+type Options struct {
+	// LeftRight makes them use the left-right diff for ordered sequences -
+	// see Diff vs DiffLeftRight in Set and Map.
+	LeftRight bool
+	// DetectMoves makes them report a value that was removed from a Map,
+	// List or struct and added to the same one at a different key, index or
+	// field as a single Difference with ChangeType DiffChangeMoved, rather
+	// than as a removal and an addition. Because values are content
+	// addressed, this only compares hashes.
+	DetectMoves bool
+	// MoveSimilarity, if it's greater than 0, makes DetectMoves also pair
+	// removed and added structs that aren't equal, if they have the same
+	// name and at least this fraction of their fields are equal.
+	MoveSimilarity float64
+}
+
+// DiffWithOptions is like Diff, but compares |v1| and |v2| according to
+// |opts|. If opts.DetectMoves is set, all Differences are found before any
+// are sent, so that removals and additions can be paired up.
+func DiffWithOptions(v1, v2 types.Value, dChan chan<- Difference, stopChan chan struct{}, opts Options) {
+	if !opts.DetectMoves {
+		Diff(v1, v2, dChan, stopChan, opts.LeftRight)
+		return
+	}
+
+	innerChan := make(chan Difference)
+	innerStopChan := make(chan struct{})
+	go func() {
+		Diff(v1, v2, innerChan, innerStopChan, opts.LeftRight)
+		close(innerChan)
+	}()
+
+	difs := []Difference{}
+	for dif := range innerChan {
+		difs = append(difs, dif)
+	}
+
+	d := differ{diffChan: dChan, stopChan: stopChan}
+	for _, dif := range detectMoves(v1, difs, opts.MoveSimilarity) {
+		if !d.sendDiff(dif) {
+			return
+		}
+	}
+}
+
+// detectMoves replaces each removal in |difs| that can be paired with an
+// addition to the same collection with a DiffChangeMoved Difference at the
+// place of the addition.
+func detectMoves(root types.Value, difs []Difference, similarity float64) []Difference {
+	// Group the removals and additions by the path of their parent.
+	type group struct {
+		removed, added []int
+	}
+	groups := map[string]*group{}
+	order := []string{}
+	for i, dif := range difs {
+		if len(dif.Path) == 0 || (dif.ChangeType != types.DiffChangeRemoved && dif.ChangeType != types.DiffChangeAdded) {
+			continue
+		}
+		parentPath := dif.Path[:len(dif.Path)-1]
+		key := parentPath.String()
+		g, ok := groups[key]
+		if !ok {
+			// Values can't move within a Set.
+			if parentPath.Resolve(root, nil).Kind() == types.SetKind {
+				continue
+			}
+			g = &group{}
+			groups[key] = g
+			order = append(order, key)
+		}
+		if dif.ChangeType == types.DiffChangeRemoved {
+			g.removed = append(g.removed, i)
+		} else {
+			g.added = append(g.added, i)
+		}
+	}
+
+	// movedTo maps the index of an addition to the removal it's paired with.
+	movedTo := map[int]int{}
+	moved := map[int]bool{}
+	pair := func(removed, added int) {
+		movedTo[added] = removed
+		moved[removed] = true
+	}
+	for _, key := range order {
+		g := groups[key]
+		if len(g.removed) == 0 || len(g.added) == 0 {
+			continue
+		}
+
+		byHash := map[hash.Hash][]int{}
+		for _, a := range g.added {
+			h := difs[a].NewValue.Hash()
+			byHash[h] = append(byHash[h], a)
+		}
+		unpaired := []int{}
+		for _, r := range g.removed {
+			h := difs[r].OldValue.Hash()
+			if added := byHash[h]; len(added) > 0 {
+				pair(r, added[0])
+				byHash[h] = added[1:]
+				continue
+			}
+			unpaired = append(unpaired, r)
+		}
+
+		if similarity <= 0 {
+			continue
+		}
+		for _, r := range unpaired {
+			best, bestSimilarity := -1, similarity
+			for _, a := range g.added {
+				if _, ok := movedTo[a]; ok {
+					continue
+				}
+				if s := structSimilarity(difs[r].OldValue, difs[a].NewValue); s >= bestSimilarity && (best < 0 || s > bestSimilarity) {
+					best, bestSimilarity = a, s
+				}
+			}
+			if best >= 0 {
+				pair(r, best)
+			}
+		}
+	}
+
+	result := make([]Difference, 0, len(difs)-len(moved))
+	for i, dif := range difs {
+		if moved[i] {
+			continue
+		}
+		if r, ok := movedTo[i]; ok {
+			dif.ChangeType = types.DiffChangeMoved
+			dif.OldValue = difs[r].OldValue
+			dif.FromPath = difs[r].Path
+		}
+		result = append(result, dif)
+	}
+	return result
+}
+
+// structSimilarity returns the fraction of the fields of |v1| and |v2| that
+// are equal, if they're structs with the same name, or 0 otherwise.
+func structSimilarity(v1, v2 types.Value) float64 {
+	s1, ok1 := v1.(types.Struct)
+	s2, ok2 := v2.(types.Struct)
+	if !ok1 || !ok2 || s1.Name() != s2.Name() {
+		return 0
+	}
+
+	fields, equal := 0, 0
+	s1.IterFields(func(name string, v types.Value) bool {
+		fields++
+		if v2, ok := s2.MaybeGet(name); ok && v.Equals(v2) {
+			equal++
+		}
+		return false
+	})
+	s2.IterFields(func(name string, v types.Value) bool {
+		if _, ok := s1.MaybeGet(name); !ok {
+			fields++
+		}
+		return false
+	})
+	if fields == 0 {
+		return 1
+	}
+	return float64(equal) / float64(fields)
+}
+
+// expandMoves turns each DiffChangeMoved Difference in |patch| back into the
+// removal and addition that it stands for.
+func expandMoves(patch Patch) Patch {
+	expanded := make(Patch, 0, len(patch))
+	for _, dif := range patch {
+		if dif.ChangeType != types.DiffChangeMoved {
+			expanded = append(expanded, dif)
+			continue
+		}
+		expanded = append(expanded,
+			Difference{Path: dif.FromPath, ChangeType: types.DiffChangeRemoved, OldValue: dif.OldValue},
+			Difference{Path: dif.Path, ChangeType: types.DiffChangeAdded, NewValue: dif.NewValue, NewKeyValue: dif.NewKeyValue})
+	}
+	return expanded
+}
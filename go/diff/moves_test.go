@@ -0,0 +1,132 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package diff
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/attic-labs/noms/go/chunks"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/attic-labs/noms/go/util/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func diffWithOptions(v1, v2 types.Value, opts Options) Patch {
+	dChan := make(chan Difference)
+	go func() {
+		DiffWithOptions(v1, v2, dChan, make(chan struct{}), opts)
+		close(dChan)
+	}()
+	patch := Patch{}
+	for dif := range dChan {
+		patch = append(patch, dif)
+	}
+	return patch
+}
+
+func TestDiffMovesMap(t *testing.T) {
+	assert := assert.New(t)
+
+	m1 := createMap("a", aa1, "b", "b-one", "c", "c-one")
+	m2 := createMap("b", "b-one", "c", "c-two", "d", aa1)
+
+	patch := diffWithOptions(m1, m2, Options{DetectMoves: true})
+	assert.Len(patch, 2)
+	assert.Equal(types.DiffChangeModified, patch[0].ChangeType)
+	assert.Equal(`["c"]`, patch[0].Path.String())
+	assert.Equal(types.DiffChangeMoved, patch[1].ChangeType)
+	assert.Equal(`["a"]`, patch[1].FromPath.String())
+	assert.Equal(`["d"]`, patch[1].Path.String())
+	assert.True(aa1.Equals(patch[1].OldValue))
+	assert.True(aa1.Equals(patch[1].NewValue))
+
+	assert.True(m2.Equals(Apply(m1, patch)))
+	assert.Len(diffWithOptions(m1, m2, Options{}), 3)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(PrintDiffWithOptions(buf, m1, m2, Options{DetectMoves: true}))
+	test.EqualsIgnoreHashes(t, `(root) {
+-   "c": "c-one"
++   "c": "c-two"
+>   "a" -> "d": map {  // 4 items
+>     "a1": "a-one",
+>     "a2": "a-two",
+>     "a3": "a-three",
+>     "a4": "a-four",
+>   }
+  }
+`, buf.String())
+}
+
+func TestDiffMovesList(t *testing.T) {
+	assert := assert.New(t)
+
+	l1 := createList("a", "b", "c", "d")
+	l2 := createList("b", "c", "d", "a")
+
+	patch := diffWithOptions(l1, l2, Options{DetectMoves: true})
+	assert.Len(patch, 1)
+	assert.Equal(types.DiffChangeMoved, patch[0].ChangeType)
+	assert.Equal("[0]", patch[0].FromPath.String())
+	assert.Equal("[3]", patch[0].Path.String())
+	assert.True(l2.Equals(Apply(l1, patch)))
+
+	buf := &bytes.Buffer{}
+	assert.NoError(PrintDiffWithOptions(buf, l1, l2, Options{DetectMoves: true}))
+	assert.Equal("(root) {\n>   [0] -> [3]: \"a\"\n  }\n", buf.String())
+
+	// Values don't move within Sets.
+	s1, s2 := createSet("a", "b"), createSet("b", "c")
+	assert.Len(diffWithOptions(s1, s2, Options{DetectMoves: true}), 2)
+}
+
+func TestDiffMovesStructSimilarity(t *testing.T) {
+	assert := assert.New(t)
+
+	bob := createStruct("Person", "name", "bob", "age", 30, "city", "nyc")
+	olderBob := createStruct("Person", "name", "bob", "age", 31, "city", "nyc")
+	carol := createStruct("Person", "name", "carol", "age", 31, "city", "sf")
+	s1 := createStruct("", "owner", bob)
+	s2 := createStruct("", "author", olderBob)
+	s3 := createStruct("", "author", carol)
+
+	assert.Len(diffWithOptions(s1, s2, Options{DetectMoves: true}), 2)
+
+	patch := diffWithOptions(s1, s2, Options{DetectMoves: true, MoveSimilarity: 0.5})
+	assert.Len(patch, 1)
+	assert.Equal(types.DiffChangeMoved, patch[0].ChangeType)
+	assert.Equal(".owner", patch[0].FromPath.String())
+	assert.Equal(".author", patch[0].Path.String())
+	assert.True(bob.Equals(patch[0].OldValue))
+	assert.True(olderBob.Equals(patch[0].NewValue))
+	assert.True(s2.Equals(Apply(s1, patch)))
+
+	assert.Len(diffWithOptions(s1, s3, Options{DetectMoves: true, MoveSimilarity: 0.5}), 2)
+}
+
+func TestDiffMovesPatchEncoding(t *testing.T) {
+	assert := assert.New(t)
+	vs := types.NewValueStore((&chunks.TestStorage{}).NewView())
+	defer vs.Close()
+
+	m1 := createMap("a", aa1, "b", "b-one")
+	m2 := createMap("b", "b-two", "c", aa1)
+	patch := diffWithOptions(m1, m2, Options{DetectMoves: true})
+	assert.Len(patch, 2)
+
+	fromValue, err := PatchFromValue(PatchToValue(vs, patch))
+	assert.NoError(err)
+	assert.Equal(types.DiffChangeMoved, fromValue[1].ChangeType)
+	assert.Equal(`["a"]`, fromValue[1].FromPath.String())
+	assert.True(m2.Equals(Apply(m1, fromValue)))
+
+	buf := &bytes.Buffer{}
+	assert.NoError(WritePatchJSON(buf, patch))
+	assert.Contains(buf.String(), `"fromPath": "[\"a\"]"`)
+	fromJSON, err := ReadPatchJSON(buf, vs)
+	assert.NoError(err)
+	assert.True(m2.Equals(Apply(m1, fromJSON)))
+}
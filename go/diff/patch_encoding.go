@@ -0,0 +1,287 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/attic-labs/noms/go/nomdl"
+	"github.com/attic-labs/noms/go/types"
+)
+
+// DifferenceName is the name of the structs that PatchToValue encodes each
+// Difference of a Patch as.
+const DifferenceName = "Difference"
+
+const (
+	patchPathField        = "path"
+	patchChangeTypeField  = "changeType"
+	patchOldValueField    = "oldValue"
+	patchNewValueField    = "newValue"
+	patchNewKeyValueField = "newKeyValue"
+	patchFromPathField    = "fromPath"
+)
+
+var changeTypeNames = map[types.DiffChangeType]string{
+	types.DiffChangeAdded:    "added",
+	types.DiffChangeRemoved:  "removed",
+	types.DiffChangeModified: "modified",
+	types.DiffChangeMoved:    "moved",
+}
+
+// ChangeTypeString returns the name of |ct| that Difference structs and JSON
+// patches use, i.e. "added", "removed", "modified" or "moved".
+func ChangeTypeString(ct types.DiffChangeType) string {
+	return changeTypeNames[ct]
+}
+
+func parseChangeType(s string) (types.DiffChangeType, error) {
+	for ct, name := range changeTypeNames {
+		if name == s {
+			return ct, nil
+		}
+	}
+	return 0, fmt.Errorf("Invalid change type: %s", s)
+}
+
+// The root of a value has an empty Path, which ParsePath doesn't accept.
+func parsePatchPath(s string) (types.Path, error) {
+	if s == "" {
+		return nil, nil
+	}
+	return types.ParsePath(s)
+}
+
+// parseFromPath parses the path a value moved from, which unlike the path of
+// a Difference can't be the root.
+func parseFromPath(s string) (types.Path, error) {
+	if s == "" {
+		return nil, fmt.Errorf("A moved value needs a %s", patchFromPathField)
+	}
+	return types.ParsePath(s)
+}
+
+// NewPatch returns the Patch that turns |v1| into |v2|, i.e. such that
+// Apply(v1, NewPatch(v1, v2)) equals v2.
+func NewPatch(v1, v2 types.Value) Patch {
+	dChan := make(chan Difference)
+	sChan := make(chan struct{})
+	go func() {
+		Diff(v1, v2, dChan, sChan, true)
+		close(dChan)
+	}()
+
+	patch := Patch{}
+	for dif := range dChan {
+		patch = append(patch, dif)
+	}
+	return patch
+}
+
+// PatchToValue encodes |patch| as a List of Difference structs, so that it can
+// be committed to a Database, synced and later turned back into a Patch with
+// PatchFromValue. Each struct has a String |path|, a String |changeType| of
+// "added", "removed", "modified" or "moved", and |oldValue|, |newValue| and
+// |newKeyValue| fields for the values of the Difference that aren't nil. Moves
+// also have a String |fromPath|.
+func PatchToValue(vrw types.ValueReadWriter, patch Patch) types.List {
+	le := types.NewList(vrw).Edit()
+	for _, dif := range patch {
+		data := types.StructData{
+			patchPathField:       types.String(dif.Path.String()),
+			patchChangeTypeField: types.String(changeTypeNames[dif.ChangeType]),
+		}
+		for name, v := range map[string]types.Value{
+			patchOldValueField:    dif.OldValue,
+			patchNewValueField:    dif.NewValue,
+			patchNewKeyValueField: dif.NewKeyValue,
+		} {
+			if v != nil {
+				data[name] = v
+			}
+		}
+		if dif.ChangeType == types.DiffChangeMoved {
+			data[patchFromPathField] = types.String(dif.FromPath.String())
+		}
+		le.Append(types.NewStruct(DifferenceName, data))
+	}
+	return le.List()
+}
+
+// PatchFromValue decodes a Patch that was encoded with PatchToValue.
+func PatchFromValue(v types.Value) (Patch, error) {
+	l, ok := v.(types.List)
+	if !ok {
+		return nil, fmt.Errorf("A patch must be a List, not a %s", v.Kind())
+	}
+
+	patch := make(Patch, 0, l.Len())
+	var err error
+	l.Iter(func(v types.Value, i uint64) bool {
+		var dif Difference
+		if dif, err = differenceFromValue(v); err != nil {
+			err = fmt.Errorf("Invalid difference at %d: %s", i, err)
+			return true
+		}
+		patch = append(patch, dif)
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	return patch, nil
+}
+
+func differenceFromValue(v types.Value) (dif Difference, err error) {
+	s, ok := v.(types.Struct)
+	if !ok || s.Name() != DifferenceName {
+		return dif, fmt.Errorf("Expected a %s struct", DifferenceName)
+	}
+
+	str := func(name string) string {
+		if fv, ok := s.MaybeGet(name); ok {
+			if sv, ok := fv.(types.String); ok {
+				return string(sv)
+			}
+		}
+		err = fmt.Errorf("Missing String field %s", name)
+		return ""
+	}
+	path, ct := str(patchPathField), str(patchChangeTypeField)
+	if err != nil {
+		return dif, err
+	}
+	if dif.Path, err = parsePatchPath(path); err != nil {
+		return dif, err
+	}
+	if dif.ChangeType, err = parseChangeType(ct); err != nil {
+		return dif, err
+	}
+	if dif.ChangeType == types.DiffChangeMoved {
+		fromPath := str(patchFromPathField)
+		if err != nil {
+			return dif, err
+		}
+		if dif.FromPath, err = parseFromPath(fromPath); err != nil {
+			return dif, err
+		}
+	}
+	dif.OldValue, _ = s.MaybeGet(patchOldValueField)
+	dif.NewValue, _ = s.MaybeGet(patchNewValueField)
+	dif.NewKeyValue, _ = s.MaybeGet(patchNewKeyValueField)
+	return dif, nil
+}
+
+// jsonDifference is the JSON form of a Difference. Values are written in the
+// text format that types.EncodedValue prints and nomdl parses.
+type jsonDifference struct {
+	Path        string `json:"path"`
+	ChangeType  string `json:"changeType"`
+	OldValue    string `json:"oldValue,omitempty"`
+	NewValue    string `json:"newValue,omitempty"`
+	NewKeyValue string `json:"newKeyValue,omitempty"`
+	FromPath    string `json:"fromPath,omitempty"`
+}
+
+// WritePatchJSON writes |patch| to |w| as a JSON array with an object for
+// each Difference, which has the same fields as the structs that PatchToValue
+// makes. Values are written as strings in the Noms text format, e.g.
+// "struct Person {name: \"bob\"}". Since a Ref can't be read back from that
+// format, it's an error if a value of |patch| contains one.
+func WritePatchJSON(w io.Writer, patch Patch) error {
+	difs := make([]jsonDifference, len(patch))
+	for i, dif := range patch {
+		difs[i] = jsonDifference{Path: dif.Path.String(), ChangeType: changeTypeNames[dif.ChangeType]}
+		if dif.ChangeType == types.DiffChangeMoved {
+			difs[i].FromPath = dif.FromPath.String()
+		}
+		for _, f := range []struct {
+			v   types.Value
+			out *string
+		}{{dif.OldValue, &difs[i].OldValue}, {dif.NewValue, &difs[i].NewValue}, {dif.NewKeyValue, &difs[i].NewKeyValue}} {
+			if f.v == nil {
+				continue
+			}
+			if containsRef(types.TypeOf(f.v)) {
+				return fmt.Errorf("Cannot write the value at %s as JSON because it contains a Ref", dif.Path)
+			}
+			*f.out = types.EncodedValue(f.v)
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(difs)
+}
+
+// ReadPatchJSON reads a Patch written by WritePatchJSON. Collections in it are
+// written to |vrw|.
+func ReadPatchJSON(r io.Reader, vrw types.ValueReadWriter) (Patch, error) {
+	difs := []jsonDifference{}
+	if err := json.NewDecoder(r).Decode(&difs); err != nil {
+		return nil, err
+	}
+
+	patch := make(Patch, len(difs))
+	for i, jd := range difs {
+		dif := &patch[i]
+		var err error
+		if dif.Path, err = parsePatchPath(jd.Path); err != nil {
+			return nil, fmt.Errorf("Invalid difference at %d: %s", i, err)
+		}
+		if dif.ChangeType, err = parseChangeType(jd.ChangeType); err != nil {
+			return nil, fmt.Errorf("Invalid difference at %d: %s", i, err)
+		}
+		if dif.ChangeType == types.DiffChangeMoved {
+			if dif.FromPath, err = parseFromPath(jd.FromPath); err != nil {
+				return nil, fmt.Errorf("Invalid difference at %d: %s", i, err)
+			}
+		}
+		for _, f := range []struct {
+			in  string
+			out *types.Value
+		}{{jd.OldValue, &dif.OldValue}, {jd.NewValue, &dif.NewValue}, {jd.NewKeyValue, &dif.NewKeyValue}} {
+			if f.in == "" {
+				continue
+			}
+			if *f.out, err = nomdl.Parse(vrw, f.in); err != nil {
+				return nil, fmt.Errorf("Invalid difference at %d: %s", i, err)
+			}
+		}
+	}
+	return patch, nil
+}
+
+func containsRef(t *types.Type) bool {
+	return containsRefRec(t, map[*types.Type]bool{})
+}
+
+func containsRefRec(t *types.Type, seen map[*types.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	switch desc := t.Desc.(type) {
+	case types.StructDesc:
+		found := false
+		desc.IterFields(func(name string, ft *types.Type, optional bool) {
+			found = found || containsRefRec(ft, seen)
+		})
+		return found
+	case types.CompoundDesc:
+		if t.TargetKind() == types.RefKind {
+			return true
+		}
+		for _, et := range desc.ElemTypes {
+			if containsRefRec(et, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package diff
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/attic-labs/noms/go/chunks"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatchEncodingRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+	vs := types.NewValueStore((&chunks.TestStorage{}).NewView())
+	defer vs.Close()
+
+	person := func(name string, age float64) types.Value {
+		return types.NewStruct("Person", types.StructData{"name": types.String(name), "age": types.Number(age)})
+	}
+	v1 := types.NewStruct("", types.StructData{
+		"list":    types.NewList(vs, types.Number(1), types.Number(2), types.Number(3)),
+		"set":     types.NewSet(vs, types.String("a"), person("alice", 30)),
+		"map":     types.NewMap(vs, person("bob", 40), types.String("b"), types.String("k"), types.Bool(true)),
+		"removed": types.String("gone"),
+		"blob":    types.NewBlob(vs, strings.NewReader("hello")),
+	})
+	v2 := types.NewStruct("", types.StructData{
+		"list":  types.NewList(vs, types.Number(1), types.Number(4), types.Number(3), types.Number(5)),
+		"set":   types.NewSet(vs, types.String("b"), person("alice", 31)),
+		"map":   types.NewMap(vs, person("carol", 50), types.String("c"), types.String("k"), types.Bool(false)),
+		"added": types.NewTuple(types.Int(-1), types.Uint(1)),
+		"blob":  types.NewBlob(vs, strings.NewReader("world")),
+	})
+
+	for _, vals := range [][2]types.Value{{v1, v2}, {v2, v1}, {types.Number(1), types.String("one")}} {
+		patch := NewPatch(vals[0], vals[1])
+		assert.NotEmpty(patch)
+		assert.True(vals[1].Equals(Apply(vals[0], patch)))
+
+		fromValue, err := PatchFromValue(PatchToValue(vs, patch))
+		assert.NoError(err)
+		assert.True(vals[1].Equals(Apply(vals[0], fromValue)))
+
+		buf := &bytes.Buffer{}
+		assert.NoError(WritePatchJSON(buf, patch))
+		fromJSON, err := ReadPatchJSON(buf, vs)
+		assert.NoError(err)
+		assert.True(vals[1].Equals(Apply(vals[0], fromJSON)))
+	}
+}
+
+func TestPatchToValue(t *testing.T) {
+	assert := assert.New(t)
+	vs := types.NewValueStore((&chunks.TestStorage{}).NewView())
+	defer vs.Close()
+
+	patch := Patch{
+		{Path: mustParsePath(assert, `.a`), ChangeType: types.DiffChangeModified, OldValue: types.Number(1), NewValue: types.Number(2)},
+		{Path: mustParsePath(assert, `.b`), ChangeType: types.DiffChangeRemoved, OldValue: types.String("x")},
+	}
+	expected := types.NewList(vs,
+		types.NewStruct("Difference", types.StructData{
+			"path": types.String(".a"), "changeType": types.String("modified"), "oldValue": types.Number(1), "newValue": types.Number(2),
+		}),
+		types.NewStruct("Difference", types.StructData{
+			"path": types.String(".b"), "changeType": types.String("removed"), "oldValue": types.String("x"),
+		}),
+	)
+	assert.True(expected.Equals(PatchToValue(vs, patch)))
+
+	buf := &bytes.Buffer{}
+	assert.NoError(WritePatchJSON(buf, patch))
+	assert.Equal(`[
+  {
+    "path": ".a",
+    "changeType": "modified",
+    "oldValue": "1",
+    "newValue": "2"
+  },
+  {
+    "path": ".b",
+    "changeType": "removed",
+    "oldValue": "\"x\""
+  }
+]
+`, buf.String())
+}
+
+func TestPatchDecodingErrors(t *testing.T) {
+	assert := assert.New(t)
+	vs := types.NewValueStore((&chunks.TestStorage{}).NewView())
+	defer vs.Close()
+
+	dif := func(data types.StructData) types.Value {
+		return types.NewList(vs, types.NewStruct("Difference", data))
+	}
+	for _, v := range []types.Value{
+		types.Number(1),
+		types.NewList(vs, types.Number(1)),
+		dif(types.StructData{"changeType": types.String("added")}),
+		dif(types.StructData{"path": types.String(".a"), "changeType": types.String("moved")}),
+		dif(types.StructData{"path": types.String("a"), "changeType": types.String("added")}),
+		dif(types.StructData{"path": types.Number(1), "changeType": types.String("added")}),
+	} {
+		_, err := PatchFromValue(v)
+		assert.Error(err)
+	}
+
+	for _, s := range []string{
+		`{}`,
+		`[{"path": ".a", "changeType": "moved"}]`,
+		`[{"path": ".a", "changeType": "added", "newValue": "struct {"}]`,
+	} {
+		_, err := ReadPatchJSON(strings.NewReader(s), vs)
+		assert.Error(err, s)
+	}
+
+	patch := Patch{{Path: mustParsePath(assert, `.a`), ChangeType: types.DiffChangeAdded, NewValue: types.NewList(vs, vs.WriteValue(types.Number(1)))}}
+	assert.Error(WritePatchJSON(&bytes.Buffer{}, patch))
+}
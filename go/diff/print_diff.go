@@ -5,7 +5,9 @@
 package diff
 
 import (
+	"fmt"
 	"io"
+	"strings"
 
 	"github.com/attic-labs/noms/go/types"
 	"github.com/attic-labs/noms/go/util/writers"
@@ -17,6 +19,7 @@ type prefixOp string
 const (
 	ADD = "+   "
 	DEL = "-   "
+	MOV = ">   "
 )
 
 type (
@@ -26,11 +29,20 @@ type (
 // PrintDiff writes a textual reprensentation of the diff from |v1| to |v2|
 // to |w|. If |leftRight| is true then the left-right diff is used for ordered
 // sequences - see Diff vs DiffLeftRight in Set and Map.
-func PrintDiff(w io.Writer, v1, v2 types.Value, leftRight bool) (err error) {
+func PrintDiff(w io.Writer, v1, v2 types.Value, leftRight bool) error {
+	return PrintDiffWithOptions(w, v1, v2, Options{LeftRight: leftRight})
+}
+
+// PrintDiffWithOptions is like PrintDiff, but compares |v1| and |v2|
+// according to |opts|. Moved values are written as "from -> to: value".
+func PrintDiffWithOptions(w io.Writer, v1, v2 types.Value, opts Options) (err error) {
 	// In the case where the diff involves two simple values, just print out the
 	// diff and return. This is needed because the code below assumes that the
 	// values being compared have a parent.
 	if !shouldDescend(v1, v2) {
+		if lines1, lines2, ok := textLines(v1, v2); ok {
+			return writeTextDiff(w, "", lines1, lines2)
+		}
 		line(w, DEL, nil, v1)
 		return line(w, ADD, nil, v2)
 	}
@@ -46,7 +58,7 @@ func PrintDiff(w io.Writer, v1, v2 types.Value, leftRight bool) (err error) {
 	// From here on, we can assume that every Difference will have at least one
 	// element in the Path
 	go func() {
-		Diff(v1, v2, dChan, stopChan, leftRight)
+		DiffWithOptions(v1, v2, dChan, stopChan, opts)
 		close(dChan)
 	}()
 
@@ -69,29 +81,32 @@ func PrintDiff(w io.Writer, v1, v2 types.Value, leftRight bool) (err error) {
 		lastPart := d.Path[len(d.Path)-1]
 		parentEl := parentPath.Resolve(v1, nil)
 
-		var key types.Value
+		key := pathKey(parentEl, lastPart)
 		var pfunc printFunc = line
+		if _, ok := parentEl.(types.Struct); ok {
+			pfunc = field
+		}
 
-		switch parent := parentEl.(type) {
-		case types.Map:
-			if indexPath, ok := lastPart.(types.IndexPath); ok {
-				key = indexPath.Index
-			} else if hip, ok := lastPart.(types.HashIndexPath); ok {
-				// In this case, the map has a non-primitive key so the value
-				// is a ref to the key. We need the actual key, not a ref to it.
-				hip1 := hip
-				hip1.IntoKey = true
-				key = hip1.Resolve(parent, nil)
-			} else {
-				panic("unexpected Path type")
+		if d.ChangeType == types.DiffChangeMoved {
+			from := keyLabel(parentEl, pathKey(parentEl, d.FromPath[len(d.FromPath)-1]), d.FromPath[len(d.FromPath)-1])
+			to := keyLabel(parentEl, key, lastPart)
+			if err = moveLine(w, from, to, d.NewValue); err != nil {
+				stopDiff()
+				break
 			}
-		case types.Set:
-			// default values are ok
-		case types.Struct:
-			key = types.String(lastPart.(types.FieldPath).Name)
-			pfunc = field
-		case types.List:
-			// default values are ok
+			continue
+		}
+
+		if lines1, lines2, ok := textLines(d.OldValue, d.NewValue); ok {
+			label := ""
+			if key != nil {
+				label = keyLabel(parentEl, key, lastPart)
+			}
+			if err = writeTextDiff(w, label, lines1, lines2); err != nil {
+				stopDiff()
+				break
+			}
+			continue
 		}
 
 		if d.OldValue != nil {
@@ -109,6 +124,134 @@ func PrintDiff(w io.Writer, v1, v2 types.Value, leftRight bool) (err error) {
 	return
 }
 
+// pathKey returns the key that |part| of a Path selects in |parent|, or nil
+// if |parent| is a Set or List.
+func pathKey(parent types.Value, part types.PathPart) types.Value {
+	switch parent := parent.(type) {
+	case types.Map:
+		if indexPath, ok := part.(types.IndexPath); ok {
+			return indexPath.Index
+		} else if hip, ok := part.(types.HashIndexPath); ok {
+			// In this case, the map has a non-primitive key so the value
+			// is a ref to the key. We need the actual key, not a ref to it.
+			hip1 := hip
+			hip1.IntoKey = true
+			return hip1.Resolve(parent, nil)
+		}
+		panic("unexpected Path type")
+	case types.Struct:
+		return types.String(part.(types.FieldPath).Name)
+	}
+	// default values are ok for Sets and Lists
+	return nil
+}
+
+// keyLabel returns how |key|, which |part| selects in |parent|, is written in
+// a diff. Struct fields are written as their names, Map keys are encoded and
+// List indexes are written as the path part, e.g. "[3]".
+func keyLabel(parent types.Value, key types.Value, part types.PathPart) string {
+	if key == nil {
+		return part.String()
+	}
+	if _, ok := parent.(types.Struct); ok {
+		return string(key.(types.String))
+	}
+	return types.EncodedValue(key)
+}
+
+// textDiffContext is the number of unchanged lines around the changed lines
+// of a text diff.
+const textDiffContext = 3
+
+// textLines returns the lines of |v1| and |v2| if they're both Strings or text
+// Blobs and one of them has more than one line, so that a text diff is more
+// useful than printing both values.
+func textLines(v1, v2 types.Value) (lines1, lines2 []string, ok bool) {
+	if v1 == nil || v2 == nil {
+		return nil, nil, false
+	}
+	if lines1, ok = types.Lines(v1); !ok {
+		return nil, nil, false
+	}
+	if lines2, ok = types.Lines(v2); !ok {
+		return nil, nil, false
+	}
+	return lines1, lines2, len(lines1) > 1 || len(lines2) > 1
+}
+
+// writeTextDiff writes the changes from |lines1| to |lines2| as the hunks of
+// a unified diff, after |label| if it isn't empty.
+func writeTextDiff(w io.Writer, label string, lines1, lines2 []string) error {
+	if label != "" {
+		if err := write(w, []byte("    "+label+":\n")); err != nil {
+			return err
+		}
+	}
+
+	writeLines := func(op string, lines []string) error {
+		for _, l := range lines {
+			if err := write(w, []byte(op+strings.TrimSuffix(l, "\n")+"\n")); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	hunkRange := func(start, count int) string {
+		if count == 0 {
+			return fmt.Sprintf("%d,0", start)
+		}
+		return fmt.Sprintf("%d,%d", start+1, count)
+	}
+
+	splices := types.LineSplices(lines1, lines2)
+	delta := 0 // The number of lines added, less those removed, before splices[i].
+	for i := 0; i < len(splices); {
+		// A hunk has the splices whose context lines overlap.
+		j := i + 1
+		for j < len(splices) && splices[j].SpAt <= splices[j-1].SpAt+splices[j-1].SpRemoved+2*textDiffContext {
+			j++
+		}
+		first, last := splices[i], splices[j-1]
+		start := int(first.SpAt) - textDiffContext
+		if start < 0 {
+			start = 0
+		}
+		end := int(last.SpAt+last.SpRemoved) + textDiffContext
+		if end > len(lines1) {
+			end = len(lines1)
+		}
+		hunkDelta := 0
+		for _, sp := range splices[i:j] {
+			hunkDelta += int(sp.SpAdded) - int(sp.SpRemoved)
+		}
+
+		hdr := fmt.Sprintf("    @@ -%s +%s @@\n", hunkRange(start, end-start), hunkRange(start+delta, end-start+hunkDelta))
+		if err := write(w, []byte(hdr)); err != nil {
+			return err
+		}
+		at := start
+		for _, sp := range splices[i:j] {
+			if err := writeLines("    ", lines1[at:sp.SpAt]); err != nil {
+				return err
+			}
+			if err := writeLines(DEL, lines1[sp.SpAt:sp.SpAt+sp.SpRemoved]); err != nil {
+				return err
+			}
+			if err := writeLines(ADD, lines2[sp.SpFrom:sp.SpFrom+sp.SpAdded]); err != nil {
+				return err
+			}
+			at = int(sp.SpAt + sp.SpRemoved)
+		}
+		if err := writeLines("    ", lines1[at:end]); err != nil {
+			return err
+		}
+
+		delta += hunkDelta
+		i = j
+	}
+	return nil
+}
+
 func writeHeader(w io.Writer, p types.Path, wroteHdr *bool) error {
 	if *wroteHdr {
 		return nil
@@ -153,6 +296,17 @@ func field(w io.Writer, op prefixOp, name, val types.Value) error {
 	return write(w, []byte("\n"))
 }
 
+func moveLine(w io.Writer, from, to string, val types.Value) error {
+	genPrefix := func(w *writers.PrefixWriter) []byte {
+		return []byte(MOV)
+	}
+	pw := &writers.PrefixWriter{Dest: w, PrefixFunc: genPrefix, NeedsPrefix: true}
+	write(pw, []byte(from+" -> "+to))
+	write(w, []byte(": "))
+	writeEncodedValue(pw, val)
+	return write(w, []byte("\n"))
+}
+
 func writeEncodedValue(w io.Writer, v types.Value) error {
 	if v.Kind() != types.BlobKind {
 		return types.WriteEncodedValue(w, v)
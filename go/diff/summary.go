@@ -57,6 +57,73 @@ func Summary(value1, value2 types.Value) {
 	status.Done()
 }
 
+// Stats counts the changes between two values. The totals are the ones that
+// Summary prints, and Paths breaks the Differences that Diff finds down by
+// the path of the value that they change.
+type Stats struct {
+	Adds    uint64               `json:"adds"`
+	Removes uint64               `json:"removes"`
+	Changes uint64               `json:"changes"`
+	OldSize uint64               `json:"oldSize"`
+	NewSize uint64               `json:"newSize"`
+	Paths   map[string]PathStats `json:"paths"`
+}
+
+// PathStats counts the Differences in a single value, e.g. the elements that
+// were added to a List.
+type PathStats struct {
+	Adds    uint64 `json:"adds"`
+	Removes uint64 `json:"removes"`
+	Changes uint64 `json:"changes"`
+}
+
+// Summarize returns the Stats of the diff between two values. Like Summary,
+// it compares the values of commits rather than the commits themselves.
+func Summarize(value1, value2 types.Value) Stats {
+	if datas.IsCommit(value1) && datas.IsCommit(value2) {
+		value1 = value1.(types.Struct).Get(datas.ValueField)
+		value2 = value2.(types.Struct).Get(datas.ValueField)
+	}
+
+	ch := make(chan diffSummaryProgress)
+	go func() {
+		diffSummary(ch, value1, value2)
+		close(ch)
+	}()
+
+	stats := Stats{Paths: map[string]PathStats{}}
+	for p := range ch {
+		stats.Adds += p.Adds
+		stats.Removes += p.Removes
+		stats.Changes += p.Changes
+		stats.NewSize += p.NewSize
+		stats.OldSize += p.OldSize
+	}
+
+	dChan := make(chan Difference)
+	go func() {
+		Diff(value1, value2, dChan, make(chan struct{}), true)
+		close(dChan)
+	}()
+	for dif := range dChan {
+		var parent types.Path
+		if len(dif.Path) > 0 {
+			parent = dif.Path[:len(dif.Path)-1]
+		}
+		ps := stats.Paths[parent.String()]
+		switch dif.ChangeType {
+		case types.DiffChangeAdded:
+			ps.Adds++
+		case types.DiffChangeRemoved:
+			ps.Removes++
+		case types.DiffChangeModified:
+			ps.Changes++
+		}
+		stats.Paths[parent.String()] = ps
+	}
+	return stats
+}
+
 type diffSummaryProgress struct {
 	Adds, Removes, Changes, NewSize, OldSize uint64
 }
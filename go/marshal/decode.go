@@ -6,6 +6,8 @@ package marshal
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 	"reflect"
 	"sync"
 
@@ -51,6 +53,10 @@ import (
 //  - types.Map -> map[T]V, where T and V is determined recursively using the
 //    same rules.
 //  - types.Number -> float64
+//  - types.Int -> int64
+//  - types.Uint -> uint64
+//  - types.Timestamp -> time.Time
+//  - types.Decimal -> *big.Rat
 //  - types.String -> string
 //  - *types.Type -> *types.Type
 //  - types.Union -> interface
@@ -153,8 +159,8 @@ func (e *UnmarshalTypeMismatchError) Error() string {
 	return fmt.Sprintf("Cannot unmarshal %s into Go value of type %s%s", types.TypeOf(e.Value).Describe(), ts, e.details)
 }
 
-func overflowError(v types.Number, t reflect.Type) *UnmarshalTypeMismatchError {
-	return &UnmarshalTypeMismatchError{v, t, fmt.Sprintf(" (%g does not fit in %s)", v, t)}
+func overflowError(v types.Value, t reflect.Type) *UnmarshalTypeMismatchError {
+	return &UnmarshalTypeMismatchError{v, t, fmt.Sprintf(" (%v does not fit in %s)", v, t)}
 }
 
 // unmarshalNomsError wraps errors from Marshaler.UnmarshalNoms. These should
@@ -186,6 +192,9 @@ func typeDecoder(t reflect.Type, tags nomsTags) decoderFunc {
 	case reflect.String:
 		return stringDecoder
 	case reflect.Struct:
+		if t == timeType {
+			return timeDecoder
+		}
 		return structDecoder(t)
 	case reflect.Interface:
 		return interfaceDecoder(t)
@@ -203,6 +212,9 @@ func typeDecoder(t reflect.Type, tags nomsTags) decoderFunc {
 		if t.Implements(nomsValueInterface) {
 			return nomsValueDecoder
 		}
+		if t == ratPtrType {
+			return ratDecoder
+		}
 		fallthrough
 	default:
 		panic(&UnsupportedTypeError{Type: t})
@@ -226,35 +238,87 @@ func stringDecoder(v types.Value, rv reflect.Value) {
 }
 
 func floatDecoder(v types.Value, rv reflect.Value) {
-	if n, ok := v.(types.Number); ok {
+	switch n := v.(type) {
+	case types.Number:
+		rv.SetFloat(float64(n))
+	case types.Int:
 		rv.SetFloat(float64(n))
+	case types.Uint:
+		rv.SetFloat(float64(n))
+	case types.Decimal:
+		f, _ := n.Rat().Float64()
+		rv.SetFloat(f)
+	default:
+		panic(&UnmarshalTypeMismatchError{v, rv.Type(), ""})
+	}
+}
+
+func timeDecoder(v types.Value, rv reflect.Value) {
+	if ts, ok := v.(types.Timestamp); ok {
+		rv.Set(reflect.ValueOf(ts.Time()))
 	} else {
 		panic(&UnmarshalTypeMismatchError{v, rv.Type(), ""})
 	}
 }
 
+func ratDecoder(v types.Value, rv reflect.Value) {
+	var r *big.Rat
+	switch n := v.(type) {
+	case types.Decimal:
+		r = n.Rat()
+	case types.Int:
+		r = new(big.Rat).SetInt64(int64(n))
+	case types.Uint:
+		r = new(big.Rat).SetInt(new(big.Int).SetUint64(uint64(n)))
+	case types.Number:
+		r = new(big.Rat).SetFloat64(float64(n))
+	}
+	if r == nil {
+		panic(&UnmarshalTypeMismatchError{v, rv.Type(), ""})
+	}
+	rv.Set(reflect.ValueOf(r))
+}
+
 func intDecoder(v types.Value, rv reflect.Value) {
-	if n, ok := v.(types.Number); ok {
-		i := int64(n)
-		if rv.OverflowInt(i) {
+	var i int64
+	switch n := v.(type) {
+	case types.Number:
+		i = int64(n)
+	case types.Int:
+		i = int64(n)
+	case types.Uint:
+		if n > math.MaxInt64 {
 			panic(overflowError(n, rv.Type()))
 		}
-		rv.SetInt(i)
-	} else {
+		i = int64(n)
+	default:
 		panic(&UnmarshalTypeMismatchError{v, rv.Type(), ""})
 	}
+	if rv.OverflowInt(i) {
+		panic(overflowError(v, rv.Type()))
+	}
+	rv.SetInt(i)
 }
 
 func uintDecoder(v types.Value, rv reflect.Value) {
-	if n, ok := v.(types.Number); ok {
-		u := uint64(n)
-		if rv.OverflowUint(u) {
+	var u uint64
+	switch n := v.(type) {
+	case types.Number:
+		u = uint64(n)
+	case types.Int:
+		if n < 0 {
 			panic(overflowError(n, rv.Type()))
 		}
-		rv.SetUint(u)
-	} else {
+		u = uint64(n)
+	case types.Uint:
+		u = uint64(n)
+	default:
 		panic(&UnmarshalTypeMismatchError{v, rv.Type(), ""})
 	}
+	if rv.OverflowUint(u) {
+		panic(overflowError(v, rv.Type()))
+	}
+	rv.SetUint(u)
 }
 
 type decoderCacheT struct {
@@ -568,6 +632,14 @@ func getGoTypeForNomsType(nt *types.Type, rt reflect.Type, v types.Value) reflec
 		return reflect.TypeOf(false)
 	case types.NumberKind:
 		return reflect.TypeOf(float64(0))
+	case types.IntKind:
+		return reflect.TypeOf(int64(0))
+	case types.UintKind:
+		return reflect.TypeOf(uint64(0))
+	case types.TimestampKind:
+		return timeType
+	case types.DecimalKind:
+		return ratPtrType
 	case types.StringKind:
 		return reflect.TypeOf("")
 	case types.ListKind, types.SetKind:
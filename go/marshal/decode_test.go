@@ -9,10 +9,12 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"reflect"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/attic-labs/noms/go/chunks"
 	"github.com/attic-labs/noms/go/d"
@@ -336,6 +338,74 @@ func TestDecodeOverflows(tt *testing.T) {
 	t(&i32, -math.Pow(2, 31)-1, "int32")
 }
 
+func TestDecodeInts(tt *testing.T) {
+	assert := assert.New(tt)
+
+	var i64 int64
+	assert.NoError(Unmarshal(types.Int(math.MinInt64), &i64))
+	assert.Equal(int64(math.MinInt64), i64)
+	assert.NoError(Unmarshal(types.Uint(42), &i64))
+	assert.Equal(int64(42), i64)
+
+	var ui64 uint64
+	assert.NoError(Unmarshal(types.Uint(math.MaxUint64), &ui64))
+	assert.Equal(uint64(math.MaxUint64), ui64)
+	assert.NoError(Unmarshal(types.Int(42), &ui64))
+	assert.Equal(uint64(42), ui64)
+
+	var f64 float64
+	assert.NoError(Unmarshal(types.Int(-42), &f64))
+	assert.Equal(float64(-42), f64)
+
+	var v interface{}
+	assert.NoError(Unmarshal(types.Int(-42), &v))
+	assert.Equal(int64(-42), v)
+	assert.NoError(Unmarshal(types.Uint(42), &v))
+	assert.Equal(uint64(42), v)
+
+	var i8 int8
+	assertDecodeErrorMessage(tt, types.Int(128), &i8, "Cannot unmarshal Int into Go value of type int8 (128 does not fit in int8)")
+	assertDecodeErrorMessage(tt, types.Uint(math.MaxUint64), &i64, "Cannot unmarshal Uint into Go value of type int64 (18446744073709551615 does not fit in int64)")
+	assertDecodeErrorMessage(tt, types.Int(-1), &ui64, "Cannot unmarshal Int into Go value of type uint64 (-1 does not fit in uint64)")
+}
+
+func TestDecodeTime(tt *testing.T) {
+	assert := assert.New(tt)
+
+	var when time.Time
+	assert.NoError(Unmarshal(types.Timestamp(1501801626123456789), &when))
+	assert.True(time.Date(2017, 8, 3, 23, 7, 6, 123456789, time.UTC).Equal(when))
+
+	var v interface{}
+	assert.NoError(Unmarshal(types.Timestamp(0), &v))
+	assert.Equal(time.Unix(0, 0).UTC(), v)
+
+	assertDecodeErrorMessage(tt, types.Number(42), &when, "Cannot unmarshal Number into Go value of type time.Time")
+}
+
+func TestDecodeRat(tt *testing.T) {
+	assert := assert.New(tt)
+
+	d, err := types.ParseDecimal("-12.05")
+	assert.NoError(err)
+
+	var r *big.Rat
+	assert.NoError(Unmarshal(d, &r))
+	assert.Equal(big.NewRat(-241, 20), r)
+	assert.NoError(Unmarshal(types.Int(-3), &r))
+	assert.Equal(big.NewRat(-3, 1), r)
+
+	var f float64
+	assert.NoError(Unmarshal(d, &f))
+	assert.Equal(-12.05, f)
+
+	var v interface{}
+	assert.NoError(Unmarshal(d, &v))
+	assert.Equal(big.NewRat(-241, 20), v)
+
+	assertDecodeErrorMessage(tt, types.String("1"), &r, "Cannot unmarshal String into Go value of type *big.Rat")
+}
+
 func TestDecodeMissingField(t *testing.T) {
 	type S struct {
 		A int32
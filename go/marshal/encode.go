@@ -9,10 +9,12 @@ package marshal
 
 import (
 	"fmt"
+	"math/big"
 	"reflect"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/attic-labs/noms/go/types"
 )
@@ -24,12 +26,20 @@ import (
 //
 // Boolean values are encoded as Noms types.Bool.
 //
-// Floating point and integer values are encoded as Noms types.Number. At the
-// moment this might lead to some loss in precision because types.Number
-// currently takes a float64.
+// Floating point and integer values are encoded as Noms types.Number. Because
+// types.Number is a float64, integers beyond 2^53 lose precision. Integer
+// fields tagged with `noms:",int"` are instead encoded exactly, as Noms
+// types.Int if signed and types.Uint if unsigned.
 //
 // String values are encoded as Noms types.String.
 //
+// time.Time values are encoded as Noms types.Timestamp. It is an error to
+// marshal a time.Time outside the years 1678 to 2262, like the zero time.Time.
+//
+// *big.Rat values are encoded as Noms types.Decimal. It is an error to
+// marshal a nil *big.Rat or one without a finite decimal representation, like
+// 1/3.
+//
 // Slices and arrays are encoded as Noms types.List by default. If a
 // field is tagged with `noms:"set", it will be encoded as Noms types.Set
 // instead.
@@ -74,6 +84,9 @@ import (
 //   //  omitted from the object if its value is empty, as defined above.
 //   Field int `noms:",omitempty"
 //
+//   // Field appears in a Noms struct as key "field", encoded as a types.Int.
+//   Field int64 `noms:",int"`
+//
 // The name of the Noms struct is the name of the Go struct where the first
 // character is changed to upper case. You can also implement the
 // StructNameMarshaler interface to get more control over the actual struct
@@ -123,7 +136,8 @@ func MustMarshal(vrw types.ValueReadWriter, v interface{}) types.Value {
 func MustMarshalOpt(vrw types.ValueReadWriter, v interface{}, opt Opt) types.Value {
 	rv := reflect.ValueOf(v)
 	nt := nomsTags{
-		set: opt.Set,
+		set:     opt.Set,
+		nomsInt: opt.Int,
 	}
 	encoder := typeEncoder(rv.Type(), map[string]reflect.Type{}, nt)
 	return encoder(rv, vrw)
@@ -181,6 +195,8 @@ func (e *marshalNomsError) Error() string {
 type Opt struct {
 	// Marshal []T or map[T]struct{} to Set<T>, or Unmarhsal Set<T> to map[T]struct{}.
 	Set bool
+	// Marshal integers to Int or Uint rather than Number.
+	Int bool
 }
 
 type nomsTags struct {
@@ -188,6 +204,7 @@ type nomsTags struct {
 	omitEmpty bool
 	original  bool
 	set       bool
+	nomsInt   bool
 	skip      bool
 	hasName   bool
 }
@@ -196,6 +213,8 @@ var nomsValueInterface = reflect.TypeOf((*types.Value)(nil)).Elem()
 var emptyInterface = reflect.TypeOf((*interface{})(nil)).Elem()
 var marshalerInterface = reflect.TypeOf((*Marshaler)(nil)).Elem()
 var structNameMarshalerInterface = reflect.TypeOf((*StructNameMarshaler)(nil)).Elem()
+var timeType = reflect.TypeOf(time.Time{})
+var ratPtrType = reflect.TypeOf((*big.Rat)(nil))
 
 type encoderFunc func(v reflect.Value, vrw types.ValueReadWriter) types.Value
 
@@ -215,6 +234,34 @@ func uintEncoder(v reflect.Value, vrw types.ValueReadWriter) types.Value {
 	return types.Number(float64(v.Uint()))
 }
 
+func timeEncoder(v reflect.Value, vrw types.ValueReadWriter) types.Value {
+	ts, err := types.NewTimestamp(v.Interface().(time.Time))
+	if err != nil {
+		panic(&marshalNomsError{err})
+	}
+	return ts
+}
+
+func ratEncoder(v reflect.Value, vrw types.ValueReadWriter) types.Value {
+	r := v.Interface().(*big.Rat)
+	if r == nil {
+		panic(&marshalNomsError{fmt.Errorf("Cannot marshal nil %s", ratPtrType)})
+	}
+	d, ok := types.NewDecimalFromRat(r)
+	if !ok {
+		panic(&marshalNomsError{fmt.Errorf("Cannot marshal %s, it has no finite decimal representation", r.RatString())})
+	}
+	return d
+}
+
+func nomsIntEncoder(v reflect.Value, vrw types.ValueReadWriter) types.Value {
+	return types.Int(v.Int())
+}
+
+func nomsUintEncoder(v reflect.Value, vrw types.ValueReadWriter) types.Value {
+	return types.Uint(v.Uint())
+}
+
 func stringEncoder(v reflect.Value, vrw types.ValueReadWriter) types.Value {
 	return types.String(v.String())
 }
@@ -247,12 +294,27 @@ func typeEncoder(t reflect.Type, seenStructs map[string]reflect.Type, tags nomsT
 	case reflect.Float64, reflect.Float32:
 		return float64Encoder
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if t.Implements(nomsValueInterface) {
+			return nomsValueEncoder
+		}
+		if tags.nomsInt {
+			return nomsIntEncoder
+		}
 		return intEncoder
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if t.Implements(nomsValueInterface) {
+			return nomsValueEncoder
+		}
+		if tags.nomsInt {
+			return nomsUintEncoder
+		}
 		return uintEncoder
 	case reflect.String:
 		return stringEncoder
 	case reflect.Struct:
+		if t == timeType {
+			return timeEncoder
+		}
 		return structEncoder(t, seenStructs)
 	case reflect.Slice, reflect.Array:
 		if shouldEncodeAsSet(t, tags) {
@@ -275,6 +337,9 @@ func typeEncoder(t reflect.Type, seenStructs map[string]reflect.Type, tags nomsT
 		if t.Implements(nomsValueInterface) {
 			return nomsValueEncoder
 		}
+		if t == ratPtrType {
+			return ratEncoder
+		}
 		fallthrough
 	default:
 		panic(&UnsupportedTypeError{Type: t})
@@ -445,6 +510,8 @@ func getTags(f reflect.StructField) (tags nomsTags) {
 			tags.original = true
 		case "set":
 			tags.set = true
+		case "int":
+			tags.nomsInt = true
 		default:
 			panic(&InvalidTagError{"Unrecognized tag: " + tag})
 		}
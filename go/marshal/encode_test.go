@@ -9,9 +9,11 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/attic-labs/noms/go/types"
 	"github.com/stretchr/testify/assert"
@@ -930,6 +932,8 @@ func TestNomsTypes(t *testing.T) {
 		Blob   types.Blob
 		Bool   types.Bool
 		Number types.Number
+		Int    types.Int
+		Uint   types.Uint
 		String types.String
 		Type   *types.Type
 	}
@@ -937,6 +941,8 @@ func TestNomsTypes(t *testing.T) {
 		Blob:   types.NewBlob(vs),
 		Bool:   types.Bool(true),
 		Number: types.Number(42),
+		Int:    types.Int(-42),
+		Uint:   types.Uint(42),
 		String: types.String("hi"),
 		Type:   types.NumberType,
 	}
@@ -945,12 +951,89 @@ func TestNomsTypes(t *testing.T) {
 			"blob":   types.NewBlob(vs),
 			"bool":   types.Bool(true),
 			"number": types.Number(42),
+			"int":    types.Int(-42),
+			"uint":   types.Uint(42),
 			"string": types.String("hi"),
 			"type":   types.NumberType,
 		}),
 	))
 }
 
+func TestEncodeTime(t *testing.T) {
+	assert := assert.New(t)
+
+	vs := newTestValueStore()
+	defer vs.Close()
+
+	type S struct {
+		When time.Time
+	}
+	when := time.Date(2017, 8, 3, 16, 7, 6, 123456789, time.FixedZone("PDT", -7*3600))
+	assert.True(MustMarshal(vs, S{when}).Equals(
+		types.NewStruct("S", types.StructData{
+			"when": types.Timestamp(1501801626123456789),
+		}),
+	))
+	assert.True(MustMarshal(vs, []time.Time{when}).Equals(types.NewList(vs, types.Timestamp(1501801626123456789))))
+
+	// Times a Timestamp can't represent, like the zero time.Time, are errors
+	// rather than wrapping around.
+	_, err := Marshal(vs, S{})
+	assert.Error(err)
+	_, err = Marshal(vs, time.Date(2263, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Error(err)
+}
+
+func TestEncodeRat(t *testing.T) {
+	assert := assert.New(t)
+
+	vs := newTestValueStore()
+	defer vs.Close()
+
+	type Entry struct {
+		Amount *big.Rat
+	}
+	d, err := types.ParseDecimal("-12.05")
+	assert.NoError(err)
+	assert.True(MustMarshal(vs, Entry{big.NewRat(-241, 20)}).Equals(
+		types.NewStruct("Entry", types.StructData{
+			"amount": d,
+		}),
+	))
+
+	_, err = Marshal(vs, big.NewRat(1, 3))
+	assert.EqualError(err, "Cannot marshal 1/3, it has no finite decimal representation")
+	_, err = Marshal(vs, Entry{})
+	assert.EqualError(err, "Cannot marshal nil *big.Rat")
+}
+
+func TestEncodeInts(t *testing.T) {
+	assert := assert.New(t)
+
+	vs := newTestValueStore()
+	defer vs.Close()
+
+	type S struct {
+		Number int64
+		Int    int64  `noms:",int"`
+		Uint   uint64 `noms:",int"`
+		Small  int8   `noms:",int"`
+	}
+	s := S{1<<53 + 1, 1<<53 + 1, math.MaxUint64, -8}
+	assert.True(MustMarshal(vs, s).Equals(
+		types.NewStruct("S", types.StructData{
+			"number": types.Number(1 << 53),
+			"int":    types.Int(1<<53 + 1),
+			"uint":   types.Uint(math.MaxUint64),
+			"small":  types.Int(-8),
+		}),
+	))
+
+	assert.True(MustMarshalOpt(vs, int32(-3), Opt{Int: true}).Equals(types.Int(-3)))
+	assert.True(MustMarshalOpt(vs, uint(3), Opt{Int: true}).Equals(types.Uint(3)))
+	assert.True(MustMarshal(vs, uint(3)).Equals(types.Number(3)))
+}
+
 type primitiveType int
 
 func (t primitiveType) MarshalNoms(vrw types.ValueReadWriter) (types.Value, error) {
@@ -54,7 +54,8 @@ func MustMarshalType(v interface{}) (nt *types.Type) {
 func MustMarshalTypeOpt(v interface{}, opt Opt) (nt *types.Type) {
 	rv := reflect.ValueOf(v)
 	tags := nomsTags{
-		set: opt.Set,
+		set:     opt.Set,
+		nomsInt: opt.Int,
 	}
 	nt = encodeType(rv.Type(), map[string]reflect.Type{}, tags)
 
@@ -115,6 +116,14 @@ func encodeType(t reflect.Type, seenStructs map[string]reflect.Type, tags nomsTa
 			return types.MakeMapType(types.ValueType, types.ValueType)
 		case "Number":
 			return types.NumberType
+		case "Int":
+			return types.IntType
+		case "Uint":
+			return types.UintType
+		case "Timestamp":
+			return types.TimestampType
+		case "Decimal":
+			return types.DecimalType
 		case "Ref":
 			return types.MakeRefType(types.ValueType)
 		case "Set":
@@ -129,14 +138,31 @@ func encodeType(t reflect.Type, seenStructs map[string]reflect.Type, tags nomsTa
 		panic(&marshalNomsError{err})
 	}
 
+	if t == ratPtrType {
+		return types.DecimalType
+	}
+
 	switch t.Kind() {
 	case reflect.Bool:
 		return types.BoolType
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if tags.nomsInt {
+			return types.IntType
+		}
+		return types.NumberType
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if tags.nomsInt {
+			return types.UintType
+		}
+		return types.NumberType
+	case reflect.Float32, reflect.Float64:
 		return types.NumberType
 	case reflect.String:
 		return types.StringType
 	case reflect.Struct:
+		if t == timeType {
+			return types.TimestampType
+		}
 		return structEncodeType(t, seenStructs)
 	case reflect.Array, reflect.Slice:
 		elemType := encodeType(t.Elem(), seenStructs, nomsTags{})
@@ -7,8 +7,10 @@ package marshal
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/attic-labs/noms/go/nomdl"
 	"github.com/attic-labs/noms/go/types"
@@ -40,6 +42,11 @@ func TestMarshalTypeType(tt *testing.T) {
 
 	t(types.BoolType, true)
 	t(types.StringType, "hi")
+	t(types.IntType, types.Int(0))
+	t(types.UintType, types.Uint(0))
+	t(types.TimestampType, types.Timestamp(0))
+	t(types.TimestampType, time.Time{})
+	t(types.DecimalType, types.Decimal{})
 
 	var l []int
 	t(types.MakeListType(types.NumberType), l)
@@ -110,6 +117,38 @@ func assertMarshalTypeErrorMessage(t *testing.T, v interface{}, expectedMessage
 	assert.Equal(t, expectedMessage, err.Error())
 }
 
+func TestMarshalTypeInts(t *testing.T) {
+	assert := assert.New(t)
+
+	type S struct {
+		Number int64
+		Int    int32  `noms:",int"`
+		Uint   uint16 `noms:",int"`
+		Float  float64
+	}
+	typ, err := MarshalType(S{})
+	assert.NoError(err)
+	assert.True(types.MakeStructTypeFromFields("S", types.FieldMap{
+		"number": types.NumberType,
+		"int":    types.IntType,
+		"uint":   types.UintType,
+		"float":  types.NumberType,
+	}).Equals(typ))
+}
+
+func TestMarshalTypeRat(t *testing.T) {
+	assert := assert.New(t)
+
+	type Entry struct {
+		Amount *big.Rat
+	}
+	typ, err := MarshalType(Entry{})
+	assert.NoError(err)
+	assert.True(types.MakeStructTypeFromFields("Entry", types.FieldMap{
+		"amount": types.DecimalType,
+	}).Equals(typ))
+}
+
 func TestMarshalTypeInvalidTypes(t *testing.T) {
 	assertMarshalTypeErrorMessage(t, make(chan int), "Type is not supported, type: chan int")
 }
@@ -0,0 +1,79 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package merge
+
+import (
+	"github.com/attic-labs/noms/go/types"
+)
+
+// Conflict describes a pair of changes that could not be merged. Path is
+// relative to the root of the merged values. Base, Ours and Theirs are the
+// values found at Path in the common ancestor and in the two candidates
+// passed to ThreeWay (a and b, respectively); each is nil if there is no
+// value at Path.
+type Conflict struct {
+	Path               types.Path
+	Base, Ours, Theirs types.Value
+}
+
+// ConflictRecorder merges values without failing on conflicts. Conflicts that
+// are not settled by Resolutions are appended to Conflicts and, so that the
+// merge can go on, resolved in favor of the first candidate ("ours").
+//
+// Conflicts caused by overlapping List splices cannot be recorded, and still
+// cause the merge to fail.
+type ConflictRecorder struct {
+	// Resolutions maps the String() of a Path to the Value that should be
+	// used there when a conflict is found at that Path. A nil Value means
+	// that there should be no value at that Path.
+	Resolutions map[string]types.Value
+	Conflicts   []Conflict
+}
+
+// NewConflictRecorder creates a ConflictRecorder that uses |resolutions|,
+// which may be nil.
+func NewConflictRecorder(resolutions map[string]types.Value) *ConflictRecorder {
+	if resolutions == nil {
+		resolutions = map[string]types.Value{}
+	}
+	return &ConflictRecorder{Resolutions: resolutions}
+}
+
+// Policy returns a Policy which merges using ThreeWay and records conflicts
+// in r. Unlike ThreeWay, the Policy also records a conflict at the empty Path
+// when the candidates themselves are unmergeable, e.g. two different Numbers.
+func (r *ConflictRecorder) Policy() Policy {
+	return r.policy(nil)
+}
+
+// policy returns r.Policy(), which also passes each change that it merges to
+// |record|, unless it's nil.
+func (r *ConflictRecorder) policy(record func(MergeChange)) Policy {
+	return func(a, b, parent types.Value, vrw types.ValueReadWriter, progress chan struct{}) (merged types.Value, err error) {
+		start := len(r.Conflicts)
+		if a != nil && b != nil && unmergeable(a, b) {
+			_, merged, _ = r.resolve(types.DiffChangeModified, types.DiffChangeModified, a, b, types.Path{})
+		} else {
+			merged, err = threeWayMerge(a, b, parent, vrw, r.resolve, progress, Options{}, record)
+		}
+		if parent != nil {
+			for i := start; i < len(r.Conflicts); i++ {
+				r.Conflicts[i].Base = r.Conflicts[i].Path.Resolve(parent, vrw)
+			}
+		}
+		return merged, err
+	}
+}
+
+func (r *ConflictRecorder) resolve(aChange, bChange types.DiffChangeType, a, b types.Value, path types.Path) (change types.DiffChangeType, merged types.Value, ok bool) {
+	if v, ok := r.Resolutions[path.String()]; ok {
+		if v == nil {
+			return types.DiffChangeRemoved, nil, true
+		}
+		return types.DiffChangeModified, v, true
+	}
+	r.Conflicts = append(r.Conflicts, Conflict{Path: path, Ours: a, Theirs: b})
+	return aChange, a, true
+}
@@ -0,0 +1,69 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package merge
+
+import (
+	"testing"
+
+	"github.com/attic-labs/noms/go/chunks"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConflictRecorder(t *testing.T) {
+	assert := assert.New(t)
+	storage := &chunks.MemoryStorage{}
+	vs := types.NewValueStore(storage.NewView())
+	defer vs.Close()
+
+	m := func(kv ...types.Value) types.Map {
+		return types.NewMap(vs, kv...)
+	}
+	k1, k2, k3 := types.String("k1"), types.String("k2"), types.String("k3")
+	parent := m(k1, types.Number(1), k2, types.Number(2))
+	a := m(k1, types.Number(10), k3, types.Number(3))
+	b := m(k1, types.Number(100))
+
+	// Without a recorder, changing k1 on both sides is fatal.
+	_, err := ThreeWay(a, b, parent, vs, None, nil)
+	assert.IsType(&ErrMergeConflict{}, err)
+
+	r := NewConflictRecorder(nil)
+	merged, err := r.Policy()(a, b, parent, vs, nil)
+	assert.NoError(err)
+	assert.True(m(k1, types.Number(10), k3, types.Number(3)).Equals(merged))
+	if assert.Len(r.Conflicts, 1) {
+		c := r.Conflicts[0]
+		assert.Equal(`["k1"]`, c.Path.String())
+		assert.True(types.Number(1).Equals(c.Base))
+		assert.True(types.Number(10).Equals(c.Ours))
+		assert.True(types.Number(100).Equals(c.Theirs))
+	}
+
+	r = NewConflictRecorder(map[string]types.Value{`["k1"]`: nil})
+	merged, err = r.Policy()(a, b, parent, vs, nil)
+	assert.NoError(err)
+	assert.Empty(r.Conflicts)
+	assert.True(m(k3, types.Number(3)).Equals(merged))
+
+	// Unmergeable candidates conflict at the root.
+	r = NewConflictRecorder(nil)
+	merged, err = r.Policy()(types.Number(1), types.String("one"), types.Bool(true), vs, nil)
+	assert.NoError(err)
+	assert.True(types.Number(1).Equals(merged))
+	if assert.Len(r.Conflicts, 1) {
+		assert.True(r.Conflicts[0].Path.IsEmpty())
+		assert.True(types.Bool(true).Equals(r.Conflicts[0].Base))
+	}
+
+	// Overlapping List splices can't be recorded.
+	r = NewConflictRecorder(nil)
+	l := func(vals ...types.Value) types.List {
+		return types.NewList(vs, vals...)
+	}
+	_, err = r.Policy()(m(k1, l(k1, k2)), m(k1, l(k1, k3)), m(k1, l(k1)), vs, nil)
+	assert.IsType(&ErrMergeConflict{}, err)
+	assert.Empty(r.Conflicts)
+}
@@ -0,0 +1,69 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package merge
+
+import (
+	"github.com/attic-labs/noms/go/hash"
+	"github.com/attic-labs/noms/go/types"
+)
+
+// minMoveSize is the smallest encoded size of a value that rebaseMoves
+// pairs up. Smaller values, like primitives, are too likely to be removed
+// at one key and added at another by coincidence.
+const minMoveSize = 32
+
+// rebaseMoves finds the values that |a| moved from one key of |parent| to
+// another, and that |b| modified at the old key, and moves them to the new
+// key in both |b| and |parent|. Without this, the merge would see a removal
+// in |a| and a modification in |b| of the same key, which conflict. With it,
+// |a|'s move is already in the returned parent, so only |b|'s modification
+// is left to merge. Moves are found by comparing hashes, so values that
+// changed as they moved aren't rebased, and a removed and an added value are
+// only paired if neither shares its hash with another removed or added value
+// and it's not a primitive or smaller than minMoveSize.
+func rebaseMoves(a, b, parent candidate, apply applyFunc) (candidate, candidate) {
+	changes := make(chan types.ValueChanged)
+	stop := make(chan struct{}, 1)
+	go func() {
+		a.diff(parent, changes, stop)
+		close(changes)
+	}()
+
+	removed := map[hash.Hash][]types.Value{}
+	added := map[hash.Hash][]types.Value{}
+	for change := range changes {
+		switch change.ChangeType {
+		case types.DiffChangeRemoved:
+			if v := parent.get(change.Key); movable(v) {
+				removed[v.Hash()] = append(removed[v.Hash()], change.Key)
+			}
+		case types.DiffChangeAdded:
+			if v := a.get(change.Key); movable(v) {
+				added[v.Hash()] = append(added[v.Hash()], change.Key)
+			}
+		}
+	}
+
+	for h, from := range removed {
+		to := added[h]
+		if len(from) != 1 || len(to) != 1 {
+			continue
+		}
+		pv, bv := parent.get(from[0]), b.get(from[0])
+		if bv == nil || bv.Equals(pv) || b.get(to[0]) != nil {
+			continue
+		}
+
+		parent = apply(parent, types.ValueChanged{ChangeType: types.DiffChangeRemoved, Key: from[0]}, pv)
+		parent = apply(parent, types.ValueChanged{ChangeType: types.DiffChangeAdded, Key: to[0]}, pv)
+		b = apply(b, types.ValueChanged{ChangeType: types.DiffChangeRemoved, Key: from[0]}, bv)
+		b = apply(b, types.ValueChanged{ChangeType: types.DiffChangeAdded, Key: to[0]}, bv)
+	}
+	return b, parent
+}
+
+func movable(v types.Value) bool {
+	return v != nil && !types.IsPrimitiveKind(v.Kind()) && len(types.EncodeValue(v).Data()) >= minMoveSize
+}
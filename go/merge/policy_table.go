@@ -0,0 +1,245 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package merge
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/attic-labs/noms/go/hash"
+	"github.com/attic-labs/noms/go/types"
+)
+
+// PathPolicy names the ResolveFunc that should resolve the conflicts at, or
+// below, the paths that match Path. Path is a types.Path, e.g. `.config` or
+// `.users["bob"]`, in which `[*]` matches any field, index or key, e.g.
+// `.counters[*]`. The other multi-valued PathParts aren't supported. The empty
+// Path matches everything. Policy is one of the names in ResolveFuncs.
+type PathPolicy struct {
+	Path   string `json:"path"`
+	Policy string `json:"policy"`
+}
+
+// ResolveFuncs are the ResolveFuncs that a PathPolicy can name.
+var ResolveFuncs = map[string]ResolveFunc{
+	"none":   None,
+	"ours":   Ours,
+	"theirs": Theirs,
+	"max":    Max,
+	"min":    Min,
+	"union":  Union,
+}
+
+// PolicyTable picks how each merge conflict is resolved by the path where it
+// happens, so that the fields of a value can have different conflict
+// semantics. See PathPolicy.
+type PolicyTable struct {
+	rules []policyRule
+}
+
+type policyRule struct {
+	pattern types.Path
+	resolve ResolveFunc
+}
+
+// NewPolicyTable creates a PolicyTable from |policies|. When more than one
+// of them matches the path of a conflict, the first one is used.
+func NewPolicyTable(policies ...PathPolicy) (PolicyTable, error) {
+	t := PolicyTable{make([]policyRule, len(policies))}
+	for i, p := range policies {
+		resolve, ok := ResolveFuncs[p.Policy]
+		if !ok {
+			return PolicyTable{}, fmt.Errorf("Unknown merge policy %q for %q, must be one of %s", p.Policy, p.Path, policyNames())
+		}
+		pattern, err := parsePathPattern(p.Path)
+		if err != nil {
+			return PolicyTable{}, err
+		}
+		t.rules[i] = policyRule{pattern, resolve}
+	}
+	return t, nil
+}
+
+// ReadPolicyTable reads a PolicyTable from |r|, which must hold a JSON array
+// of PathPolicy objects, e.g.
+//
+//   [
+//     {"path": ".config", "policy": "theirs"},
+//     {"path": ".counters[*]", "policy": "max"}
+//   ]
+func ReadPolicyTable(r io.Reader) (PolicyTable, error) {
+	policies := []PathPolicy{}
+	if err := json.NewDecoder(r).Decode(&policies); err != nil {
+		return PolicyTable{}, fmt.Errorf("Invalid merge policy file: %s", err)
+	}
+	return NewPolicyTable(policies...)
+}
+
+// ResolveFunc returns a ResolveFunc which resolves each conflict with the
+// ResolveFunc of the first PathPolicy in t that matches its path, or with
+// |fallback| if none do.
+func (t PolicyTable) ResolveFunc(fallback ResolveFunc) ResolveFunc {
+	if fallback == nil {
+		fallback = None
+	}
+	return func(aChange, bChange types.DiffChangeType, a, b types.Value, path types.Path) (change types.DiffChangeType, merged types.Value, ok bool) {
+		for _, rule := range t.rules {
+			if matchPathPattern(rule.pattern, path) {
+				return rule.resolve(aChange, bChange, a, b, path)
+			}
+		}
+		return fallback(aChange, bChange, a, b, path)
+	}
+}
+
+// listResolveFunc returns the ResolveFunc of the first PathPolicy in t that
+// matches |path|, if that PathPolicy names |path| itself rather than a path
+// above it. See Options.Lists.
+func (t PolicyTable) listResolveFunc(path types.Path) (ResolveFunc, bool) {
+	for _, rule := range t.rules {
+		if matchPathPattern(rule.pattern, path) {
+			return rule.resolve, len(rule.pattern) == len(path)
+		}
+	}
+	return nil, false
+}
+
+// Max resolves conflicts by choosing the greater of the two values, as
+// ordered by Value.Less. It can't resolve a conflict with a removal.
+func Max(aChange, bChange types.DiffChangeType, a, b types.Value, path types.Path) (change types.DiffChangeType, merged types.Value, ok bool) {
+	if a == nil || b == nil {
+		return change, merged, false
+	}
+	if a.Less(b) {
+		return bChange, b, true
+	}
+	return aChange, a, true
+}
+
+// Min resolves conflicts by choosing the lesser of the two values, as ordered
+// by Value.Less. It can't resolve a conflict with a removal.
+func Min(aChange, bChange types.DiffChangeType, a, b types.Value, path types.Path) (change types.DiffChangeType, merged types.Value, ok bool) {
+	if a == nil || b == nil {
+		return change, merged, false
+	}
+	if b.Less(a) {
+		return bChange, b, true
+	}
+	return aChange, a, true
+}
+
+// Union resolves conflicts between two Sets, Maps or Lists by combining
+// them. Sets are unioned, Maps get the entries of b whose keys aren't in a,
+// and Lists get the elements of b that aren't in a appended. A value that
+// was removed on one side is kept as the other side changed it.
+func Union(aChange, bChange types.DiffChangeType, a, b types.Value, path types.Path) (change types.DiffChangeType, merged types.Value, ok bool) {
+	if a == nil {
+		return bChange, b, b != nil
+	} else if b == nil {
+		return aChange, a, true
+	}
+
+	switch a := a.(type) {
+	case types.Set:
+		if b, ok := b.(types.Set); ok {
+			se := a.Edit()
+			b.IterAll(func(v types.Value) {
+				se.Insert(v)
+			})
+			return aChange, se.Set(), true
+		}
+	case types.Map:
+		if b, ok := b.(types.Map); ok {
+			me := a.Edit()
+			b.IterAll(func(k, v types.Value) {
+				if !a.Has(k) {
+					me.Set(k, v)
+				}
+			})
+			return aChange, me.Map(), true
+		}
+	case types.List:
+		if b, ok := b.(types.List); ok {
+			seen := hash.HashSet{}
+			a.IterAll(func(v types.Value, _ uint64) {
+				seen.Insert(v.Hash())
+			})
+			le := a.Edit()
+			b.IterAll(func(v types.Value, _ uint64) {
+				if !seen.Has(v.Hash()) {
+					seen.Insert(v.Hash())
+					le.Append(v)
+				}
+			})
+			return aChange, le.List(), true
+		}
+	}
+	return change, merged, false
+}
+
+func policyNames() string {
+	names := make([]string, 0, len(ResolveFuncs))
+	for name := range ResolveFuncs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// parsePathPattern parses |s| as a types.Path whose only multi-valued parts
+// are `[*]`s.
+func parsePathPattern(s string) (types.Path, error) {
+	if s == "" {
+		return types.Path{}, nil
+	}
+	p, err := types.ParsePath(s)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid path pattern %q: %s", s, err)
+	}
+	for _, part := range p {
+		if wp, ok := part.(types.WildcardPath); ok && !wp.IntoKey {
+			continue
+		}
+		if (types.Path{part}).IsMultiValued() {
+			return nil, fmt.Errorf("Invalid path pattern %q: only [*] can match more than one path", s)
+		}
+	}
+	return p, nil
+}
+
+// matchPathPattern returns whether |pattern| matches the start of |path|, so
+// that a pattern also matches everything below it.
+func matchPathPattern(pattern, path types.Path) bool {
+	if len(pattern) > len(path) {
+		return false
+	}
+	for i, part := range pattern {
+		if _, ok := part.(types.WildcardPath); ok {
+			if !matchesWildcard(path[i]) {
+				return false
+			}
+		} else if part.String() != path[i].String() {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesWildcard returns whether |part| addresses a field, index or key, as
+// the ones that `[*]` resolves to do.
+func matchesWildcard(part types.PathPart) bool {
+	switch part := part.(type) {
+	case types.FieldPath:
+		return true
+	case types.IndexPath:
+		return !part.IntoKey
+	case types.HashIndexPath:
+		return !part.IntoKey
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package merge
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/attic-labs/noms/go/chunks"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyTable(t *testing.T) {
+	assert := assert.New(t)
+	storage := &chunks.MemoryStorage{}
+	vs := types.NewValueStore(storage.NewView())
+	defer vs.Close()
+
+	s := func(config, cache string, c1, c2 float64, tags types.List, other string) types.Struct {
+		return types.NewStruct("", types.StructData{
+			"config":   types.NewStruct("", types.StructData{"mode": types.String(config)}),
+			"cache":    types.String(cache),
+			"counters": types.NewMap(vs, types.String("c1"), types.Number(c1), types.String("c2"), types.Number(c2)),
+			"tags":     tags,
+			"other":    types.String(other),
+		})
+	}
+	l := func(vals ...string) types.List {
+		l := types.NewList(vs)
+		for _, v := range vals {
+			l = l.Edit().Append(types.String(v)).List()
+		}
+		return l
+	}
+
+	parent := s("fast", "p", 1, 1, l("x"), "o")
+	a := s("slow", "a", 5, 2, l("x", "y"), "o")
+	b := s("safe", "b", 3, 7, l("x", "z"), "o")
+
+	table, err := ReadPolicyTable(strings.NewReader(`[
+		{"path": ".config", "policy": "theirs"},
+		{"path": ".cache", "policy": "ours"},
+		{"path": ".counters[*]", "policy": "max"},
+		{"path": ".tags", "policy": "union"}
+	]`))
+	assert.NoError(err)
+	policy := func(fallback ResolveFunc) Policy {
+		return NewThreeWayWithOptions(table.ResolveFunc(fallback), Options{Lists: table})
+	}
+	merged, err := policy(nil)(a, b, parent, vs, nil)
+	assert.NoError(err)
+	assert.True(s("safe", "a", 5, 7, l("x", "y", "z"), "o").Equals(merged), types.EncodedValue(merged))
+
+	// Overlapping List splices are only resolved by a PathPolicy for the List
+	// given as Options.Lists.
+	_, err = NewThreeWay(table.ResolveFunc(nil))(a, b, parent, vs, nil)
+	assert.IsType(&ErrMergeConflict{}, err)
+	_, err = NewThreeWay(Ours)(a, b, parent, vs, nil)
+	assert.IsType(&ErrMergeConflict{}, err)
+	below, err := NewPolicyTable(PathPolicy{"", "union"})
+	assert.NoError(err)
+	_, err = NewThreeWayWithOptions(below.ResolveFunc(nil), Options{Lists: below})(a, b, parent, vs, nil)
+	assert.IsType(&ErrMergeConflict{}, err)
+
+	// Conflicts that no PathPolicy matches go to the fallback.
+	b = s("safe", "b", 3, 7, l("x", "z"), "b")
+	a = s("slow", "a", 5, 2, l("x", "y"), "a")
+	_, err = policy(nil)(a, b, parent, vs, nil)
+	assert.IsType(&ErrMergeConflict{}, err)
+	merged, err = policy(Theirs)(a, b, parent, vs, nil)
+	assert.NoError(err)
+	assert.True(s("safe", "a", 5, 7, l("x", "y", "z"), "b").Equals(merged))
+
+	// The first matching PathPolicy wins.
+	table, err = NewPolicyTable(PathPolicy{"[*]", "min"}, PathPolicy{"", "ours"})
+	assert.NoError(err)
+	merged, err = policy(nil)(a, b, parent, vs, nil)
+	assert.NoError(err)
+	assert.True(s("safe", "a", 3, 2, l("x", "y"), "a").Equals(merged), types.EncodedValue(merged))
+}
+
+func TestPolicyTableErrors(t *testing.T) {
+	assert := assert.New(t)
+	for _, p := range []PathPolicy{
+		{".a", "nope"},
+		{"a", "ours"},
+		{".a[", "ours"},
+		{".a[\"b]", "ours"},
+		{".a[b]", "ours"},
+		{".a[1:2]", "ours"},
+		{".a[*]@key", "ours"},
+		{".a..b", "ours"},
+	} {
+		_, err := NewPolicyTable(p)
+		assert.Error(err, "%v", p)
+	}
+	_, err := ReadPolicyTable(strings.NewReader(`{"path": ".a"}`))
+	assert.Error(err)
+}
+
+func TestPathPattern(t *testing.T) {
+	assert := assert.New(t)
+	matches := func(pattern, path string) bool {
+		pp, err := parsePathPattern(pattern)
+		assert.NoError(err)
+		p, err := types.ParsePath(path)
+		assert.NoError(err)
+		return matchPathPattern(pp, p)
+	}
+
+	assert.True(matches("", ".a"))
+	assert.True(matches(".a", ".a"))
+	assert.True(matches(".a", ".a.b[1]"))
+	assert.False(matches(".a", ".ab"))
+	assert.False(matches(".a.b", ".a"))
+	assert.True(matches(`.a["k.[]"]`, `.a["k.[]"].b`))
+	assert.True(matches(".a[*].b", `.a["x"].b`))
+	assert.True(matches(".a[*].b", `.a[1].b`))
+	assert.True(matches(".a[*].b", `.a.c.b`))
+	assert.True(matches(".a[*]", `.a[#`+strings.Repeat("a", 32)+`]`))
+	assert.False(matches(".a[*]", `.a[1]@key`))
+	assert.True(matches("[*][0]", `.z[0]`))
+	assert.False(matches("[*][1]", `.z[0]`))
+}
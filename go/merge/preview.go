@@ -0,0 +1,109 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package merge
+
+import (
+	"fmt"
+
+	"github.com/attic-labs/noms/go/types"
+)
+
+// ChangeSource tells which of the candidates passed to Preview a merged change
+// came from.
+type ChangeSource int
+
+const (
+	// FromOurs is a change made only in the first candidate.
+	FromOurs ChangeSource = iota
+	// FromTheirs is a change made only in the second candidate.
+	FromTheirs
+	// FromBoth is the same change made in both candidates, or the result of
+	// merging changes that can be merged as a whole, e.g. two Lists whose
+	// splices don't overlap.
+	FromBoth
+)
+
+func (s ChangeSource) String() string {
+	switch s {
+	case FromOurs:
+		return "ours"
+	case FromTheirs:
+		return "theirs"
+	case FromBoth:
+		return "both"
+	}
+	return fmt.Sprintf("ChangeSource(%d)", int(s))
+}
+
+// MergeChange describes a change that was merged without conflict. Path is
+// relative to the root of the merged values and Value is the value at Path
+// after the change, or nil if it was removed.
+type MergeChange struct {
+	Path       types.Path
+	ChangeType types.DiffChangeType
+	Source     ChangeSource
+	Value      types.Value
+}
+
+// PreviewResult is what Preview found out about a merge.
+type PreviewResult struct {
+	// Merged is the merged value, in which each conflict is resolved in
+	// favor of the first candidate ("ours").
+	Merged    types.Value
+	Conflicts []Conflict
+	Changes   []MergeChange
+}
+
+// Preview merges |a| and |b| against |parent| as ThreeWay does, but doesn't
+// stop at conflicts: it returns every conflict, as a ConflictRecorder would,
+// and every change that was merged, in the order they were found. Nothing is
+// committed; the only values written to |vrw| are the targets of merged Refs.
+//
+// Unlike ThreeWay, candidates that can't be merged as a whole, e.g. two
+// Numbers, are only in conflict if both differ from |parent|.
+func Preview(a, b, parent types.Value, vrw types.ValueReadWriter) (PreviewResult, error) {
+	res := PreviewResult{}
+	record := func(c MergeChange) {
+		res.Changes = append(res.Changes, c)
+	}
+	if a != nil && b != nil && unmergeable(a, b) {
+		if merged, ok := recordWhole(types.Path{}, a, b, parent, record); ok {
+			res.Merged = merged
+			return res, nil
+		}
+	}
+
+	r := NewConflictRecorder(nil)
+	merged, err := r.policy(record)(a, b, parent, vrw, nil)
+	if err != nil {
+		return PreviewResult{}, err
+	}
+	res.Merged, res.Conflicts = merged, r.Conflicts
+	return res, nil
+}
+
+// recordWhole merges |a| and |b| against |parent| without looking into them,
+// which only works if at most one of them changed, and records the change at
+// |path|, if any.
+func recordWhole(path types.Path, a, b, parent types.Value, record func(MergeChange)) (merged types.Value, ok bool) {
+	changeType := types.DiffChangeModified
+	if parent == nil {
+		changeType = types.DiffChangeAdded
+	}
+	switch {
+	case a.Equals(b):
+		if parent == nil || !a.Equals(parent) {
+			record(MergeChange{path, changeType, FromBoth, a})
+		}
+		return a, true
+	case parent != nil && a.Equals(parent):
+		record(MergeChange{path, changeType, FromTheirs, b})
+		return b, true
+	case parent != nil && b.Equals(parent):
+		record(MergeChange{path, changeType, FromOurs, a})
+		return a, true
+	}
+	return nil, false
+}
@@ -0,0 +1,119 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package merge
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/attic-labs/noms/go/chunks"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func describeChanges(changes []MergeChange) (out []string) {
+	for _, c := range changes {
+		v := "<none>"
+		if c.Value != nil {
+			v = types.EncodedValue(c.Value)
+		}
+		change := map[types.DiffChangeType]string{
+			types.DiffChangeAdded:    "added",
+			types.DiffChangeRemoved:  "removed",
+			types.DiffChangeModified: "modified",
+		}[c.ChangeType]
+		out = append(out, fmt.Sprintf("%s %s %s %s", c.Source, change, c.Path, v))
+	}
+	return
+}
+
+func TestPreview(t *testing.T) {
+	assert := assert.New(t)
+	storage := &chunks.MemoryStorage{}
+	vs := types.NewValueStore(storage.NewView())
+	defer vs.Close()
+
+	m := func(kv ...types.Value) types.Map {
+		return types.NewMap(vs, kv...)
+	}
+	l := func(vals ...types.Value) types.List {
+		return types.NewList(vs, vals...)
+	}
+	n := func(f float64) types.Number {
+		return types.Number(f)
+	}
+	k1, k2, k3, k4, k5 := types.String("k1"), types.String("k2"), types.String("k3"), types.String("k4"), types.String("k5")
+	parent := m(k1, n(1), k2, n(2), k3, m(k1, n(1)), k4, l(n(1), n(2)))
+	a := m(k1, n(10), k2, n(20), k3, m(k1, n(1), k2, n(2)), k4, l(n(0), n(1), n(2)))
+	b := m(k1, n(100), k3, m(k1, n(10)), k4, l(n(1), n(2), n(3)), k5, n(5))
+
+	res, err := Preview(a, b, parent, vs)
+	assert.NoError(err)
+	assert.True(m(k1, n(10), k2, n(20), k3, m(k1, n(10), k2, n(2)), k4, l(n(0), n(1), n(2), n(3)), k5, n(5)).Equals(res.Merged))
+	if assert.Len(res.Conflicts, 2) {
+		c := res.Conflicts[0]
+		assert.Equal(`["k1"]`, c.Path.String())
+		assert.True(n(1).Equals(c.Base))
+		assert.True(n(10).Equals(c.Ours))
+		assert.True(n(100).Equals(c.Theirs))
+
+		// k2 is modified in a and removed in b.
+		c = res.Conflicts[1]
+		assert.Equal(`["k2"]`, c.Path.String())
+		assert.True(n(20).Equals(c.Ours))
+		assert.Nil(c.Theirs)
+	}
+	assert.Equal([]string{
+		`theirs modified ["k3"]["k1"] 10`,
+		`ours added ["k3"]["k2"] 2`,
+		`both modified ["k4"] ` + types.EncodedValue(l(n(0), n(1), n(2), n(3))),
+		`theirs added ["k5"] 5`,
+	}, describeChanges(res.Changes))
+
+	// Without conflicts, the merged value is what ThreeWay returns.
+	a = m(k1, n(1), k2, n(2), k3, m(k1, n(1)), k4, l(n(1), n(2)), k5, n(5))
+	b = m(k1, n(1), k3, m(k1, n(1)), k4, l(n(1), n(2)), k5, n(5))
+	res, err = Preview(a, b, parent, vs)
+	assert.NoError(err)
+	merged, err := ThreeWay(a, b, parent, vs, None, nil)
+	assert.NoError(err)
+	assert.True(merged.Equals(res.Merged))
+	assert.Empty(res.Conflicts)
+	assert.Equal([]string{
+		`theirs removed ["k2"] <none>`,
+		`both added ["k5"] 5`,
+	}, describeChanges(res.Changes))
+}
+
+func TestPreviewRoot(t *testing.T) {
+	assert := assert.New(t)
+	storage := &chunks.MemoryStorage{}
+	vs := types.NewValueStore(storage.NewView())
+	defer vs.Close()
+
+	// Values that can't be merged as a whole only conflict if both changed.
+	res, err := Preview(types.Number(1), types.Number(2), types.Number(1), vs)
+	assert.NoError(err)
+	assert.True(types.Number(2).Equals(res.Merged))
+	assert.Empty(res.Conflicts)
+	assert.Equal([]string{"theirs modified  2"}, describeChanges(res.Changes))
+
+	res, err = Preview(types.Number(2), types.Number(3), types.Number(1), vs)
+	assert.NoError(err)
+	assert.True(types.Number(2).Equals(res.Merged))
+	assert.Empty(res.Changes)
+	if assert.Len(res.Conflicts, 1) {
+		assert.True(res.Conflicts[0].Path.IsEmpty())
+		assert.True(types.Number(1).Equals(res.Conflicts[0].Base))
+	}
+
+	// Lists are merged as a whole.
+	l := func(vals ...types.Value) types.List {
+		return types.NewList(vs, vals...)
+	}
+	res, err = Preview(l(types.Number(1)), l(types.Number(1), types.Number(2)), l(types.Number(1)), vs)
+	assert.NoError(err)
+	assert.Equal([]string{"theirs modified  " + types.EncodedValue(l(types.Number(1), types.Number(2)))}, describeChanges(res.Changes))
+}
@@ -0,0 +1,54 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package merge
+
+import (
+	"sync"
+
+	"github.com/attic-labs/noms/go/d"
+	"github.com/attic-labs/noms/go/types"
+)
+
+// StructMergeFunc merges two structs of the name it's registered for, which
+// have both changed with respect to |parent|, without conflict. |parent| is
+// nil if the struct was added on both sides, or if it was something else
+// before. A StructMergeFunc must be deterministic, and merge(a, b) must
+// equal merge(b, a), so that replicas that merge in any order agree. If |a|
+// or |b| isn't the struct it expects, e.g. because it lacks a field, it
+// returns an error and ThreeWay merges them field by field instead.
+type StructMergeFunc func(a, b types.Struct, parent types.Value, vrw types.ValueReadWriter) (types.Struct, error)
+
+var (
+	structMergeFuncs   = map[string]StructMergeFunc{}
+	structMergeFuncsMu sync.RWMutex
+)
+
+// RegisterStructMerge makes ThreeWay merge any two structs named |name| with
+// |merge|, rather than field by field. This is how types like those in the
+// crdt package always merge without conflict. Since it applies to every struct
+// named |name|, |name| should be specific to the merge. Registering the same
+// name twice panics.
+func RegisterStructMerge(name string, merge StructMergeFunc) {
+	structMergeFuncsMu.Lock()
+	defer structMergeFuncsMu.Unlock()
+	if _, ok := structMergeFuncs[name]; ok {
+		d.Panic("A merge is already registered for struct %s", name)
+	}
+	structMergeFuncs[name] = merge
+}
+
+// registeredStructMerge returns the StructMergeFunc registered for |a| and
+// |b|, if they're structs with the same name.
+func registeredStructMerge(a, b types.Value) (StructMergeFunc, bool) {
+	aStruct, aOk := a.(types.Struct)
+	bStruct, bOk := b.(types.Struct)
+	if !aOk || !bOk || aStruct.Name() != bStruct.Name() {
+		return nil, false
+	}
+	structMergeFuncsMu.RLock()
+	defer structMergeFuncsMu.RUnlock()
+	merge, ok := structMergeFuncs[aStruct.Name()]
+	return merge, ok
+}
@@ -60,11 +60,35 @@ func newMergeConflict(format string, args ...interface{}) *ErrMergeConflict {
 // NewThreeWay creates a new Policy based on ThreeWay using the provided
 // ResolveFunc.
 func NewThreeWay(resolve ResolveFunc) Policy {
+	return NewThreeWayWithOptions(resolve, Options{})
+}
+
+// NewThreeWayWithOptions creates a new Policy based on ThreeWayWithOptions
+// using the provided ResolveFunc and Options.
+func NewThreeWayWithOptions(resolve ResolveFunc, opts Options) Policy {
 	return func(a, b, parent types.Value, vrw types.ValueReadWriter, progress chan struct{}) (merged types.Value, err error) {
-		return ThreeWay(a, b, parent, vrw, resolve, progress)
+		return ThreeWayWithOptions(a, b, parent, vrw, resolve, progress, opts)
 	}
 }
 
+// Options control optional merge behavior of ThreeWayWithOptions.
+type Options struct {
+	// DetectMoves makes a value that one candidate moved, unchanged, from one
+	// key of a Map or field of a struct to another, and that the other
+	// candidate modified at the old key, merge as the modified value at the
+	// new key. Otherwise, the removal and the modification conflict. Only
+	// values that are unique among those removed and among those added, and
+	// that aren't primitives or very small, are taken to have moved. It costs
+	// another diff of each Map and struct that both candidates changed.
+	DetectMoves bool
+
+	// Lists resolves overlapping splices to a List, which otherwise fail the
+	// merge, if the PathPolicy it would pick for the path of the List matches
+	// that path exactly, e.g. `.tags` but not `[*]` for `.tags[0]`. The two
+	// Lists are passed whole to its ResolveFunc, as two modifications.
+	Lists PolicyTable
+}
+
 // ThreeWay attempts a three-way merge between two _candidate_ values that
 // have both changed with respect to a common _parent_ value. The result of
 // the algorithm is a _merged_ value or an error if merging could not be done.
@@ -80,7 +104,8 @@ func NewThreeWay(resolve ResolveFunc) Policy {
 //     - if the two merged values are still different: conflict
 //   - if a key was inserted in one candidate and removed in the other: conflict
 // - If the values are structs:
-//   - Same as map, except using field names instead of map keys
+//   - If a StructMergeFunc is registered for their name and it accepts them: the result is what it returns
+//   - Otherwise, same as map, except using field names instead of map keys
 // - If the values are sets:
 //   - Apply the changes from both candidates to the parent to get the result. No conflicts are possible.
 // - If the values are list:
@@ -134,6 +159,20 @@ func NewThreeWay(resolve ResolveFunc) Policy {
 // b:      [a, d, e]
 // merged: [a, d, e]
 func ThreeWay(a, b, parent types.Value, vrw types.ValueReadWriter, resolve ResolveFunc, progress chan struct{}) (merged types.Value, err error) {
+	return threeWayMerge(a, b, parent, vrw, resolve, progress, Options{}, nil)
+}
+
+// ThreeWayWithOptions is like ThreeWay, but also merges according to |opts|.
+// With opts.DetectMoves, if one candidate moved a value to a new key and the
+// other modified it at the old key, the modified value is merged at the new
+// key.
+func ThreeWayWithOptions(a, b, parent types.Value, vrw types.ValueReadWriter, resolve ResolveFunc, progress chan struct{}, opts Options) (merged types.Value, err error) {
+	return threeWayMerge(a, b, parent, vrw, resolve, progress, opts, nil)
+}
+
+// threeWayMerge is ThreeWayWithOptions, which also passes each change that it
+// merges to |record|, unless it's nil.
+func threeWayMerge(a, b, parent types.Value, vrw types.ValueReadWriter, resolve ResolveFunc, progress chan struct{}, opts Options, record func(MergeChange)) (merged types.Value, err error) {
 	describe := func(v types.Value) string {
 		if v != nil {
 			return types.TypeOf(v).Describe()
@@ -150,7 +189,7 @@ func ThreeWay(a, b, parent types.Value, vrw types.ValueReadWriter, resolve Resol
 	if resolve == nil {
 		resolve = None
 	}
-	m := &merger{vrw, resolve, progress}
+	m := &merger{vrw, resolve, progress, opts, record}
 	return m.threeWay(a, b, parent, types.Path{})
 }
 
@@ -167,6 +206,33 @@ type merger struct {
 	vrw      types.ValueReadWriter
 	resolve  ResolveFunc
 	progress chan<- struct{}
+	opts     Options
+	record   func(MergeChange)
+}
+
+func (m *merger) recordChange(path types.Path, changeType types.DiffChangeType, source ChangeSource, v types.Value) {
+	if m.record != nil {
+		m.record(MergeChange{path, changeType, source, v})
+	}
+}
+
+// recordMerged records |merged|, the result of merging |a| and |b| as a
+// whole, as a change at |path|.
+func (m *merger) recordMerged(path types.Path, a, b, parent, merged types.Value) {
+	if m.record == nil || (parent != nil && merged.Equals(parent)) {
+		return
+	}
+	changeType := types.DiffChangeModified
+	if parent == nil {
+		changeType = types.DiffChangeAdded
+	}
+	source := FromBoth
+	if parent != nil && a.Equals(parent) {
+		source = FromTheirs
+	} else if parent != nil && b.Equals(parent) {
+		source = FromOurs
+	}
+	m.record(MergeChange{path, changeType, source, merged})
 }
 
 func updateProgress(progress chan<- struct{}) {
@@ -185,7 +251,20 @@ func (m *merger) threeWay(a, b, parent types.Value, path types.Path) (merged typ
 	switch a.Kind() {
 	case types.ListKind:
 		if aList, bList, pList, ok := listAssert(m.vrw, a, b, parent); ok {
-			return threeWayListMerge(aList, bList, pList)
+			merged, err := threeWayListMerge(aList, bList, pList)
+			if err != nil {
+				// Overlapping splices can't be resolved one by one, so only a
+				// policy for the List itself can resolve them.
+				if resolve, ok := m.opts.Lists.listResolveFunc(path); ok {
+					if _, resolved, ok := resolve(types.DiffChangeModified, types.DiffChangeModified, a, b, path); ok && resolved != nil {
+						m.recordMerged(path, a, b, parent, resolved)
+						return resolved, nil
+					}
+				}
+				return merged, err
+			}
+			m.recordMerged(path, a, b, parent, merged)
+			return merged, nil
 		}
 
 	case types.MapKind:
@@ -208,6 +287,12 @@ func (m *merger) threeWay(a, b, parent types.Value, path types.Path) (merged typ
 		}
 
 	case types.StructKind:
+		if merge, ok := registeredStructMerge(a, b); ok {
+			if merged, err := merge(a.(types.Struct), b.(types.Struct), parent, m.vrw); err == nil {
+				m.recordMerged(path, a, b, parent, merged)
+				return merged, nil
+			}
+		}
 		if aStruct, bStruct, pStruct, ok := structAssert(a, b, parent); ok {
 			return m.threeWayStructMerge(aStruct, bStruct, pStruct, path)
 		}
@@ -232,7 +317,12 @@ func (m *merger) threeWayMapMerge(a, b, parent types.Map, path types.Path) (merg
 			panic("Not Reached")
 		}
 	}
-	return m.threeWayOrderedSequenceMerge(mapCandidate{a}, mapCandidate{b}, mapCandidate{parent}, apply, path)
+	ac, bc, pc := candidate(mapCandidate{a}), candidate(mapCandidate{b}), candidate(mapCandidate{parent})
+	if m.opts.DetectMoves {
+		bc, pc = rebaseMoves(ac, bc, pc, apply)
+		ac, pc = rebaseMoves(bc, ac, pc, apply)
+	}
+	return m.threeWayOrderedSequenceMerge(ac, bc, pc, apply, path)
 }
 
 func (m *merger) threeWaySetMerge(a, b, parent types.Set, path types.Path) (merged types.Value, err error) {
@@ -272,7 +362,12 @@ func (m *merger) threeWayStructMerge(a, b, parent types.Struct, path types.Path)
 		}
 		panic(fmt.Errorf("Bad key type in diff: %s", types.TypeOf(change.Key).Describe()))
 	}
-	return m.threeWayOrderedSequenceMerge(structCandidate{a}, structCandidate{b}, structCandidate{parent}, apply, path)
+	ac, bc, pc := candidate(structCandidate{a}), candidate(structCandidate{b}), candidate(structCandidate{parent})
+	if m.opts.DetectMoves {
+		bc, pc = rebaseMoves(ac, bc, pc, apply)
+		ac, pc = rebaseMoves(bc, ac, pc, apply)
+	}
+	return m.threeWayOrderedSequenceMerge(ac, bc, pc, apply, path)
 }
 
 func listAssert(vrw types.ValueReadWriter, a, b, parent types.Value) (aList, bList, pList types.List, ok bool) {
@@ -236,3 +236,39 @@ func (s *ThreeWayKeyValMergeSuite) TestThreeWayMerge_NestedConflictingOperation(
 	s.tryThreeWayConflict(s.create(a), s.create(mm2b), s.create(mm2), `removed "k2"`)
 	s.tryThreeWayConflict(s.create(a), s.create(mm2b), s.create(mm2), `modded "k2"`)
 }
+
+func (s *ThreeWayKeyValMergeSuite) TestThreeWayMerge_MoveAndModify() {
+	opts := Options{DetectMoves: true}
+	tryMerge := func(a, b, p, exp seq) {
+		merged, err := ThreeWayWithOptions(s.create(a), s.create(b), s.create(p), s.vs, nil, nil, opts)
+		if s.NoError(err) {
+			s.True(s.create(exp).Equals(merged), "%s != %s", types.EncodedValue(s.create(exp)), types.EncodedValue(merged))
+		}
+	}
+	tryConflict := func(a, b, p seq, contained string) {
+		_, err := ThreeWayWithOptions(s.create(a), s.create(b), s.create(p), s.vs, nil, nil, opts)
+		if s.Error(err) {
+			s.Contains(err.Error(), contained)
+		}
+	}
+
+	p := kvs{"k1", aa1, "k3", "k-three"}
+	a := kvs{"k2", aa1, "k3", "k-three"}
+	b := kvs{"k1", aa1a, "k3", "k-three", "k4", "k-four"}
+	merged := kvs{"k2", aa1a, "k3", "k-three", "k4", "k-four"}
+	tryMerge(a, b, p, merged)
+	tryMerge(b, a, p, merged)
+
+	// Moves are only merged when asked for.
+	s.tryThreeWayConflict(s.create(a), s.create(b), s.create(p), `removed "k1"`)
+
+	// A value that's also modified as it's moved is a removal, which conflicts.
+	tryConflict(kvs{"k2", aa1b, "k3", "k-three"}, b, p, `removed "k1"`)
+
+	// Primitives aren't taken to have moved: here, a removed k1 and added an
+	// unrelated k2 that happens to have the same value.
+	tryConflict(kvs{"k2", "k-one", "k3", "k-three"}, kvs{"k1", "k-uno", "k3", "k-three"}, kvs{"k1", "k-one", "k3", "k-three"}, `removed "k1"`)
+
+	// Nor are values that were added, or removed, at more than one key.
+	tryConflict(kvs{"k2", aa1, "k3", "k-three", "k5", aa1}, b, p, `removed "k1"`)
+}
@@ -49,10 +49,12 @@ func (m *merger) threeWayOrderedSequenceMerge(a, b, parent candidate, apply appl
 		// It's also obviously OK to apply a change if only one diff is generating any changes, e.g. aChange.V is non-nil and bChange.V is nil.
 		if aChange.Key != nil && (bChange.Key == nil || aChange.Key.Less(bChange.Key)) {
 			merged = apply(merged, aChange, a.get(aChange.Key))
+			m.recordSequenceChange(a, aChange, path, FromOurs)
 			aChange = types.ValueChanged{}
 			continue
 		} else if bChange.Key != nil && (aChange.Key == nil || bChange.Key.Less(aChange.Key)) {
 			merged = apply(merged, bChange, b.get(bChange.Key))
+			m.recordSequenceChange(b, bChange, path, FromTheirs)
 			bChange = types.ValueChanged{}
 			continue
 		}
@@ -84,6 +86,11 @@ func (m *merger) mergeChanges(aChange, bChange types.ValueChanged, a, b, p candi
 
 	if aChange.ChangeType == types.DiffChangeRemoved || aValue.Equals(bValue) {
 		// If both diffs generated a remove, or if the new value is the same in both, merge is fine.
+		if aChange.ChangeType == types.DiffChangeRemoved {
+			m.recordChange(path, aChange.ChangeType, FromBoth, nil)
+		} else {
+			m.recordChange(path, aChange.ChangeType, FromBoth, aValue)
+		}
 		return aChange, aValue, nil
 	}
 
@@ -104,6 +111,17 @@ func (m *merger) mergeChanges(aChange, bChange types.ValueChanged, a, b, p candi
 	return change, nil, newMergeConflict("Conflict:\n%s = %s\nvs\n%s = %s", describeChange(aChange), types.EncodedValue(aValue), describeChange(bChange), types.EncodedValue(bValue))
 }
 
+func (m *merger) recordSequenceChange(c candidate, change types.ValueChanged, path types.Path, source ChangeSource) {
+	if m.record == nil {
+		return
+	}
+	var v types.Value
+	if change.ChangeType != types.DiffChangeRemoved {
+		v = c.get(change.Key)
+	}
+	m.recordChange(c.pathConcat(change, path), change.ChangeType, source, v)
+}
+
 func stopAndDrain(stop chan<- struct{}, drain <-chan types.ValueChanged) {
 	close(stop)
 	for range drain {
@@ -0,0 +1,120 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package merge
+
+import (
+	"strings"
+
+	"github.com/attic-labs/noms/go/types"
+)
+
+// MergeLines returns a ResolveFunc that merges conflicting modifications of
+// Strings, and of Blobs that hold text, line by line. The changes that a and
+// b make to the lines of the value in |parent| at the same path are merged
+// the way ThreeWay merges the splices of Lists, so they can be merged unless
+// they overlap. |parent| is the common ancestor that ThreeWay is called with.
+// Conflicts that can't be merged this way are passed on to |resolve|.
+func MergeLines(parent types.Value, vrw types.ValueReadWriter, resolve ResolveFunc) ResolveFunc {
+	if resolve == nil {
+		resolve = None
+	}
+	return func(aChange, bChange types.DiffChangeType, a, b types.Value, path types.Path) (change types.DiffChangeType, merged types.Value, ok bool) {
+		if aChange == types.DiffChangeModified && bChange == types.DiffChangeModified && parent != nil {
+			if merged, ok := threeWayTextMerge(a, b, path.Resolve(parent, vrw), vrw); ok {
+				return types.DiffChangeModified, merged, true
+			}
+		}
+		return resolve(aChange, bChange, a, b, path)
+	}
+}
+
+// NewLineMerge creates a Policy based on ThreeWay that resolves conflicts with
+// MergeLines, falling back to |resolve|. Unlike ThreeWay, it also merges
+// candidates that are themselves Strings or Blobs.
+func NewLineMerge(resolve ResolveFunc) Policy {
+	return NewLineMergeWithOptions(resolve, Options{})
+}
+
+// NewLineMergeWithOptions is like NewLineMerge, but merges according to
+// |opts| as ThreeWayWithOptions does.
+func NewLineMergeWithOptions(resolve ResolveFunc, opts Options) Policy {
+	return func(a, b, parent types.Value, vrw types.ValueReadWriter, progress chan struct{}) (merged types.Value, err error) {
+		if merged, ok := threeWayTextMerge(a, b, parent, vrw); ok {
+			return merged, nil
+		}
+		return ThreeWayWithOptions(a, b, parent, vrw, MergeLines(parent, vrw, resolve), progress, opts)
+	}
+}
+
+// threeWayTextMerge merges the lines of |a| and |b|, which must both be Strings
+// or Blobs of the same Kind as |parent|.
+func threeWayTextMerge(a, b, parent types.Value, vrw types.ValueReadWriter) (types.Value, bool) {
+	if a == nil || b == nil || parent == nil || a.Kind() != parent.Kind() || b.Kind() != parent.Kind() {
+		return nil, false
+	}
+	aLines, aOk := types.Lines(a)
+	bLines, bOk := types.Lines(b)
+	pLines, pOk := types.Lines(parent)
+	if !aOk || !bOk || !pOk {
+		return nil, false
+	}
+
+	lines, ok := threeWayLineMerge(aLines, bLines, pLines)
+	if !ok {
+		return nil, false
+	}
+	text := strings.Join(lines, "")
+	if parent.Kind() == types.StringKind {
+		return types.String(text), true
+	}
+	return types.NewBlob(vrw, strings.NewReader(text)), true
+}
+
+// threeWayLineMerge is threeWayListMerge for lines of text.
+func threeWayLineMerge(a, b, parent []string) (merged []string, ok bool) {
+	aSplices, bSplices := types.LineSplices(parent, a), types.LineSplices(parent, b)
+
+	apply := func(source []string, s types.Splice) {
+		merged = append(merged, source[s.SpFrom:s.SpFrom+s.SpAdded]...)
+	}
+	at := uint64(0)
+	copyTo := func(end uint64) {
+		merged = append(merged, parent[at:end]...)
+	}
+
+	for len(aSplices) > 0 || len(bSplices) > 0 {
+		if len(aSplices) > 0 && len(bSplices) > 0 && overlap(aSplices[0], bSplices[0]) {
+			if !canMergeLines(a, b, aSplices[0], bSplices[0]) {
+				return nil, false
+			}
+			bSplices = bSplices[1:]
+		}
+
+		var s types.Splice
+		var source []string
+		if len(bSplices) == 0 || (len(aSplices) > 0 && aSplices[0].SpAt < bSplices[0].SpAt) {
+			s, source, aSplices = aSplices[0], a, aSplices[1:]
+		} else {
+			s, source, bSplices = bSplices[0], b, bSplices[1:]
+		}
+		copyTo(s.SpAt)
+		apply(source, s)
+		at = s.SpAt + s.SpRemoved
+	}
+	copyTo(uint64(len(parent)))
+	return merged, true
+}
+
+func canMergeLines(a, b []string, aSplice, bSplice types.Splice) bool {
+	if aSplice != bSplice {
+		return false
+	}
+	for i := uint64(0); i < aSplice.SpAdded; i++ {
+		if a[aSplice.SpFrom+i] != b[bSplice.SpFrom+i] {
+			return false
+		}
+	}
+	return true
+}
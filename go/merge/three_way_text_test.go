@@ -0,0 +1,84 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package merge
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/attic-labs/noms/go/chunks"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThreeWayLineMerge(t *testing.T) {
+	assert := assert.New(t)
+	lines := func(s string) []string {
+		l, _ := types.Lines(types.String(s))
+		return l
+	}
+
+	tc := []struct {
+		a, b, parent, merged string
+	}{
+		{"a\nB\nc\nd\n", "a\nb\nc\nD\n", "a\nb\nc\nd\n", "a\nB\nc\nD\n"},
+		{"x\na\nb\n", "a\nb\ny\n", "a\nb\n", "x\na\nb\ny\n"},
+		{"a\nc\n", "a\nb\nc\nd\n", "a\nb\nc\n", "a\nc\nd\n"},
+		{"a\nX\nc\n", "a\nX\nc\n", "a\nb\nc\n", "a\nX\nc\n"},
+		{"", "a\nb\n", "a\n", "b\n"},
+		{"a\nc\n", "a\nb\nx\nc\n", "a\nb\nc\n", "a\nx\nc\n"},
+	}
+	for _, c := range tc {
+		merged, ok := threeWayLineMerge(lines(c.a), lines(c.b), lines(c.parent))
+		if assert.True(ok, "%q %q %q", c.a, c.b, c.parent) {
+			assert.Equal(c.merged, strings.Join(merged, ""))
+		}
+	}
+
+	for _, c := range []struct{ a, b, parent string }{
+		{"a\nX\nc\n", "a\nY\nc\n", "a\nb\nc\n"},
+		{"a\nc\n", "a\nB\nc\n", "a\nb\nc\n"},
+		{"a\nx\nb\n", "a\ny\nb\n", "a\nb\n"},
+	} {
+		_, ok := threeWayLineMerge(lines(c.a), lines(c.b), lines(c.parent))
+		assert.False(ok, "%q %q %q", c.a, c.b, c.parent)
+	}
+}
+
+func TestMergeLines(t *testing.T) {
+	assert := assert.New(t)
+	vs := types.NewValueStore((&chunks.MemoryStorage{}).NewView())
+	defer vs.Close()
+
+	blob := func(s string) types.Value {
+		return types.NewBlob(vs, strings.NewReader(s))
+	}
+	doc := func(body, notes types.Value) types.Value {
+		return types.NewStruct("Doc", types.StructData{"body": body, "notes": notes})
+	}
+	parent := doc(blob("one\ntwo\nthree\n"), types.String("a\nb\n"))
+	a := doc(blob("ONE\ntwo\nthree\n"), types.String("a\nb\nc\n"))
+	b := doc(blob("one\ntwo\nTHREE\n"), types.String("a\nb\n"))
+
+	_, err := ThreeWay(a, b, parent, vs, nil, nil)
+	assert.Error(err)
+
+	merged, err := NewLineMerge(None)(a, b, parent, vs, nil)
+	assert.NoError(err)
+	assert.True(doc(blob("ONE\ntwo\nTHREE\n"), types.String("a\nb\nc\n")).Equals(merged))
+
+	// The candidates themselves can be text.
+	merged, err = NewLineMerge(None)(types.String("x\nb\nc\n"), types.String("a\nb\ny\n"), types.String("a\nb\nc\n"), vs, nil)
+	assert.NoError(err)
+	assert.True(types.String("x\nb\ny\n").Equals(merged))
+
+	// Overlapping edits fall back to the ResolveFunc.
+	c := doc(blob("uno\ntwo\nthree\n"), types.String("a\nb\n"))
+	_, err = NewLineMerge(None)(a, c, parent, vs, nil)
+	assert.Error(err)
+	merged, err = NewLineMerge(Theirs)(a, c, parent, vs, nil)
+	assert.NoError(err)
+	assert.True(doc(blob("uno\ntwo\nthree\n"), types.String("a\nb\nc\n")).Equals(merged))
+}
@@ -0,0 +1,119 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Package migration rewrites Noms values whose types have evolved, e.g. when
+// a struct field is renamed or the type of a field changes. A Migration is a
+// list of declarative Rules which are applied to a whole value. Only the parts
+// of the value that a rule can affect are rebuilt, so unchanged subtrees are
+// shared between the old and the migrated value.
+package migration
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/attic-labs/noms/go/types"
+)
+
+// Rule is a single step of a Migration.
+type Rule interface {
+	// Apply returns a copy of v with the rule applied. Values that are
+	// reachable through Refs are migrated too and written to vrw.
+	Apply(vrw types.ValueReadWriter, v types.Value) (types.Value, error)
+
+	// String returns the rule in the syntax accepted by ParseRule.
+	String() string
+}
+
+// Migration is a list of Rules, which are applied in order.
+type Migration []Rule
+
+// Parse parses each of rules with ParseRule.
+func Parse(rules ...string) (Migration, error) {
+	m := make(Migration, len(rules))
+	for i, s := range rules {
+		r, err := ParseRule(s)
+		if err != nil {
+			return nil, err
+		}
+		m[i] = r
+	}
+	return m, nil
+}
+
+// ParseRule parses a rule in one of the forms:
+//
+//   rename <Struct>.<field> <newField>   renames a field of every <Struct>
+//   drop <Struct>.<field>                removes a field from every <Struct>
+//   convert <path> <Kind>                converts the values at <path> to <Kind>
+//
+// <Struct> can be * to match structs of any name. <path> is relative to the
+// migrated value, may be multi-valued (e.g. .people[*].age), and <Kind> is one
+// of Bool, Int, Number, String or Uint.
+func ParseRule(s string) (Rule, error) {
+	args := strings.Fields(s)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("Empty migration rule")
+	}
+	switch args[0] {
+	case "rename":
+		if len(args) == 3 {
+			name, field, ok := splitStructField(args[1])
+			if ok && types.IsValidStructFieldName(args[2]) {
+				return RenameField{name, field, args[2]}, nil
+			}
+		}
+	case "drop":
+		if len(args) == 2 {
+			if name, field, ok := splitStructField(args[1]); ok {
+				return DropField{name, field}, nil
+			}
+		}
+	case "convert":
+		if len(args) == 3 {
+			p, err := types.ParsePath(args[1])
+			if err != nil {
+				return nil, err
+			}
+			k, ok := convertibleKinds[args[2]]
+			if !ok {
+				return nil, fmt.Errorf("Cannot convert to %s", args[2])
+			}
+			return ConvertKind{p, k}, nil
+		}
+	}
+	return nil, fmt.Errorf("Invalid migration rule: %s", s)
+}
+
+func splitStructField(s string) (name, field string, ok bool) {
+	i := strings.LastIndex(s, ".")
+	if i < 0 {
+		return
+	}
+	name, field = s[:i], s[i+1:]
+	if name != AnyStruct && !types.IsValidStructFieldName(name) {
+		return
+	}
+	return name, field, types.IsValidStructFieldName(field)
+}
+
+// Apply applies each rule of m to v in turn.
+func (m Migration) Apply(vrw types.ValueReadWriter, v types.Value) (types.Value, error) {
+	for _, r := range m {
+		var err error
+		if v, err = r.Apply(vrw, v); err != nil {
+			return nil, fmt.Errorf("%s: %s", r, err)
+		}
+	}
+	return v, nil
+}
+
+// String returns the rules of m separated by semicolons.
+func (m Migration) String() string {
+	rules := make([]string, len(m))
+	for i, r := range m {
+		rules[i] = r.String()
+	}
+	return strings.Join(rules, "; ")
+}
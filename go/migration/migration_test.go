@@ -0,0 +1,190 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package migration
+
+import (
+	"testing"
+
+	"github.com/attic-labs/noms/go/chunks"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestValueStore() *types.ValueStore {
+	st := &chunks.TestStorage{}
+	return types.NewValueStore(st.NewView())
+}
+
+func person(name string, age types.Value) types.Struct {
+	return types.NewStruct("Person", types.StructData{"name": types.String(name), "age": age})
+}
+
+func TestParseRule(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, s := range []string{
+		"rename Person.name fullName",
+		"rename *.name fullName",
+		"drop Person.age",
+		"convert .people[*].age Number",
+		"convert .count Uint",
+	} {
+		r, err := ParseRule(s)
+		assert.NoError(err, s)
+		assert.Equal(s, r.String())
+	}
+
+	r, err := ParseRule("  rename   Person.name  fullName ")
+	assert.NoError(err)
+	assert.Equal(RenameField{"Person", "name", "fullName"}, r)
+
+	for _, s := range []string{
+		"",
+		"rename Person.name",
+		"rename Person name fullName",
+		"rename Person.name full-name",
+		"drop Person",
+		"drop .age",
+		"convert .age",
+		"convert .age Blob",
+		"convert age Number",
+		"split Person",
+	} {
+		_, err := ParseRule(s)
+		assert.Error(err, s)
+	}
+}
+
+func TestRenameAndDropField(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+	defer vs.Close()
+
+	other := types.NewStruct("Pet", types.StructData{"name": types.String("rex")})
+	v := types.NewStruct("", types.StructData{
+		"people": types.NewList(vs, person("alice", types.Number(30)), person("bob", types.Number(40))),
+		"byName": types.NewMap(vs, types.String("carol"), person("carol", types.Number(50))),
+		"ref":    vs.WriteValue(person("dave", types.Number(60))),
+		"pet":    other,
+	})
+
+	m, err := Parse("rename Person.name fullName", "drop Person.age")
+	assert.NoError(err)
+	migrated, err := m.Apply(vs, v)
+	assert.NoError(err)
+
+	renamed := func(name string) types.Value {
+		return types.NewStruct("Person", types.StructData{"fullName": types.String(name)})
+	}
+	expected := types.NewStruct("", types.StructData{
+		"people": types.NewList(vs, renamed("alice"), renamed("bob")),
+		"byName": types.NewMap(vs, types.String("carol"), renamed("carol")),
+		"ref":    types.NewRef(renamed("dave")),
+		"pet":    other,
+	})
+	assert.True(expected.Equals(migrated), types.EncodedValue(migrated))
+	assert.True(renamed("dave").Equals(migrated.(types.Struct).Get("ref").(types.Ref).TargetValue(vs)))
+
+	// Renaming onto an existing field is an error.
+	_, err = RenameField{"Person", "name", "age"}.Apply(vs, v)
+	assert.Error(err)
+
+	// Any struct.
+	migrated, err = RenameField{AnyStruct, "name", "id"}.Apply(vs, other)
+	assert.NoError(err)
+	assert.True(types.NewStruct("Pet", types.StructData{"id": types.String("rex")}).Equals(migrated))
+}
+
+func TestRewriteStructsSkipsUnaffectedValues(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+	defer vs.Close()
+
+	called := 0
+	fn := func(s types.Struct) (types.Value, error) {
+		called++
+		return s, nil
+	}
+
+	// Nothing of this type can contain a Person, so fn is never called and the
+	// Ref isn't followed.
+	v := types.NewList(vs, types.Number(1), vs.WriteValue(types.String("x")))
+	nv, err := RewriteStructs(vs, v, "Person", fn)
+	assert.NoError(err)
+	assert.True(v.Equals(nv))
+	assert.Equal(0, called)
+
+	v = types.NewList(vs, person("alice", types.Number(1)), types.Number(2), person("bob", types.Number(3)))
+	nv, err = RewriteStructs(vs, v, "Person", fn)
+	assert.NoError(err)
+	assert.True(v.Equals(nv))
+	assert.Equal(2, called)
+}
+
+func TestConvertKind(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+	defer vs.Close()
+
+	v := types.NewStruct("", types.StructData{
+		"people": types.NewList(vs, person("alice", types.String("30")), person("bob", types.Number(40))),
+	})
+	m, err := Parse("convert .people[*].age Number")
+	assert.NoError(err)
+	migrated, err := m.Apply(vs, v)
+	assert.NoError(err)
+	expected := types.NewStruct("", types.StructData{
+		"people": types.NewList(vs, person("alice", types.Number(30)), person("bob", types.Number(40))),
+	})
+	assert.True(expected.Equals(migrated), types.EncodedValue(migrated))
+
+	m, err = Parse("convert .people[*].age String")
+	assert.NoError(err)
+	migrated, err = m.Apply(vs, migrated)
+	assert.NoError(err)
+	expected = types.NewStruct("", types.StructData{
+		"people": types.NewList(vs, person("alice", types.String("30")), person("bob", types.String("40"))),
+	})
+	assert.True(expected.Equals(migrated), types.EncodedValue(migrated))
+
+	// A path that doesn't resolve is a no-op.
+	migrated, err = ConvertKind{types.MustParsePath(".missing"), types.NumberKind}.Apply(vs, v)
+	assert.NoError(err)
+	assert.True(v.Equals(migrated))
+
+	_, err = ConvertKind{types.MustParsePath(".people[0].name"), types.NumberKind}.Apply(vs, v)
+	assert.Error(err)
+}
+
+func TestConvert(t *testing.T) {
+	assert := assert.New(t)
+
+	test := func(v types.Value, k types.NomsKind, expected types.Value) {
+		nv, err := convert(v, k)
+		if expected == nil {
+			assert.Error(err)
+			return
+		}
+		assert.NoError(err)
+		assert.True(expected.Equals(nv), "%s -> %s", types.EncodedValue(v), k)
+	}
+
+	test(types.String("1.5"), types.NumberKind, types.Number(1.5))
+	test(types.String("-2"), types.IntKind, types.Int(-2))
+	test(types.String("2"), types.UintKind, types.Uint(2))
+	test(types.String("true"), types.BoolKind, types.Bool(true))
+	test(types.String("x"), types.NumberKind, nil)
+	test(types.String("-2"), types.UintKind, nil)
+	test(types.Number(1.5), types.StringKind, types.String("1.5"))
+	test(types.Number(3), types.IntKind, types.Int(3))
+	test(types.Number(1.5), types.IntKind, nil)
+	test(types.Number(-1), types.UintKind, nil)
+	test(types.Number(0), types.BoolKind, types.Bool(false))
+	test(types.Int(-3), types.StringKind, types.String("-3"))
+	test(types.Uint(3), types.NumberKind, types.Number(3))
+	test(types.Bool(true), types.NumberKind, types.Number(1))
+	test(types.Bool(false), types.StringKind, types.String("false"))
+	test(types.EmptyStruct, types.StringKind, nil)
+}
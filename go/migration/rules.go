@@ -0,0 +1,330 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package migration
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/attic-labs/noms/go/hash"
+	"github.com/attic-labs/noms/go/types"
+)
+
+// AnyStruct can be used as a struct name in rules to match structs of any name.
+const AnyStruct = "*"
+
+// RenameField renames the field From of every struct named Struct to To.
+type RenameField struct {
+	Struct, From, To string
+}
+
+func (r RenameField) Apply(vrw types.ValueReadWriter, v types.Value) (types.Value, error) {
+	return RewriteStructs(vrw, v, r.Struct, func(s types.Struct) (types.Value, error) {
+		fv, ok := s.MaybeGet(r.From)
+		if !ok {
+			return s, nil
+		}
+		if _, ok := s.MaybeGet(r.To); ok {
+			return nil, fmt.Errorf("Struct %s already has a field named %s", s.Name(), r.To)
+		}
+		return s.Delete(r.From).Set(r.To, fv), nil
+	})
+}
+
+func (r RenameField) String() string {
+	return fmt.Sprintf("rename %s.%s %s", r.Struct, r.From, r.To)
+}
+
+// DropField removes the field Field from every struct named Struct.
+type DropField struct {
+	Struct, Field string
+}
+
+func (r DropField) Apply(vrw types.ValueReadWriter, v types.Value) (types.Value, error) {
+	return RewriteStructs(vrw, v, r.Struct, func(s types.Struct) (types.Value, error) {
+		return s.Delete(r.Field), nil
+	})
+}
+
+func (r DropField) String() string {
+	return fmt.Sprintf("drop %s.%s", r.Struct, r.Field)
+}
+
+// ConvertKind converts every value that Path resolves to into a value of Kind,
+// e.g. the String "42" into the Number 42. Values that are already of Kind are
+// left as is, and it's an error if a value can't be converted.
+type ConvertKind struct {
+	Path types.Path
+	Kind types.NomsKind
+}
+
+var convertibleKinds = map[string]types.NomsKind{}
+
+func init() {
+	for _, k := range []types.NomsKind{types.BoolKind, types.IntKind, types.NumberKind, types.StringKind, types.UintKind} {
+		convertibleKinds[k.String()] = k
+	}
+}
+
+func (r ConvertKind) Apply(vrw types.ValueReadWriter, v types.Value) (types.Value, error) {
+	type match struct {
+		p types.Path
+		v types.Value
+	}
+	matches := []match{}
+	r.Path.ResolveAll(v, vrw, func(p types.Path, mv types.Value) bool {
+		if mv.Kind() != r.Kind {
+			matches = append(matches, match{p, mv})
+		}
+		return false
+	})
+
+	// Edit the last match first, like types.SetAtPath, in case converting a
+	// value changes its position in a Set or Map.
+	for i := len(matches) - 1; i >= 0; i-- {
+		nv, err := convert(matches[i].v, r.Kind)
+		if err != nil {
+			return nil, fmt.Errorf("%s at %s", err, matches[i].p)
+		}
+		if v, err = types.SetAtPath(v, matches[i].p, nv, vrw); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+func (r ConvertKind) String() string {
+	return fmt.Sprintf("convert %s %s", r.Path, r.Kind)
+}
+
+func convert(v types.Value, k types.NomsKind) (types.Value, error) {
+	var f float64
+	switch v := v.(type) {
+	case types.String:
+		s := string(v)
+		switch k {
+		case types.BoolKind:
+			if b, err := strconv.ParseBool(s); err == nil {
+				return types.Bool(b), nil
+			}
+		case types.IntKind:
+			if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+				return types.Int(i), nil
+			}
+		case types.NumberKind:
+			if n, err := strconv.ParseFloat(s, 64); err == nil {
+				return types.Number(n), nil
+			}
+		case types.UintKind:
+			if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+				return types.Uint(u), nil
+			}
+		}
+		return nil, fmt.Errorf("Cannot convert %q to %s", s, k)
+	case types.Bool:
+		if k == types.StringKind {
+			return types.String(strconv.FormatBool(bool(v))), nil
+		}
+		if v {
+			f = 1
+		}
+	case types.Number:
+		if k == types.StringKind {
+			return types.String(strconv.FormatFloat(float64(v), 'g', -1, 64)), nil
+		}
+		f = float64(v)
+	case types.Int:
+		if k == types.StringKind {
+			return types.String(strconv.FormatInt(int64(v), 10)), nil
+		}
+		f = float64(v)
+	case types.Uint:
+		if k == types.StringKind {
+			return types.String(strconv.FormatUint(uint64(v), 10)), nil
+		}
+		f = float64(v)
+	default:
+		return nil, fmt.Errorf("Cannot convert %s to %s", v.Kind(), k)
+	}
+
+	switch k {
+	case types.BoolKind:
+		return types.Bool(f != 0), nil
+	case types.NumberKind:
+		return types.Number(f), nil
+	case types.IntKind:
+		if f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+			return types.Int(int64(f)), nil
+		}
+	case types.UintKind:
+		if f == math.Trunc(f) && f >= 0 && f < math.MaxUint64 {
+			return types.Uint(uint64(f)), nil
+		}
+	}
+	return nil, fmt.Errorf("Cannot convert %s to %s", types.EncodedValue(v), k)
+}
+
+// StructFunc returns the replacement for a struct.
+type StructFunc func(s types.Struct) (types.Value, error)
+
+// RewriteStructs returns a copy of v in which every struct named name, or
+// every struct if name is AnyStruct, is replaced by the result of fn. The
+// fields of a struct are rewritten before the struct itself. Values reachable
+// through Refs are rewritten too and written to vrw. Parts of v whose type
+// can't contain a matching struct are left untouched.
+func RewriteStructs(vrw types.ValueReadWriter, v types.Value, name string, fn StructFunc) (types.Value, error) {
+	rw := structRewriter{vrw, name, fn, map[hash.Hash]types.Value{}, map[hash.Hash]bool{}}
+	return rw.rewrite(v)
+}
+
+type structRewriter struct {
+	vrw      types.ValueReadWriter
+	name     string
+	fn       StructFunc
+	refs     map[hash.Hash]types.Value
+	affected map[hash.Hash]bool
+}
+
+func (rw *structRewriter) rewrite(v types.Value) (nv types.Value, err error) {
+	if !rw.isAffected(types.TypeOf(v)) {
+		return v, nil
+	}
+
+	switch v := v.(type) {
+	case types.Struct:
+		changed := types.StructData{}
+		v.IterFields(func(name string, fv types.Value) bool {
+			var nfv types.Value
+			if nfv, err = rw.rewrite(fv); err != nil {
+				return true
+			}
+			if !nfv.Equals(fv) {
+				changed[name] = nfv
+			}
+			return false
+		})
+		if err != nil {
+			return nil, err
+		}
+		for name, nfv := range changed {
+			v = v.Set(name, nfv)
+		}
+		if rw.matches(v.Name()) {
+			return rw.fn(v)
+		}
+		return v, nil
+
+	case types.List:
+		le := v.Edit()
+		v.Iter(func(ev types.Value, i uint64) bool {
+			var nev types.Value
+			if nev, err = rw.rewrite(ev); err != nil {
+				return true
+			}
+			if !nev.Equals(ev) {
+				le.Set(i, nev)
+			}
+			return false
+		})
+		if err != nil {
+			return nil, err
+		}
+		return le.List(), nil
+
+	case types.Set:
+		se := v.Edit()
+		v.Iter(func(ev types.Value) bool {
+			var nev types.Value
+			if nev, err = rw.rewrite(ev); err != nil {
+				return true
+			}
+			if !nev.Equals(ev) {
+				se.Remove(ev).Insert(nev)
+			}
+			return false
+		})
+		if err != nil {
+			return nil, err
+		}
+		return se.Set(), nil
+
+	case types.Map:
+		me := v.Edit()
+		v.Iter(func(k, mv types.Value) bool {
+			var nk, nmv types.Value
+			if nk, err = rw.rewrite(k); err != nil {
+				return true
+			}
+			if nmv, err = rw.rewrite(mv); err != nil {
+				return true
+			}
+			if !nk.Equals(k) {
+				me.Remove(k)
+			}
+			if !nk.Equals(k) || !nmv.Equals(mv) {
+				me.Set(nk, nmv)
+			}
+			return false
+		})
+		if err != nil {
+			return nil, err
+		}
+		return me.Map(), nil
+
+	case types.Ref:
+		if r, ok := rw.refs[v.TargetHash()]; ok {
+			return r, nil
+		}
+		var r types.Value = v
+		target := v.TargetValue(rw.vrw)
+		nt, err := rw.rewrite(target)
+		if err != nil {
+			return nil, err
+		}
+		if !nt.Equals(target) {
+			r = rw.vrw.WriteValue(nt)
+		}
+		rw.refs[v.TargetHash()] = r
+		return r, nil
+	}
+	return v, nil
+}
+
+func (rw *structRewriter) matches(name string) bool {
+	return rw.name == AnyStruct || rw.name == name
+}
+
+// isAffected returns whether values of type t can contain a matching struct.
+func (rw *structRewriter) isAffected(t *types.Type) bool {
+	h := t.Hash()
+	if affected, ok := rw.affected[h]; ok {
+		return affected
+	}
+	affected := rw.hasMatchingStruct(t, map[*types.Type]bool{})
+	rw.affected[h] = affected
+	return affected
+}
+
+func (rw *structRewriter) hasMatchingStruct(t *types.Type, seen map[*types.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	found := false
+	switch desc := t.Desc.(type) {
+	case types.StructDesc:
+		found = rw.matches(desc.Name)
+		desc.IterFields(func(name string, ft *types.Type, optional bool) {
+			found = found || rw.hasMatchingStruct(ft, seen)
+		})
+	case types.CompoundDesc:
+		for _, et := range desc.ElemTypes {
+			found = found || rw.hasMatchingStruct(et, seen)
+		}
+	}
+	return found
+}
@@ -5,13 +5,25 @@
 package main
 
 import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/attic-labs/kingpin"
 	"github.com/dustin/go-humanize"
 
+	"github.com/attic-labs/noms/go/chunks"
 	"github.com/attic-labs/noms/go/d"
+	"github.com/attic-labs/noms/go/datas"
+	"github.com/attic-labs/noms/go/hash"
 	"github.com/attic-labs/noms/go/nbs/benchmarks/gen"
+	"github.com/attic-labs/noms/go/types"
 )
 
 const (
@@ -23,6 +35,9 @@ const (
 var (
 	genSize    = kingpin.Flag("gen", "MiB of data to generate and chunk").Default("1024").Uint64()
 	chunkInput = kingpin.Flag("chunk", "Treat arg as data file to chunk").Bool()
+	configs    = kingpin.Flag("config", "chunking config to compare, as hash:target[:min[:max]], e.g. gear:65536:16384:262144 - can be repeated").Strings()
+	records    = kingpin.Flag("records", "number of records in the Map to compare configs with").Default("100000").Int()
+	edits      = kingpin.Flag("edits", "number of random edits made to the data when comparing configs").Default("100").Int()
 	fileName   = kingpin.Arg("file", "filename").String()
 )
 
@@ -41,8 +56,134 @@ func main() {
 		defer fd.Close()
 	}
 
-	cm := gen.OpenOrBuildChunkMap(*fileName+".chunks", fd)
-	defer cm.Close()
+	if len(*configs) == 0 {
+		cm := gen.OpenOrBuildChunkMap(*fileName+".chunks", fd)
+		defer cm.Close()
+		return
+	}
+
+	data, err := ioutil.ReadAll(fd)
+	d.Chk.NoError(err)
+
+	fmt.Printf("%-48s %-5s %10s %10s %10s %10s %10s\n", "config", "value", "chunks", "avg size", "stored", "novel", "read")
+	for _, s := range *configs {
+		cfg, err := parseConfig(s)
+		d.CheckError(err)
+		compareBlob(cfg, data)
+		if *records > 0 {
+			compareMap(cfg, *records)
+		}
+	}
+}
+
+func parseConfig(s string) (cfg types.ChunkingConfig, err error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 4 {
+		return cfg, fmt.Errorf("Invalid chunking config: %s", s)
+	}
+	cfg.Hash = parts[0]
+	sizes := []*uint32{&cfg.TargetSize, &cfg.MinSize, &cfg.MaxSize}
+	for i, p := range parts[1:] {
+		n, err := strconv.ParseUint(p, 10, 32)
+		if err != nil {
+			return cfg, fmt.Errorf("Invalid chunking config: %s", s)
+		}
+		*sizes[i] = uint32(n)
+	}
+	return cfg, cfg.Validate()
+}
+
+// compareBlob reports how |data| is chunked as a Blob with |cfg|, how many
+// bytes are novel after some random edits, and how long reading it takes.
+func compareBlob(cfg types.ChunkingConfig, data []byte) {
+	r := rand.New(rand.NewSource(0))
+	edited := append([]byte{}, data...)
+	for i := 0; i < *edits && len(edited) > 0; i++ {
+		edited[r.Intn(len(edited))]++
+	}
+
+	compare(cfg, "blob", func(db datas.Database) types.Value {
+		return types.NewBlob(db, bytes.NewReader(data))
+	}, func(db datas.Database, v types.Value) types.Value {
+		return types.NewBlob(db, bytes.NewReader(edited))
+	}, func(v types.Value) {
+		v.(types.Blob).Copy(ioutil.Discard)
+	})
+}
+
+// compareMap is like compareBlob, for a Map of |n| small records.
+func compareMap(cfg types.ChunkingConfig, n int) {
+	record := func(i int) types.Value {
+		return types.NewStruct("Record", types.StructData{"id": types.Number(i), "name": types.String(fmt.Sprintf("record %d", i))})
+	}
+
+	compare(cfg, "map", func(db datas.Database) types.Value {
+		kvs := make([]types.Value, 0, 2*n)
+		for i := 0; i < n; i++ {
+			kvs = append(kvs, types.Number(i), record(i))
+		}
+		return types.NewMap(db, kvs...)
+	}, func(db datas.Database, v types.Value) types.Value {
+		r := rand.New(rand.NewSource(0))
+		me := v.(types.Map).Edit()
+		for i := 0; i < *edits; i++ {
+			me.Set(types.Number(r.Intn(n)), record(-i))
+		}
+		return me.Map()
+	}, func(v types.Value) {
+		v.(types.Map).IterAll(func(k, v types.Value) {})
+	})
+}
+
+func compare(cfg types.ChunkingConfig, kind string, build func(db datas.Database) types.Value, edit func(db datas.Database, v types.Value) types.Value, read func(v types.Value)) {
+	storage := &chunks.MemoryStorage{}
+	cs := &countingStore{ChunkStore: storage.NewView(), seen: hash.HashSet{}}
+	db := datas.NewDatabase(cs)
+	d.PanicIfError(datas.SetChunkingConfig(db, cfg))
+
+	ds := db.GetDataset("ds")
+	v := build(db)
+	ds, err := db.CommitValue(ds, v)
+	d.PanicIfError(err)
+	commit := ds.HeadRef().TargetHash()
+	numChunks, stored := cs.count()
+
+	_, err = db.CommitValue(ds, edit(db, v))
+	d.PanicIfError(err)
+	_, total := cs.count()
+	d.PanicIfError(db.Close())
+
+	// Read from a new Database, so nothing is cached.
+	db = datas.NewDatabase(storage.NewView())
+	defer db.Close()
+	start := time.Now()
+	read(db.ReadValue(commit).(types.Struct).Get(datas.ValueField))
+	elapsed := time.Since(start)
+
+	fmt.Printf("%-48s %-5s %10d %10s %10s %10s %10s\n", cfg, kind, numChunks,
+		humanize.Bytes(stored/uint64(numChunks)), humanize.Bytes(stored), humanize.Bytes(total-stored), elapsed.Round(time.Millisecond))
+}
+
+// countingStore counts the chunks and bytes that are put into it.
+type countingStore struct {
+	chunks.ChunkStore
+	mu    sync.Mutex
+	seen  hash.HashSet
+	bytes uint64
+}
+
+func (cs *countingStore) Put(c chunks.Chunk) {
+	cs.mu.Lock()
+	if !cs.seen.Has(c.Hash()) {
+		cs.seen.Insert(c.Hash())
+		cs.bytes += uint64(len(c.Data()))
+	}
+	cs.mu.Unlock()
+	cs.ChunkStore.Put(c)
+}
 
-	return
+func (cs *countingStore) count() (numChunks int, bytes uint64) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return len(cs.seen), cs.bytes
 }
@@ -56,6 +56,12 @@ func (suite *QueryGraphQLSuite) TestScalars() {
 	suite.assertQueryResult(types.Number(0.001), "{root}", `{"data":{"root":0.001}}`)
 	suite.assertQueryResult(types.Number(0.00000001), "{root}", `{"data":{"root":1e-08}}`)
 
+	suite.assertQueryResult(types.Int(-1<<62), "{root}", `{"data":{"root":"-4611686018427387904"}}`)
+	suite.assertQueryResult(types.Uint(1<<63), "{root}", `{"data":{"root":"9223372036854775808"}}`)
+	suite.assertQueryResult(types.Timestamp(1501801626123456789), "{root}", `{"data":{"root":"2017-08-03T23:07:06.123456789Z"}}`)
+	dec, _ := types.ParseDecimal("-0.10")
+	suite.assertQueryResult(dec, "{root}", `{"data":{"root":"-0.1"}}`)
+
 	suite.assertQueryResult(types.Bool(false), "{root}", `{"data":{"root":false}}`)
 	suite.assertQueryResult(types.Bool(true), "{root}", `{"data":{"root":true}}`)
 }
@@ -1113,6 +1119,22 @@ func (suite *QueryGraphQLSuite) TestSetWithComplexKeys() {
 		`{"data":{"root":{"values":[{"n": "c"}, {"n": "e"}]}}}`)
 }
 
+func (suite *QueryGraphQLSuite) TestMapWithTupleKeys() {
+	m := types.NewMap(suite.vs,
+		types.NewTuple(types.String("a"), types.Number(1)), types.Number(1),
+		types.NewTuple(types.String("a"), types.Number(2)), types.Number(2),
+		types.NewTuple(types.String("b"), types.Number(1)), types.Number(3),
+		types.NewTuple(types.String("c"), types.Number(1)), types.Number(4),
+	)
+
+	suite.assertQueryResult(m, `{root{keys{_0 _1}}}`,
+		`{"data":{"root":{"keys":[{"_0":"a","_1":1},{"_0":"a","_1":2},{"_0":"b","_1":1},{"_0":"c","_1":1}]}}}`)
+	suite.assertQueryResult(m, `{root{values(key: {_0: "a", _1: 2})}}`, `{"data":{"root":{"values":[2]}}}`)
+
+	// Tuples are ordered by value so a range covers the keys between the bounds.
+	suite.assertQueryResult(m, `{root{values(key: {_0: "a", _1: 2}, through: {_0: "b", _1: 1})}}`, `{"data":{"root":{"values":[2, 3]}}}`)
+}
+
 func (suite *QueryGraphQLSuite) TestInputToNomsValue() {
 	test := func(expected types.Value, val interface{}) {
 		suite.True(expected.Equals(InputToNomsValue(suite.vs, val, types.TypeOf(expected))))
@@ -1130,6 +1152,13 @@ func (suite *QueryGraphQLSuite) TestInputToNomsValue() {
 	test(types.String("hi"), "hi")
 	test(types.String(""), "")
 
+	test(types.Int(-1<<62), "-4611686018427387904")
+	test(types.Uint(1<<63), "9223372036854775808")
+	test(types.Timestamp(1501801626123456789), "2017-08-03T16:07:06.123456789-07:00")
+	dec, _ := types.ParseDecimal("12.5")
+	test(dec, "12.50")
+	test(types.NewTuple(types.String("a"), types.Number(1)), map[string]interface{}{"_0": "a", "_1": float64(1)})
+
 	test(types.NewList(suite.vs, types.Number(42)), []interface{}{float64(42)})
 	test(types.NewList(suite.vs, types.Number(1), types.Number(2)), []interface{}{float64(1), float64(2)})
 
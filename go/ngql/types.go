@@ -8,8 +8,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
-
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/attic-labs/graphql"
 	"github.com/attic-labs/noms/go/d"
@@ -158,6 +159,15 @@ func (tc *TypeConverter) nomsTypeToGraphQLType(nomsType *types.Type, boxedIfScal
 			gqlType = tc.scalarToValue(nomsType, gqlType)
 		}
 
+	case types.IntKind, types.UintKind, types.TimestampKind, types.DecimalKind:
+		// GraphQL Int is only 32 bits so 64 bit integers are passed as
+		// decimal strings to not lose precision, as are Decimals. Timestamps
+		// are passed as RFC 3339 strings.
+		gqlType = graphql.String
+		if boxedIfScalar {
+			gqlType = tc.scalarToValue(nomsType, gqlType)
+		}
+
 	case types.BoolKind:
 		gqlType = graphql.Boolean
 		if boxedIfScalar {
@@ -167,6 +177,9 @@ func (tc *TypeConverter) nomsTypeToGraphQLType(nomsType *types.Type, boxedIfScal
 	case types.StructKind:
 		gqlType = tc.structToGQLObject(nomsType)
 
+	case types.TupleKind:
+		gqlType = tc.tupleToGQLObject(nomsType)
+
 	case types.ListKind, types.SetKind:
 		gqlType = tc.listAndSetToGraphQLObject(nomsType)
 
@@ -220,7 +233,7 @@ func (tc *TypeConverter) nomsTypeToGraphQLInputType(nomsType *types.Type) (graph
 	case types.NumberKind:
 		gqlType = graphql.Float
 
-	case types.StringKind:
+	case types.StringKind, types.IntKind, types.UintKind, types.TimestampKind, types.DecimalKind:
 		gqlType = graphql.String
 
 	case types.BoolKind:
@@ -229,6 +242,9 @@ func (tc *TypeConverter) nomsTypeToGraphQLInputType(nomsType *types.Type) (graph
 	case types.StructKind:
 		gqlType, err = tc.structToGQLInputObject(nomsType)
 
+	case types.TupleKind:
+		gqlType, err = tc.tupleToGQLInputObject(nomsType)
+
 	case types.ListKind, types.SetKind:
 		gqlType, err = tc.listAndSetToGraphQLInputObject(nomsType)
 
@@ -339,6 +355,40 @@ func (tc *TypeConverter) structToGQLObject(nomsType *types.Type) *graphql.Object
 	})
 }
 
+// tupleFieldName returns the name of the field that holds the element at
+// |idx| of a Tuple. GraphQL field names cannot start with a digit.
+func tupleFieldName(idx int) string {
+	return fmt.Sprintf("_%d", idx)
+}
+
+func (tc *TypeConverter) tupleToGQLObject(nomsType *types.Type) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: tc.getTypeName(nomsType),
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			fields := graphql.Fields{
+				"hash": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.String),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Source.(types.Tuple).Hash().String(), nil
+					},
+				},
+			}
+
+			for i, nomsElemType := range nomsType.Desc.(types.CompoundDesc).ElemTypes {
+				idx := uint64(i)
+				fields[tupleFieldName(i)] = &graphql.Field{
+					Type: graphql.NewNonNull(tc.nomsTypeToGraphQLType(nomsElemType, false)),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return MaybeGetScalar(p.Source.(types.Tuple).Get(idx)), nil
+					},
+				}
+			}
+
+			return fields
+		}),
+	})
+}
+
 func (tc *TypeConverter) listAndSetToGraphQLInputObject(nomsType *types.Type) (graphql.Input, error) {
 	nomsValueType := nomsType.Desc.(types.CompoundDesc).ElemTypes[0]
 	elemType, err := tc.nomsTypeToGraphQLInputType(nomsValueType)
@@ -399,6 +449,34 @@ func (tc *TypeConverter) structToGQLInputObject(nomsType *types.Type) (graphql.I
 	return rv, nil
 }
 
+func (tc *TypeConverter) tupleToGQLInputObject(nomsType *types.Type) (graphql.Input, error) {
+	var err error
+	rv := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: tc.getInputTypeName(nomsType),
+		Fields: graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap {
+			elemTypes := nomsType.Desc.(types.CompoundDesc).ElemTypes
+			fields := make(graphql.InputObjectConfigFieldMap, len(elemTypes))
+
+			for i, nomsElemType := range elemTypes {
+				var elemType graphql.Input
+				elemType, err = tc.nomsTypeToGraphQLInputType(nomsElemType)
+				if err != nil {
+					break
+				}
+				fields[tupleFieldName(i)] = &graphql.InputObjectFieldConfig{
+					Type: graphql.NewNonNull(elemType),
+				}
+			}
+
+			return fields
+		}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rv, nil
+}
+
 var listArgs = graphql.FieldConfigArgument{
 	atKey:    &graphql.ArgumentConfig{Type: graphql.Int},
 	countKey: &graphql.ArgumentConfig{Type: graphql.Int},
@@ -717,6 +795,18 @@ func getTypeName(nomsType *types.Type, suffix string) string {
 	case types.NumberKind:
 		return "Number"
 
+	case types.IntKind:
+		return "Int"
+
+	case types.UintKind:
+		return "Uint"
+
+	case types.TimestampKind:
+		return "Timestamp"
+
+	case types.DecimalKind:
+		return "Decimal"
+
 	case types.StringKind:
 		return "String"
 
@@ -759,6 +849,17 @@ func getTypeName(nomsType *types.Type, suffix string) string {
 		// GraphQL type names must be globally unique.
 		return fmt.Sprintf("%s%s_%s", nomsType.Desc.(types.StructDesc).Name, suffix, nomsType.Hash().String()[:6])
 
+	case types.TupleKind:
+		elemTypes := nomsType.Desc.(types.CompoundDesc).ElemTypes
+		if len(elemTypes) == 0 {
+			return "EmptyTuple" + suffix
+		}
+		names := make([]string, len(elemTypes))
+		for i, elemType := range elemTypes {
+			names[i] = GetTypeName(elemType)
+		}
+		return fmt.Sprintf("%sTuple%s", strings.Join(names, "And"), suffix)
+
 	case types.TypeKind:
 		// GraphQL Name cannot start with a number.
 		// TODO: https://github.com/attic-labs/noms/issues/3155
@@ -961,6 +1062,14 @@ func MaybeGetScalar(v types.Value) interface{} {
 		return bool(v.(types.Bool))
 	case types.Number:
 		return float64(v.(types.Number))
+	case types.Int:
+		return strconv.FormatInt(int64(v.(types.Int)), 10)
+	case types.Uint:
+		return strconv.FormatUint(uint64(v.(types.Uint)), 10)
+	case types.Timestamp:
+		return v.(types.Timestamp).Time().Format(time.RFC3339Nano)
+	case types.Decimal:
+		return v.(types.Decimal).String()
 	case types.String:
 		return string(v.(types.String))
 	case *types.Type, types.Blob:
@@ -982,6 +1091,24 @@ func InputToNomsValue(vrw types.ValueReadWriter, arg interface{}, nomsType *type
 			return types.Number(i)
 		}
 		return types.Number(arg.(float64))
+	case types.IntKind:
+		i, err := strconv.ParseInt(arg.(string), 10, 64)
+		d.PanicIfError(err)
+		return types.Int(i)
+	case types.UintKind:
+		u, err := strconv.ParseUint(arg.(string), 10, 64)
+		d.PanicIfError(err)
+		return types.Uint(u)
+	case types.TimestampKind:
+		t, err := time.Parse(time.RFC3339Nano, arg.(string))
+		d.PanicIfError(err)
+		ts, err := types.NewTimestamp(t)
+		d.PanicIfError(err)
+		return ts
+	case types.DecimalKind:
+		dec, err := types.ParseDecimal(arg.(string))
+		d.PanicIfError(err)
+		return dec
 	case types.StringKind:
 		return types.String(arg.(string))
 	case types.ListKind, types.SetKind:
@@ -1017,6 +1144,14 @@ func InputToNomsValue(vrw types.ValueReadWriter, arg interface{}, nomsType *type
 			}
 		})
 		return types.NewStruct(desc.Name, data)
+	case types.TupleKind:
+		elemTypes := nomsType.Desc.(types.CompoundDesc).ElemTypes
+		m := arg.(map[string]interface{})
+		vs := make(types.ValueSlice, len(elemTypes))
+		for i, t := range elemTypes {
+			vs[i] = InputToNomsValue(vrw, m[tupleFieldName(i)], t)
+		}
+		return types.NewTuple(vs...)
 	}
 	panic("not yet implemented")
 }
@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"strings"
 	"text/scanner"
+	"time"
 
 	"github.com/attic-labs/noms/go/d"
 	"github.com/attic-labs/noms/go/types"
@@ -86,6 +87,10 @@ func (p *Parser) ensureAtEnd() {
 //   `Blob`
 //   `Bool`
 //   `Number`
+//   `Int`
+//   `Uint`
+//   `Timestamp`
+//   `Decimal`
 //   `String`
 //   `Type`
 //   `Value`
@@ -95,6 +100,7 @@ func (p *Parser) ensureAtEnd() {
 //   RefType
 //   SetType
 //   StructType
+//   TupleType
 //
 // CycleType :
 //   `Cycle` `<` StructName `>`
@@ -126,6 +132,13 @@ func (p *Parser) ensureAtEnd() {
 //
 // StructFieldName :
 //   Ident
+//
+// TupleType :
+//   `Tuple` `<` TupleElemTypes? `>`
+//
+// TupleElemTypes :
+//   Type
+//   Type `,` TupleElemTypes
 
 func (p *Parser) parseType() *types.Type {
 	tok := p.lex.eat(scanner.Ident)
@@ -165,6 +178,14 @@ func (p *Parser) parseSingleTypeWithToken(tok rune, tokenText string) *types.Typ
 		return types.BlobType
 	case "Number":
 		return types.NumberType
+	case "Int":
+		return types.IntType
+	case "Uint":
+		return types.UintType
+	case "Timestamp":
+		return types.TimestampType
+	case "Decimal":
+		return types.DecimalType
 	case "String":
 		return types.StringType
 	case "Type":
@@ -186,6 +207,8 @@ func (p *Parser) parseSingleTypeWithToken(tok rune, tokenText string) *types.Typ
 		return types.MakeRefType(elemType)
 	case "Cycle":
 		return p.parseCycleType()
+	case "Tuple":
+		return p.parseTupleType()
 	}
 
 	p.lex.unexpectedToken(tok)
@@ -244,6 +267,19 @@ func (p *Parser) parseCycleType() *types.Type {
 	return types.MakeCycleType(name)
 }
 
+func (p *Parser) parseTupleType() *types.Type {
+	p.lex.eat('<')
+	elemTypes := []*types.Type{}
+	for p.lex.peek() != '>' {
+		elemTypes = append(elemTypes, p.parseType())
+		if !p.lex.eatIf(',') {
+			break
+		}
+	}
+	p.lex.eat('>')
+	return types.MakeTupleType(elemTypes...)
+}
+
 func (p *Parser) parseMapType() *types.Type {
 	var keyType, valueType *types.Type
 	p.lex.eat('<')
@@ -264,11 +300,16 @@ func (p *Parser) parseMapType() *types.Type {
 //   Type
 //   Bool
 //   Number
+//   Int
+//   Uint
+//   Timestamp
+//   Decimal
 //   String
 //   List
 //   Set
 //   Map
 //   Struct
+//   Tuple
 //
 // Bool :
 //   `true`
@@ -277,6 +318,18 @@ func (p *Parser) parseMapType() *types.Type {
 // Number :
 //   ...
 //
+// Int :
+//   `int` `(` `-`? ... `)`
+//
+// Uint :
+//   `uint` `(` ... `)`
+//
+// Timestamp :
+//   `timestamp` `(` String `)`, where the string is in RFC 3339 format
+//
+// Decimal :
+//   `decimal` `(` `-`? ... `)`
+//
 // String :
 //   ...
 //
@@ -309,6 +362,9 @@ func (p *Parser) parseMapType() *types.Type {
 //
 // StructField :
 //   StructFieldName `:` Value
+//
+// Tuple :
+//   `tuple` `(` Values? `)`
 func (p *Parser) parseValue() types.Value {
 	tok := p.lex.next()
 	switch tok {
@@ -326,6 +382,16 @@ func (p *Parser) parseValue() types.Value {
 			return p.parseStruct()
 		case "blob":
 			return p.parseBlob()
+		case "int":
+			return p.parseInt()
+		case "uint":
+			return p.parseUint()
+		case "timestamp":
+			return p.parseTimestamp()
+		case "decimal":
+			return p.parseDecimal()
+		case "tuple":
+			return p.parseTuple()
 		default:
 			return p.parseTypeWithToken(tok, tokenText)
 		}
@@ -365,6 +431,75 @@ func (p *Parser) parseFloat() types.Number {
 	return types.Number(f)
 }
 
+func (p *Parser) parseInt() types.Int {
+	// already swallowed 'int'
+	p.lex.eat('(')
+	s := ""
+	if p.lex.eatIf('-') {
+		s = "-"
+	}
+	p.lex.eat(scanner.Int)
+	s += p.lex.tokenText()
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		raiseSyntaxError(fmt.Sprintf("Invalid int %s", s), p.lex.pos())
+	}
+	p.lex.eat(')')
+	return types.Int(i)
+}
+
+func (p *Parser) parseUint() types.Uint {
+	// already swallowed 'uint'
+	p.lex.eat('(')
+	p.lex.eat(scanner.Int)
+	s := p.lex.tokenText()
+	u, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		raiseSyntaxError(fmt.Sprintf("Invalid uint %s", s), p.lex.pos())
+	}
+	p.lex.eat(')')
+	return types.Uint(u)
+}
+
+func (p *Parser) parseTimestamp() types.Timestamp {
+	// already swallowed 'timestamp'
+	p.lex.eat('(')
+	p.lex.eat(scanner.String)
+	s, err := strconv.Unquote(p.lex.tokenText())
+	if err != nil {
+		raiseSyntaxError(fmt.Sprintf("Invalid string %s", p.lex.tokenText()), p.lex.pos())
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		raiseSyntaxError(fmt.Sprintf("Invalid timestamp %s", s), p.lex.pos())
+	}
+	ts, err := types.NewTimestamp(t)
+	if err != nil {
+		raiseSyntaxError(fmt.Sprintf("Timestamp %s is out of range", s), p.lex.pos())
+	}
+	p.lex.eat(')')
+	return ts
+}
+
+func (p *Parser) parseDecimal() types.Decimal {
+	// already swallowed 'decimal'
+	p.lex.eat('(')
+	s := ""
+	if p.lex.eatIf('-') {
+		s = "-"
+	}
+	if !p.lex.eatIf(scanner.Float) {
+		p.lex.eat(scanner.Int)
+	}
+	s += p.lex.tokenText()
+	d, err := types.ParseDecimal(s)
+	if err != nil {
+		raiseSyntaxError(fmt.Sprintf("Invalid decimal %s", s), p.lex.pos())
+	}
+	p.lex.eat(')')
+	return d
+}
+
 func (p *Parser) parseList() types.List {
 	// already swallowed '['
 	le := types.NewList(p.vrw).Edit()
@@ -383,6 +518,24 @@ func (p *Parser) parseList() types.List {
 	return le.List()
 }
 
+func (p *Parser) parseTuple() types.Tuple {
+	// already swallowed 'tuple'
+	p.lex.eat('(')
+	vs := []types.Value{}
+
+	for p.lex.peek() != ')' {
+		vs = append(vs, p.parseValue())
+
+		if p.lex.eatIf(',') {
+			continue
+		}
+
+		break
+	}
+	p.lex.eat(')')
+	return types.NewTuple(vs...)
+}
+
 func (p *Parser) parseSet() types.Set {
 	// already swallowed 'set'
 	p.lex.eat('{')
@@ -424,9 +577,9 @@ func (p *Parser) parseMap() types.Map {
 	return me.Map()
 }
 
-func (p *Parser) blobString(s string) []byte {
+func (p *Parser) blobString(s string, pos scanner.Position) []byte {
 	raise := func() {
-		raiseSyntaxError(fmt.Sprintf("Invalid blob \"%s\"", s), p.lex.pos())
+		raiseSyntaxError(fmt.Sprintf("Invalid blob \"%s\"", s), pos)
 	}
 
 	if len(s)%2 != 0 {
@@ -452,9 +605,23 @@ func (p *Parser) parseBlob() types.Blob {
 	for p.lex.peek() != '}' {
 		tok := p.lex.next()
 		switch tok {
-		case scanner.Ident, scanner.Int:
-			s := p.lex.tokenText()
-			buff.Write(p.blobString(s))
+		case scanner.Ident, scanner.Int, scanner.Float:
+			// The scanner splits hex such as 68656c6c6f or 1e0a into several
+			// tokens, so join the ones that aren't separated by whitespace or
+			// comments.
+			s, pos := p.lex.tokenText(), p.lex.pos()
+			end := p.lex.scanner.Position.Offset + len(s)
+			for {
+				next := p.lex.peek()
+				if (next != scanner.Ident && next != scanner.Int && next != scanner.Float) || p.lex.scanner.Position.Offset != end {
+					break
+				}
+				p.lex.next()
+				t := p.lex.tokenText()
+				s, pos = s+t, p.lex.pos()
+				end = p.lex.scanner.Position.Offset + len(t)
+			}
+			buff.Write(p.blobString(s, pos))
 		default:
 			p.lex.unexpectedToken(tok)
 		}
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/attic-labs/noms/go/chunks"
 	"github.com/attic-labs/noms/go/types"
@@ -56,6 +57,10 @@ func TestSimpleTypes(t *testing.T) {
 	assertParseType(t, "Blob", types.BlobType)
 	assertParseType(t, "Bool", types.BoolType)
 	assertParseType(t, "Number", types.NumberType)
+	assertParseType(t, "Int", types.IntType)
+	assertParseType(t, "Uint", types.UintType)
+	assertParseType(t, "Timestamp", types.TimestampType)
+	assertParseType(t, "Decimal", types.DecimalType)
 	assertParseType(t, "String", types.StringType)
 	assertParseType(t, "Value", types.ValueType)
 	assertParseType(t, "Type", types.TypeType)
@@ -107,6 +112,11 @@ func TestCompoundTypes(t *testing.T) {
 	assertParseError(t, "Cycle<", `Unexpected token EOF, expected Ident, example:1:7`)
 	assertParseError(t, "Cycle", `Unexpected token EOF, expected "<", example:1:6`)
 
+	assertParseType(t, "Tuple<>", types.MakeTupleType())
+	assertParseType(t, "Tuple<String, Number | Bool>", types.MakeTupleType(types.StringType, types.MakeUnionType(types.NumberType, types.BoolType)))
+	assertParseError(t, "Tuple<String", `Unexpected token EOF, expected ">", example:1:13`)
+	assertParseError(t, "Tuple", `Unexpected token EOF, expected "<", example:1:6`)
+
 	assertParseType(t, "Map<>", types.MakeMapType(types.MakeUnionType(), types.MakeUnionType()))
 	assertParseType(t, "Map<Bool, String>", types.MakeMapType(types.BoolType, types.StringType))
 	assertParseError(t, "Map<Bool,>", `Unexpected token ">", expected Ident, example:1:11`)
@@ -256,6 +266,30 @@ func TestValuePrimitives(t *testing.T) {
 	assertParse(t, vs, "-1e-1", types.Number(-1e-1))
 	assertParse(t, vs, "-1e+1", types.Number(-1e+1))
 
+	assertParse(t, vs, "int(0)", types.Int(0))
+	assertParse(t, vs, "int(-9223372036854775808)", types.Int(-9223372036854775808))
+	assertParse(t, vs, "int(9007199254740993)", types.Int(9007199254740993))
+	assertParse(t, vs, "uint(18446744073709551615)", types.Uint(18446744073709551615))
+	assertParseError(t, "int(1.5)", `Unexpected token Float, expected Int, example:1:8`)
+	assertParseError(t, "uint(-1)", `Unexpected token "-", expected Int, example:1:7`)
+	assertParseError(t, "uint(18446744073709551616)", "Invalid uint 18446744073709551616, example:1:26")
+
+	assertParse(t, vs, `timestamp("2017-08-03T16:07:06.123456789-07:00")`, types.Timestamp(time.Date(2017, 8, 3, 23, 7, 6, 123456789, time.UTC).UnixNano()))
+	assertParse(t, vs, `timestamp("1970-01-01T00:00:00Z")`, types.Timestamp(0))
+	assertParseError(t, `timestamp("yesterday")`, "Invalid timestamp yesterday, example:1:22")
+	assertParseError(t, `timestamp("0001-01-01T00:00:00Z")`, "Timestamp 0001-01-01T00:00:00Z is out of range, example:1:33")
+
+	mustParseDecimal := func(s string) types.Decimal {
+		d, err := types.ParseDecimal(s)
+		assert.NoError(t, err)
+		return d
+	}
+	assertParse(t, vs, "decimal(0)", types.Decimal{})
+	assertParse(t, vs, "decimal(-12.50)", mustParseDecimal("-12.5"))
+	assertParse(t, vs, "decimal(123456789012345678901234567890.1)", mustParseDecimal("123456789012345678901234567890.1"))
+	assertParse(t, vs, "decimal(1e-3)", mustParseDecimal("0.001"))
+	assertParseError(t, `decimal("1")`, `Unexpected token String, expected Int, example:1:12`)
+
 	assertParse(t, vs, `"a"`, types.String("a"))
 	assertParse(t, vs, `""`, types.String(""))
 	assertParse(t, vs, `"\""`, types.String("\""))
@@ -327,6 +361,16 @@ func TestValueSet(t *testing.T) {
         }`, types.NewSet(vs, types.Number(42), types.BoolType))
 }
 
+func TestValueTuple(t *testing.T) {
+	vs := newTestValueStore()
+	assertParse(t, vs, "tuple()", types.NewTuple())
+	assertParse(t, vs, `tuple("us-east", int(42))`, types.NewTuple(types.String("us-east"), types.Int(42)))
+	assertParse(t, vs, "tuple(tuple(1), [])", types.NewTuple(types.NewTuple(types.Number(1)), types.NewList(vs)))
+
+	assertParseError(t, "tuple", "Unexpected token EOF, expected \"(\", example:1:6")
+	assertParseError(t, "tuple(42", "Unexpected token EOF, expected \")\", example:1:9")
+}
+
 func TestValueMap(t *testing.T) {
 	vs := newTestValueStore()
 	assertParse(t, vs, "map {}", types.NewMap(vs))
@@ -401,6 +445,8 @@ func TestValueBlob(t *testing.T) {
 	test("blob {0000ff}", 0, 0, 0xff)
 	test("blob {00 00 ff}", 0, 0, 0xff)
 	test("blob { 00\n00\nff }", 0, 0, 0xff)
+	test("blob {68656c6c6f}", 0x68, 0x65, 0x6c, 0x6c, 0x6f)
+	test("blob {1e0a 1e}", 0x1e, 0x0a, 0x1e)
 	test("blob { ffffffff ffffffff ffffffff ffffffff}",
 		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
 		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
@@ -419,6 +465,7 @@ func TestValueBlob(t *testing.T) {
 	assertParseError(t, "blob {0}", "Invalid blob \"0\", example:1:8")
 	assertParseError(t, "blob {00 0}", "Invalid blob \"0\", example:1:11")
 	assertParseError(t, "blob {ff 0 0}", "Invalid blob \"0\", example:1:11")
+	assertParseError(t, "blob {6865c}", "Invalid blob \"6865c\", example:1:12")
 
 }
 
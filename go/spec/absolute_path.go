@@ -82,26 +82,38 @@ func NewAbsolutePath(str string) (AbsolutePath, error) {
 	return AbsolutePath{Hash: h, Dataset: dataset, Path: path}, nil
 }
 
-// Resolve returns the Value reachable by 'p' in 'db'.
+// Resolve returns the Value reachable by 'p' in 'db'. If p.Path is
+// multi-valued, e.g. `.rows[*].name`, Resolve returns the first value it
+// matches; use ResolveAll to get all of them.
 func (p AbsolutePath) Resolve(db datas.Database) (val types.Value) {
-	if len(p.Dataset) > 0 {
-		var ok bool
-		ds := db.GetDataset(p.Dataset)
-		if val, ok = ds.MaybeHead(); !ok {
-			val = nil
-		}
-	} else if !p.Hash.IsEmpty() {
-		val = db.ReadValue(p.Hash)
-	} else {
-		panic("Unreachable")
-	}
-
+	val = p.root(db)
 	if val != nil && p.Path != nil {
 		val = p.Path.Resolve(val, db)
 	}
 	return
 }
 
+// ResolveAll calls cb with each Value that 'p' matches in 'db', and the
+// concrete path to it relative to the dataset head or hash, until cb returns
+// true. Matches are not collected, so nothing is written to 'db'.
+func (p AbsolutePath) ResolveAll(db datas.Database, cb func(p types.Path, v types.Value) (stop bool)) {
+	if val := p.root(db); val != nil {
+		p.Path.ResolveAll(val, db, cb)
+	}
+}
+
+func (p AbsolutePath) root(db datas.Database) types.Value {
+	if len(p.Dataset) > 0 {
+		if head, ok := db.GetDataset(p.Dataset).MaybeHead(); ok {
+			return head
+		}
+		return nil
+	} else if !p.Hash.IsEmpty() {
+		return db.ReadValue(p.Hash)
+	}
+	panic("Unreachable")
+}
+
 func (p AbsolutePath) IsEmpty() bool {
 	return p.Dataset == "" && p.Hash.IsEmpty()
 }
@@ -72,13 +72,35 @@ func TestAbsolutePaths(t *testing.T) {
 	resolvesTo(s1, "#"+s1.Hash().String())
 	resolvesTo(s0, "#"+list.Hash().String()+"[0]")
 	resolvesTo(s1, "#"+list.Hash().String()+"[1]")
+	resolvesTo(s0, "ds.value[*]")
+	resolvesTo(nil, "ds.parents[*]")
 
 	resolvesTo(nil, "foo")
 	resolvesTo(nil, "foo.parents")
 	resolvesTo(nil, "foo.value")
 	resolvesTo(nil, "foo.value[0]")
+	resolvesTo(nil, "foo.value[*]")
 	resolvesTo(nil, "#"+types.String("baz").Hash().String())
 	resolvesTo(nil, "#"+types.String("baz").Hash().String()+"[0]")
+
+	resolvesAllTo := func(expPaths []string, exp types.ValueSlice, str string) {
+		p, err := NewAbsolutePath(str)
+		assert.NoError(err)
+		paths, vals := []string{}, types.ValueSlice{}
+		p.ResolveAll(db, func(p types.Path, v types.Value) bool {
+			paths = append(paths, p.String())
+			vals = append(vals, v)
+			return false
+		})
+		assert.Equal(expPaths, paths, str)
+		assert.True(types.NewTuple(exp...).Equals(types.NewTuple(vals...)), str)
+	}
+	resolvesAllTo([]string{".value[0]", ".value[1]"}, types.ValueSlice{s0, s1}, "ds.value[*]")
+	resolvesAllTo([]string{".value[1]"}, types.ValueSlice{s1}, "ds.value[1:]")
+	resolvesAllTo([]string{".value[0]"}, types.ValueSlice{s0}, `ds.value[?(@ == "foo")]`)
+	resolvesAllTo([]string{"[1]"}, types.ValueSlice{s1}, "#"+list.Hash().String()+"[1]")
+	resolvesAllTo([]string{}, types.ValueSlice{}, "ds.parents[*]")
+	resolvesAllTo([]string{}, types.ValueSlice{}, "foo.value[*]")
 }
 
 func TestReadAbsolutePaths(t *testing.T) {
@@ -0,0 +1,170 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package types
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+	"sync"
+
+	"github.com/kch42/buzhash"
+)
+
+// ChunkingConfig controls where the prolly trees that back Blobs, Lists, Maps
+// and Sets are split into chunks. The same value chunked with different
+// configs has different chunks, and so a different hash, which is why a
+// Database records the config that all of its values are written with (see
+// datas.SetChunkingConfig).
+type ChunkingConfig struct {
+	// TargetSize is the average size in bytes that chunks are aimed at. It must
+	// be a power of 2.
+	TargetSize uint32
+
+	// MinSize and MaxSize bound the size of a chunk. Boundaries found before
+	// MinSize bytes are ignored, and a boundary is forced after MaxSize bytes.
+	// A MaxSize of 0 means chunks are only bounded by the 16MB limit on the
+	// encoded values of a List, Map or Set chunk.
+	MinSize, MaxSize uint32
+
+	// Window is the number of bytes the rolling hash is computed over. 0 means
+	// 67, which is what DefaultChunkingConfig uses.
+	Window uint32
+
+	// Hash is the name of the RollingHash used to find boundaries, see
+	// RegisterRollingHash. "" means "buzhash".
+	Hash string
+}
+
+// DefaultChunkingConfig returns the config that values are chunked with unless
+// their ValueStore is configured otherwise.
+func DefaultChunkingConfig() ChunkingConfig {
+	return ChunkingConfig{TargetSize: defaultChunkPattern + 1, Window: chunkWindow, Hash: buzHashName}
+}
+
+func (c ChunkingConfig) withDefaults() ChunkingConfig {
+	if c.Window == 0 {
+		c.Window = chunkWindow
+	}
+	if c.Hash == "" {
+		c.Hash = buzHashName
+	}
+	return c
+}
+
+// Validate returns an error if c can't be used to chunk values.
+func (c ChunkingConfig) Validate() error {
+	c = c.withDefaults()
+	if c.TargetSize < 2 || c.TargetSize&(c.TargetSize-1) != 0 {
+		return fmt.Errorf("Chunk target size must be a power of 2 greater than 1, not %d", c.TargetSize)
+	}
+	if c.MinSize > c.TargetSize || (c.MaxSize != 0 && c.TargetSize > c.MaxSize) {
+		return fmt.Errorf("Chunk sizes must satisfy min <= target <= max, not %d, %d, %d", c.MinSize, c.TargetSize, c.MaxSize)
+	}
+	if _, ok := rollingHashFn(c.Hash); !ok {
+		return fmt.Errorf("Unknown rolling hash %s, must be one of %v", c.Hash, RollingHashes())
+	}
+	return nil
+}
+
+func (c ChunkingConfig) String() string {
+	c = c.withDefaults()
+	return fmt.Sprintf("%s target=%d min=%d max=%d window=%d", c.Hash, c.TargetSize, c.MinSize, c.MaxSize, c.Window)
+}
+
+// chunkingConfigurer is implemented by ValueReadWriters, e.g. ValueStore,
+// that chunk their values with a ChunkingConfig other than the default.
+type chunkingConfigurer interface {
+	ChunkingConfig() ChunkingConfig
+}
+
+func chunkingConfigFor(vrw ValueReadWriter) ChunkingConfig {
+	if cc, ok := vrw.(chunkingConfigurer); ok {
+		return cc.ChunkingConfig()
+	}
+	return defaultChunkingConfig()
+}
+
+// RollingHash computes a hash of the last few bytes written to it, which
+// rollingValueHasher uses to decide where chunks end.
+type RollingHash interface {
+	// HashByte adds b to the hash and returns the hash of the current window.
+	HashByte(b byte) uint32
+}
+
+// NewRollingHashFn returns a new RollingHash over a window of |window| bytes.
+type NewRollingHashFn func(window uint32) RollingHash
+
+const (
+	buzHashName  = "buzhash"
+	gearHashName = "gear"
+)
+
+var (
+	rollingHashesMu = &sync.Mutex{}
+	rollingHashes   = map[string]NewRollingHashFn{
+		buzHashName: func(window uint32) RollingHash {
+			return buzhash.NewBuzHash(window)
+		},
+		gearHashName: func(window uint32) RollingHash {
+			return &gearHash{}
+		},
+	}
+)
+
+// RegisterRollingHash makes the RollingHash created by |fn| available to
+// ChunkingConfigs as |name|. It panics if |name| is already registered.
+func RegisterRollingHash(name string, fn NewRollingHashFn) {
+	rollingHashesMu.Lock()
+	defer rollingHashesMu.Unlock()
+	if _, ok := rollingHashes[name]; ok {
+		panic(fmt.Errorf("Rolling hash %s is already registered", name))
+	}
+	rollingHashes[name] = fn
+}
+
+// RollingHashes returns the names of the registered RollingHashes.
+func RollingHashes() []string {
+	rollingHashesMu.Lock()
+	defer rollingHashesMu.Unlock()
+	names := make([]string, 0, len(rollingHashes))
+	for name := range rollingHashes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func rollingHashFn(name string) (NewRollingHashFn, bool) {
+	rollingHashesMu.Lock()
+	defer rollingHashesMu.Unlock()
+	fn, ok := rollingHashes[name]
+	return fn, ok
+}
+
+// gearHash is the hash used by FastCDC. It's cheaper than buzhash, but its
+// window is fixed at 32 bytes.
+type gearHash struct {
+	h uint32
+}
+
+var gearTable = func() (t [256]uint32) {
+	// xorshift32 with a fixed seed, so the table is the same everywhere.
+	x := uint32(2463534242)
+	for i := range t {
+		x ^= x << 13
+		x ^= x >> 17
+		x ^= x << 5
+		t[i] = x
+	}
+	return
+}()
+
+func (g *gearHash) HashByte(b byte) uint32 {
+	g.h = g.h<<1 + gearTable[b]
+	// The low bits of h only depend on the last few bytes, but boundaries are
+	// found by looking at the low bits of the sum.
+	return bits.Reverse32(g.h)
+}
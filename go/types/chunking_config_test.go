@@ -0,0 +1,109 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package types
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunkingConfigValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(DefaultChunkingConfig().Validate())
+	assert.NoError(ChunkingConfig{TargetSize: 1024}.Validate())
+	assert.NoError(ChunkingConfig{TargetSize: 1024, MinSize: 256, MaxSize: 4096, Window: 32, Hash: "gear"}.Validate())
+
+	assert.Error(ChunkingConfig{}.Validate())
+	assert.Error(ChunkingConfig{TargetSize: 1000}.Validate())
+	assert.Error(ChunkingConfig{TargetSize: 1024, MinSize: 2048}.Validate())
+	assert.Error(ChunkingConfig{TargetSize: 1024, MaxSize: 512}.Validate())
+	assert.Error(ChunkingConfig{TargetSize: 1024, Hash: "nope"}.Validate())
+
+	vs := newTestValueStore()
+	assert.Error(vs.SetChunkingConfig(ChunkingConfig{TargetSize: 3}))
+	assert.Equal(defaultChunkingConfig(), vs.ChunkingConfig())
+}
+
+func TestRegisterRollingHash(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([]string{"buzhash", "gear"}, RollingHashes())
+	assert.Panics(func() {
+		RegisterRollingHash("gear", func(window uint32) RollingHash { return &gearHash{} })
+	})
+}
+
+func leafLengths(seq sequence) (lens []int) {
+	if seq.isLeaf() {
+		return []int{seq.seqLen()}
+	}
+	for i := 0; i < seq.seqLen(); i++ {
+		lens = append(lens, leafLengths(seq.getChildSequence(i))...)
+	}
+	return
+}
+
+func TestChunkingConfigChangesChunks(t *testing.T) {
+	assert := assert.New(t)
+
+	newList := func(cfg *ChunkingConfig) List {
+		vs := newTestValueStore()
+		if cfg != nil {
+			assert.NoError(vs.SetChunkingConfig(*cfg))
+		}
+		return NewList(vs, generateNumbersAsValues(10000)...)
+	}
+
+	def := newList(nil)
+	small := newList(&ChunkingConfig{TargetSize: 256})
+	gear := newList(&ChunkingConfig{TargetSize: 256, Hash: "gear"})
+
+	assert.True(def.Equals(newList(nil)))
+	assert.True(small.Equals(newList(&ChunkingConfig{TargetSize: 256})))
+	assert.False(def.Equals(small))
+	assert.False(small.Equals(gear))
+	assert.True(len(leafLengths(small.sequence)) > len(leafLengths(def.sequence)))
+
+	// Whatever the chunks, the values are the same.
+	for _, l := range []List{small, gear} {
+		assert.Equal(def.Len(), l.Len())
+		assert.True(def.Get(1234).Equals(l.Get(1234)))
+	}
+}
+
+func TestChunkingConfigBoundsBlobChunks(t *testing.T) {
+	assert := assert.New(t)
+
+	buff := make([]byte, 1<<17)
+	rand.New(rand.NewSource(42)).Read(buff)
+	// A run of zeros has no boundaries of its own.
+	for i := 1 << 15; i < 1<<16; i++ {
+		buff[i] = 0
+	}
+
+	for _, hash := range RollingHashes() {
+		vs := newTestValueStore()
+		cfg := ChunkingConfig{TargetSize: 1024, MinSize: 512, MaxSize: 2048, Hash: hash}
+		assert.NoError(vs.SetChunkingConfig(cfg))
+		b := NewBlob(vs, bytes.NewReader(buff))
+
+		lens := leafLengths(b.sequence)
+		assert.True(len(lens) > 1<<17/2048)
+		for i, l := range lens {
+			if i < len(lens)-1 {
+				assert.True(l >= 512, "chunk %d of %d bytes with %s", i, l, hash)
+			}
+			assert.True(l <= 2048, "chunk %d of %d bytes with %s", i, l, hash)
+		}
+
+		out := &bytes.Buffer{}
+		b.Copy(out)
+		assert.Equal(buff, out.Bytes())
+	}
+}
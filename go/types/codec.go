@@ -57,8 +57,10 @@ type nomsWriter interface {
 	writeBytes(v []byte)
 	writeCount(count uint64)
 	writeHash(h hash.Hash)
+	writeInt(v int64)
 	writeNumber(v Number)
 	writeString(v string)
+	writeUint(v uint64)
 	writeUint8(v uint8)
 
 	writeRaw(buff []byte)
@@ -126,6 +128,38 @@ func (b *binaryNomsReader) skipNumber() {
 	b.offset += uint32(count2)
 }
 
+func (b *binaryNomsReader) readInt() int64 {
+	v, count := binary.Varint(b.buff[b.offset:])
+	b.offset += uint32(count)
+	return v
+}
+
+func (b *binaryNomsReader) skipInt() {
+	_, count := binary.Varint(b.buff[b.offset:])
+	b.offset += uint32(count)
+}
+
+func (b *binaryNomsReader) readUint() uint64 {
+	return b.readCount()
+}
+
+func (b *binaryNomsReader) skipUint() {
+	b.skipCount()
+}
+
+func (b *binaryNomsReader) readDecimal() Decimal {
+	neg := b.readBool()
+	mag := b.readString()
+	scale := b.readInt()
+	return Decimal{neg, mag, int32(scale)}
+}
+
+func (b *binaryNomsReader) skipDecimal() {
+	b.skipBool()
+	b.skipString()
+	b.skipInt()
+}
+
 func (b *binaryNomsReader) readBool() bool {
 	return b.readUint8() == 1
 }
@@ -223,6 +257,16 @@ func (b *binaryNomsWriter) writeNumber(v Number) {
 	b.offset += uint32(count)
 }
 
+func (b *binaryNomsWriter) writeInt(v int64) {
+	b.ensureCapacity(binary.MaxVarintLen64)
+	count := binary.PutVarint(b.buff[b.offset:], v)
+	b.offset += uint32(count)
+}
+
+func (b *binaryNomsWriter) writeUint(v uint64) {
+	b.writeCount(v)
+}
+
 func (b *binaryNomsWriter) writeBool(v bool) {
 	if v {
 		b.writeUint8(uint8(1))
@@ -40,6 +40,8 @@ func containCommonSupertypeImpl(a, b *Type, aVisited, bVisited []*Type) bool {
 		return containersIntersect(k, a, b, aVisited, bVisited)
 	case MapKind:
 		return mapsIntersect(a, b, aVisited, bVisited)
+	case TupleKind:
+		return tuplesIntersect(a, b, aVisited, bVisited)
 	default:
 		return true
 	}
@@ -97,6 +99,20 @@ func mapsIntersect(a, b *Type, aVisited, bVisited []*Type) bool {
 	return containCommonSupertypeImpl(aDesc.ElemTypes[1], bDesc.ElemTypes[1], aVisited, bVisited)
 }
 
+func tuplesIntersect(a, b *Type, aVisited, bVisited []*Type) bool {
+	d.Chk.True(TupleKind == a.Desc.Kind() && TupleKind == b.Desc.Kind())
+	aElemTypes, bElemTypes := a.Desc.(CompoundDesc).ElemTypes, b.Desc.(CompoundDesc).ElemTypes
+	if len(aElemTypes) != len(bElemTypes) {
+		return false
+	}
+	for i, et := range aElemTypes {
+		if !containCommonSupertypeImpl(et, bElemTypes[i], aVisited, bVisited) {
+			return false
+		}
+	}
+	return true
+}
+
 func structsIntersect(a, b *Type, aVisited, bVisited []*Type) bool {
 	_, aFound := indexOfType(a, aVisited)
 	_, bFound := indexOfType(b, bVisited)
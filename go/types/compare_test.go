@@ -6,6 +6,7 @@ package types
 
 import (
 	"bytes"
+	"math"
 	"sort"
 	"testing"
 
@@ -23,6 +24,11 @@ func TestCompareTotalOrdering(t *testing.T) {
 		Bool(false), Bool(true),
 		Number(-10), Number(0), Number(10),
 		String("a"), String("b"), String("c"),
+		Int(-10), Int(0), Int(10),
+		Uint(0), Uint(10),
+		Timestamp(-1), Timestamp(0), Timestamp(1),
+		mustParseDecimal("-1.5"), mustParseDecimal("0"), mustParseDecimal("0.001"), mustParseDecimal("1e3"),
+		NewTuple(), NewTuple(String("a")), NewTuple(String("a"), Number(1)), NewTuple(String("a"), Number(2)), NewTuple(String("b")),
 
 		// The order of these are done by the hash.
 		NewSet(vrw, Number(0), Number(1), Number(2), Number(3)),
@@ -71,7 +77,7 @@ func TestCompareDifferentPrimitiveTypes(t *testing.T) {
 	nSet := NewSet(vrw, nums...)
 	nStruct := NewStruct("teststruct", map[string]Value{"f1": Number(1)})
 
-	vals := ValueSlice{Bool(true), Number(19), String("hellow"), blob, nList, nMap, nRef, nSet, nStruct}
+	vals := ValueSlice{Bool(true), Number(19), String("hellow"), Int(-19), Uint(19), Timestamp(19), mustParseDecimal("19.5"), NewTuple(Number(19)), blob, nList, nMap, nRef, nSet, nStruct}
 	sort.Sort(vals)
 
 	for i, v1 := range vals {
@@ -103,6 +109,46 @@ func TestComparePrimitives(t *testing.T) {
 		}
 	}
 
+	ints := []Int{math.MinInt64, -1 << 53, -1, 0, 1<<53 + 1, math.MaxInt64}
+	for i, v1 := range ints {
+		for j, v2 := range ints {
+			res := compareEncodedNomsValues(encode(v1), encode(v2))
+			assert.Equal(compareInts(i, j), res)
+		}
+	}
+
+	timestamps := []Timestamp{math.MinInt64, -1, 0, 1, math.MaxInt64}
+	for i, v1 := range timestamps {
+		for j, v2 := range timestamps {
+			res := compareEncodedNomsValues(encode(v1), encode(v2))
+			assert.Equal(compareInts(i, j), res)
+		}
+	}
+
+	decimals := []Decimal{mustParseDecimal("-100"), mustParseDecimal("-99.99"), mustParseDecimal("-0.5"), {}, mustParseDecimal("1e-30"), mustParseDecimal("0.3"), mustParseDecimal("12"), mustParseDecimal("12.0001"), mustParseDecimal("1e30")}
+	for i, v1 := range decimals {
+		for j, v2 := range decimals {
+			res := compareEncodedNomsValues(encode(v1), encode(v2))
+			assert.Equal(compareInts(i, j), res)
+		}
+	}
+
+	tuples := []Tuple{NewTuple(), NewTuple(Number(-1)), NewTuple(Number(-1), String("a")), NewTuple(Number(-1), String("b")), NewTuple(Number(0), Bool(false)), NewTuple(String("a"))}
+	for i, v1 := range tuples {
+		for j, v2 := range tuples {
+			res := compareEncodedNomsValues(encode(v1), encode(v2))
+			assert.Equal(compareInts(i, j), res)
+		}
+	}
+
+	uints := []Uint{0, 1, 1<<53 + 1, math.MaxUint64}
+	for i, v1 := range uints {
+		for j, v2 := range uints {
+			res := compareEncodedNomsValues(encode(v1), encode(v2))
+			assert.Equal(compareInts(i, j), res)
+		}
+	}
+
 	words := []String{"", "aaa", "another", "another1"}
 	for i, v1 := range words {
 		for j, v2 := range words {
@@ -0,0 +1,257 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/attic-labs/noms/go/hash"
+)
+
+var bigTen = big.NewInt(10)
+
+// maxParsedDecimalScale bounds the scale of the Decimals ParseDecimal returns,
+// so that a short string like "1e-2000000000" can't make Rat() or String()
+// compute or print billions of digits.
+const maxParsedDecimalScale = 10000
+
+// Decimal is a Noms Value representing an exact decimal number, the unscaled
+// integer Unscaled() multiplied by 10^-Scale(). Decimals are kept in a
+// canonical form, without trailing zeros in the unscaled integer, so that
+// equal numbers are encoded and hashed identically. Decimals are ordered by
+// value. The zero value is 0.
+type Decimal struct {
+	neg   bool
+	mag   string // big-endian bytes of the absolute value of the unscaled integer
+	scale int32
+}
+
+// NewDecimal returns the Decimal |unscaled| * 10^-|scale|.
+func NewDecimal(unscaled *big.Int, scale int32) Decimal {
+	if unscaled.Sign() == 0 {
+		return Decimal{}
+	}
+	u := new(big.Int).Set(unscaled)
+	r := new(big.Int)
+	for {
+		q, m := new(big.Int).QuoRem(u, bigTen, r)
+		if m.Sign() != 0 {
+			break
+		}
+		u = q
+		scale--
+	}
+	return Decimal{u.Sign() < 0, string(new(big.Int).Abs(u).Bytes()), scale}
+}
+
+// NewDecimalFromRat returns the Decimal equal to |r|. It returns false if |r|
+// has no finite decimal representation, like 1/3.
+func NewDecimalFromRat(r *big.Rat) (Decimal, bool) {
+	num, denom := new(big.Int).Set(r.Num()), new(big.Int).Set(r.Denom())
+	scale := int32(0)
+	rem := new(big.Int)
+	// Multiply by 10 until the denominator divides the numerator. That only
+	// happens if the denominator's prime factors are 2 and 5.
+	for d := new(big.Int).Set(denom); d.Cmp(big.NewInt(1)) != 0; {
+		g := new(big.Int).GCD(nil, nil, d, bigTen)
+		if g.Cmp(big.NewInt(1)) == 0 {
+			return Decimal{}, false
+		}
+		d.Quo(d, g)
+	}
+	for {
+		q, m := new(big.Int).QuoRem(num, denom, rem)
+		if m.Sign() == 0 {
+			return NewDecimal(q, scale), true
+		}
+		num.Mul(num, bigTen)
+		scale++
+	}
+}
+
+// ParseDecimal parses a decimal number like "-12.50" or "1.2e-3".
+func ParseDecimal(s string) (Decimal, error) {
+	mantissa, exp := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+		mantissa = s[:i]
+		if exp, err = strconv.ParseInt(s[i+1:], 10, 32); err != nil {
+			return Decimal{}, fmt.Errorf("Invalid decimal %s", s)
+		}
+	}
+	scale := int64(0)
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		scale = int64(len(mantissa) - i - 1)
+		mantissa = mantissa[:i] + mantissa[i+1:]
+	}
+	u, ok := new(big.Int).SetString(mantissa, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("Invalid decimal %s", s)
+	}
+	scale -= exp
+	if int64(int32(scale)) != scale {
+		return Decimal{}, fmt.Errorf("Decimal exponent out of range %s", s)
+	}
+	d := NewDecimal(u, int32(scale))
+	if abs32(d.scale) > maxParsedDecimalScale {
+		return Decimal{}, fmt.Errorf("Decimal exponent out of range %s", s)
+	}
+	return d, nil
+}
+
+// Unscaled returns the unscaled integer of |v|.
+func (v Decimal) Unscaled() *big.Int {
+	u := new(big.Int).SetBytes([]byte(v.mag))
+	if v.neg {
+		u.Neg(u)
+	}
+	return u
+}
+
+// Scale returns the number of digits of |v| after the decimal point, which is
+// negative if the unscaled integer is to be multiplied by a power of ten.
+func (v Decimal) Scale() int32 {
+	return v.scale
+}
+
+// Rat returns |v| as a big.Rat.
+func (v Decimal) Rat() *big.Rat {
+	r := new(big.Rat).SetInt(v.Unscaled())
+	p := new(big.Int).Exp(bigTen, big.NewInt(int64(abs32(v.scale))), nil)
+	if v.scale > 0 {
+		return r.Quo(r, new(big.Rat).SetInt(p))
+	}
+	return r.Mul(r, new(big.Rat).SetInt(p))
+}
+
+// Cmp compares |v| and |other| and returns -1, 0 or 1.
+func (v Decimal) Cmp(other Decimal) int {
+	if s1, s2 := v.sign(), other.sign(); s1 != s2 || s1 == 0 {
+		if s1 < s2 {
+			return -1
+		} else if s1 > s2 {
+			return 1
+		}
+		return 0
+	}
+	c := v.cmpAbs(other)
+	if v.neg {
+		return -c
+	}
+	return c
+}
+
+func (v Decimal) sign() int {
+	if v.mag == "" {
+		return 0
+	} else if v.neg {
+		return -1
+	}
+	return 1
+}
+
+// cmpAbs compares the absolute values of the non-zero |v| and |other|. Their
+// adjusted exponents, the number of digits before the decimal point, are
+// compared first, so that only Decimals whose scales differ by less than the
+// length of their digits are brought to the same scale.
+func (v Decimal) cmpAbs(other Decimal) int {
+	a := new(big.Int).SetBytes([]byte(v.mag))
+	b := new(big.Int).SetBytes([]byte(other.mag))
+	aDigits, bDigits := int64(len(a.String())), int64(len(b.String()))
+	aExp, bExp := aDigits-int64(v.scale), bDigits-int64(other.scale)
+	if aExp != bExp {
+		if aExp < bExp {
+			return -1
+		}
+		return 1
+	}
+	// With equal adjusted exponents, the scales differ by as much as the
+	// numbers of digits do.
+	if d := bDigits - aDigits; d > 0 {
+		a.Mul(a, new(big.Int).Exp(bigTen, big.NewInt(d), nil))
+	} else if d < 0 {
+		b.Mul(b, new(big.Int).Exp(bigTen, big.NewInt(-d), nil))
+	}
+	return a.Cmp(b)
+}
+
+// String returns |v| in decimal notation, like "-12.5".
+func (v Decimal) String() string {
+	digits := new(big.Int).SetBytes([]byte(v.mag)).String()
+	if v.scale < 0 {
+		digits += strings.Repeat("0", int(-v.scale))
+	} else if v.scale > 0 {
+		if pad := int(v.scale) - len(digits) + 1; pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		i := len(digits) - int(v.scale)
+		digits = digits[:i] + "." + digits[i:]
+	}
+	if v.neg {
+		return "-" + digits
+	}
+	return digits
+}
+
+func abs32(i int32) int64 {
+	if i < 0 {
+		return -int64(i)
+	}
+	return int64(i)
+}
+
+// Value interface
+func (v Decimal) Value() Value {
+	return v
+}
+
+func (v Decimal) Equals(other Value) bool {
+	return v == other
+}
+
+func (v Decimal) Less(other Value) bool {
+	if v2, ok := other.(Decimal); ok {
+		return v.Cmp(v2) < 0
+	}
+	return kindLess(DecimalKind, other.Kind())
+}
+
+func (v Decimal) Hash() hash.Hash {
+	return getHash(v)
+}
+
+func (v Decimal) WalkValues(cb ValueCallback) {
+}
+
+func (v Decimal) WalkRefs(cb RefCallback) {
+}
+
+func (v Decimal) typeOf() *Type {
+	return DecimalType
+}
+
+func (v Decimal) Kind() NomsKind {
+	return DecimalKind
+}
+
+func (v Decimal) valueReadWriter() ValueReadWriter {
+	return nil
+}
+
+func (v Decimal) writeTo(w nomsWriter) {
+	DecimalKind.writeTo(w)
+	w.writeBool(v.neg)
+	w.writeString(v.mag)
+	w.writeInt(int64(v.scale))
+}
+
+func (v Decimal) valueBytes() []byte {
+	w := newBinaryNomsWriter()
+	v.writeTo(&w)
+	return w.data()
+}
@@ -0,0 +1,110 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package types
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestDecimalParseAndString(t *testing.T) {
+	assert := assert.New(t)
+
+	test := func(in, out string, unscaled int64, scale int32) {
+		d, err := ParseDecimal(in)
+		assert.NoError(err, in)
+		assert.Equal(out, d.String(), in)
+		assert.Equal(big.NewInt(unscaled), d.Unscaled(), in)
+		assert.Equal(scale, d.Scale(), in)
+	}
+	test("0", "0", 0, 0)
+	test("-0.000", "0", 0, 0)
+	test("1", "1", 1, 0)
+	test("12.50", "12.5", 125, 1)
+	test("-0.05", "-0.05", -5, 2)
+	test(".5", "0.5", 5, 1)
+	test("+3.", "3", 3, 0)
+	test("1200", "1200", 12, -2)
+	test("1.5e3", "1500", 15, -2)
+	test("15E-4", "0.0015", 15, 4)
+
+	test("1e10000", "1"+strings.Repeat("0", 10000), 1, -10000)
+	test("1000e-10003", "0."+strings.Repeat("0", 9999)+"1", 1, 10000)
+
+	for _, s := range []string{"", "abc", "1.2.3", "--1", "1e", "1e1.5", "0x10", "1e10001", "1e-10001", "1e-2000000000"} {
+		_, err := ParseDecimal(s)
+		assert.Error(err, s)
+	}
+}
+
+func TestDecimalCanonical(t *testing.T) {
+	assert := assert.New(t)
+
+	a := NewDecimal(big.NewInt(1500), 3)
+	b := NewDecimal(big.NewInt(15), 1)
+	assert.Equal(a, b)
+	assert.True(a.Equals(b))
+	assert.Equal(a.Hash(), b.Hash())
+	assert.Equal(0, a.Cmp(b))
+	assert.True(Decimal{}.Equals(NewDecimal(big.NewInt(0), 10)))
+}
+
+func TestDecimalCmp(t *testing.T) {
+	assert := assert.New(t)
+
+	ordered := []string{"-1e100", "-12.5", "-12.4", "-1", "-0.05", "0", "0.0001", "0.001", "0.00123", "0.1", "1", "1.00000001", "12.4", "12.5", "99.99", "100", "1e100"}
+	for i, a := range ordered {
+		for j, b := range ordered {
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			assert.Equal(expected, mustParseDecimal(a).Cmp(mustParseDecimal(b)), "%s <=> %s", a, b)
+			assert.Equal(mustParseDecimal(a).Rat().Cmp(mustParseDecimal(b).Rat()), mustParseDecimal(a).Cmp(mustParseDecimal(b)), "%s <=> %s", a, b)
+		}
+	}
+
+	// Scales far apart are compared without computing a power of ten for
+	// the difference.
+	tiny := NewDecimal(big.NewInt(1), math.MaxInt32)
+	huge := NewDecimal(big.NewInt(1), math.MinInt32)
+	assert.Equal(-1, tiny.Cmp(huge))
+	assert.Equal(1, huge.Cmp(tiny))
+	assert.Equal(1, NewDecimal(big.NewInt(-1), math.MaxInt32).Cmp(NewDecimal(big.NewInt(-1), math.MinInt32)))
+	assert.Equal(-1, Decimal{}.Cmp(tiny))
+	assert.True(tiny.Less(huge))
+}
+
+func TestDecimalRat(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(big.NewRat(-1, 20), mustParseDecimal("-0.05").Rat())
+	assert.Equal(big.NewRat(1200, 1), mustParseDecimal("12e2").Rat())
+
+	d, ok := NewDecimalFromRat(big.NewRat(3, 8))
+	assert.True(ok)
+	assert.True(mustParseDecimal("0.375").Equals(d))
+
+	d, ok = NewDecimalFromRat(big.NewRat(-700, 1))
+	assert.True(ok)
+	assert.True(mustParseDecimal("-700").Equals(d))
+
+	_, ok = NewDecimalFromRat(big.NewRat(1, 3))
+	assert.False(ok)
+}
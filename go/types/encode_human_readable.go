@@ -10,6 +10,7 @@ import (
 	"io"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/attic-labs/noms/go/d"
 	"github.com/attic-labs/noms/go/util/writers"
@@ -36,6 +37,7 @@ type HRSCommenter interface {
 
 var (
 	commenterRegistry = map[string]map[string]HRSCommenter{}
+	timestampLocation = time.UTC
 	registryLock      sync.RWMutex
 )
 
@@ -70,6 +72,14 @@ func UnregisterHRSCommenter(typename, unique string) {
 	delete(r, unique)
 }
 
+// SetHRSTimestampLocation sets the time zone in which Timestamps are encoded.
+// The default is UTC.
+func SetHRSTimestampLocation(loc *time.Location) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	timestampLocation = loc
+}
+
 // GetHRSCommenters the map of 'unique' strings to HRSCommentFunc for
 // a specified typename.
 func GetHRSCommenters(typename string) []HRSCommenter {
@@ -176,6 +186,21 @@ func (w *hrsWriter) Write(v Value) {
 	case NumberKind:
 		w.write(strconv.FormatFloat(float64(v.(Number)), w.floatFormat, -1, 64))
 
+	case IntKind:
+		w.write("int(" + strconv.FormatInt(int64(v.(Int)), 10) + ")")
+
+	case UintKind:
+		w.write("uint(" + strconv.FormatUint(uint64(v.(Uint)), 10) + ")")
+
+	case TimestampKind:
+		registryLock.RLock()
+		loc := timestampLocation
+		registryLock.RUnlock()
+		w.write("timestamp(" + strconv.Quote(v.(Timestamp).Time().In(loc).Format(time.RFC3339Nano)) + ")")
+
+	case DecimalKind:
+		w.write("decimal(" + v.(Decimal).String() + ")")
+
 	case StringKind:
 		w.write(strconv.Quote(string(v.(String))))
 
@@ -246,6 +271,19 @@ func (w *hrsWriter) Write(v Value) {
 	case StructKind:
 		w.writeStruct(v.(Struct))
 
+	case TupleKind:
+		w.write("tuple(")
+		for i, ev := range v.(Tuple).Values() {
+			if i != 0 {
+				w.write(", ")
+			}
+			w.Write(ev)
+			if w.err != nil {
+				break
+			}
+		}
+		w.write(")")
+
 	default:
 		panic("unreachable")
 	}
@@ -315,7 +353,7 @@ func (w *hrsWriter) writeSize(v Value) {
 
 func (w *hrsWriter) writeType(t *Type, seenStructs map[*Type]struct{}) {
 	switch t.TargetKind() {
-	case BlobKind, BoolKind, NumberKind, StringKind, TypeKind, ValueKind:
+	case BlobKind, BoolKind, NumberKind, StringKind, TypeKind, ValueKind, IntKind, UintKind, TimestampKind, DecimalKind:
 		w.write(t.TargetKind().String())
 	case ListKind, RefKind, SetKind, MapKind:
 		w.write(t.TargetKind().String())
@@ -345,6 +383,19 @@ func (w *hrsWriter) writeType(t *Type, seenStructs map[*Type]struct{}) {
 				break
 			}
 		}
+	case TupleKind:
+		w.write(t.TargetKind().String())
+		w.write("<")
+		for i, et := range t.Desc.(CompoundDesc).ElemTypes {
+			if i != 0 {
+				w.write(", ")
+			}
+			w.writeType(et, seenStructs)
+			if w.err != nil {
+				break
+			}
+		}
+		w.write(">")
 	case StructKind:
 		w.writeStructType(t, seenStructs)
 	case CycleKind:
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/attic-labs/noms/go/util/test"
 	"github.com/stretchr/testify/assert"
@@ -35,6 +36,19 @@ func TestWriteHumanReadablePrimitiveValues(t *testing.T) {
 	assertWriteHRSEqual(t, "314159.26535", Number(3.1415926535e5))
 	assertWriteHRSEqual(t, "3.1415926535e+20", Number(3.1415926535e20))
 
+	assertWriteHRSEqual(t, "int(-9007199254740993)", Int(-9007199254740993))
+	assertWriteHRSEqual(t, "uint(18446744073709551615)", Uint(18446744073709551615))
+	assertWriteHRSEqual(t, `timestamp("2017-08-03T23:07:06.123456789Z")`, Timestamp(time.Date(2017, 8, 3, 16, 7, 6, 123456789, time.FixedZone("PDT", -7*3600)).UnixNano()))
+	assertWriteHRSEqual(t, `timestamp("1969-12-31T23:59:59Z")`, Timestamp(-1e9))
+	assertWriteHRSEqual(t, "decimal(-12.34)", mustParseDecimal("-12.340"))
+	assertWriteHRSEqual(t, "decimal(1200)", mustParseDecimal("12e2"))
+	assertWriteHRSEqual(t, "tuple()", NewTuple())
+	assertWriteHRSEqual(t, `tuple("a", int(1), tuple(true))`, NewTuple(String("a"), Int(1), NewTuple(Bool(true))))
+
+	SetHRSTimestampLocation(time.FixedZone("PDT", -7*3600))
+	assertWriteHRSEqual(t, `timestamp("2017-08-03T16:07:06.5-07:00")`, Timestamp(time.Date(2017, 8, 3, 23, 7, 6, 5e8, time.UTC).UnixNano()))
+	SetHRSTimestampLocation(time.UTC)
+
 	assertWriteHRSEqual(t, `"abc"`, String("abc"))
 	assertWriteHRSEqual(t, `" "`, String(" "))
 	assertWriteHRSEqual(t, `"\t"`, String("\t"))
@@ -203,6 +217,12 @@ func TestWriteHumanReadableType(t *testing.T) {
 	assertWriteHRSEqual(t, "Blob", BlobType)
 	assertWriteHRSEqual(t, "String", StringType)
 	assertWriteHRSEqual(t, "Number", NumberType)
+	assertWriteHRSEqual(t, "Int", IntType)
+	assertWriteHRSEqual(t, "Uint", UintType)
+	assertWriteHRSEqual(t, "Timestamp", TimestampType)
+	assertWriteHRSEqual(t, "Decimal", DecimalType)
+	assertWriteHRSEqual(t, "Tuple<>", MakeTupleType())
+	assertWriteHRSEqual(t, "Tuple<String, Bool | Number>", MakeTupleType(StringType, MakeUnionType(NumberType, BoolType)))
 
 	assertWriteHRSEqual(t, "List<Number>", MakeListType(NumberType))
 	assertWriteHRSEqual(t, "Set<Number>", MakeSetType(NumberType))
@@ -28,6 +28,8 @@ func toBinaryNomsReaderData(data []interface{}) []byte {
 			w.writeNumber(v)
 		case uint64:
 			w.writeCount(v)
+		case int64:
+			w.writeInt(v)
 		case bool:
 			w.writeBool(v)
 		case hash.Hash:
@@ -100,12 +102,27 @@ func TestRoundTrips(t *testing.T) {
 	assertRoundTrips(Number(math.MaxFloat64))
 	assertRoundTrips(Number(math.Nextafter(1, 2) - 1))
 
+	for _, i := range []int64{0, 1, -1, 127, -128, 1 << 53, 1<<53 + 1, math.MaxInt64, math.MinInt64} {
+		assertRoundTrips(Int(i))
+	}
+	for _, u := range []uint64{0, 1, 255, 1<<53 + 1, math.MaxUint64} {
+		assertRoundTrips(Uint(u))
+	}
+	for _, ts := range []int64{0, -1, 1500000000123456789, math.MaxInt64, math.MinInt64} {
+		assertRoundTrips(Timestamp(ts))
+	}
+	for _, s := range []string{"0", "-1", "0.1", "1e100", "-123456789012345678901234567890.123456789"} {
+		assertRoundTrips(mustParseDecimal(s))
+	}
+
 	assertRoundTrips(String(""))
 	assertRoundTrips(String("foo"))
 	assertRoundTrips(String("AINT NO THANG"))
 	assertRoundTrips(String("💩"))
 
 	assertRoundTrips(NewStruct("", StructData{"a": Bool(true), "b": String("foo"), "c": Number(2.3)}))
+	assertRoundTrips(NewTuple())
+	assertRoundTrips(NewTuple(String("foo"), Int(-1), NewTuple(Bool(true))))
 
 	listLeaf := newList(newListLeafSequence(vs, Number(4), Number(5), Number(6), Number(7)))
 	assertRoundTrips(listLeaf)
@@ -172,6 +189,30 @@ func TestWritePrimitives(t *testing.T) {
 			StringKind, "hi",
 		},
 		String("hi"))
+
+	assertEncoding(t,
+		[]interface{}{
+			IntKind, int64(-9007199254740993),
+		},
+		Int(-9007199254740993))
+
+	assertEncoding(t,
+		[]interface{}{
+			UintKind, uint64(math.MaxUint64),
+		},
+		Uint(math.MaxUint64))
+
+	assertEncoding(t,
+		[]interface{}{
+			TimestampKind, int64(1500000000123456789),
+		},
+		Timestamp(1500000000123456789))
+
+	assertEncoding(t,
+		[]interface{}{
+			DecimalKind, true, string([]byte{0x4, 0xd2}), int64(2),
+		},
+		mustParseDecimal("-12.340"))
 }
 
 func TestWriteSimpleBlob(t *testing.T) {
@@ -300,6 +341,16 @@ func TestWriteStruct(t *testing.T) {
 	)
 }
 
+func TestWriteTuple(t *testing.T) {
+	assertEncoding(t,
+		[]interface{}{
+			TupleKind, uint64(2), /* len */
+			StringKind, "a", NumberKind, Number(42),
+		},
+		NewTuple(String("a"), Number(42)),
+	)
+}
+
 func TestWriteStructTooMuchData(t *testing.T) {
 	s := NewStruct("S", StructData{"x": Number(42), "b": Bool(true)})
 	c := EncodeValue(s)
@@ -524,6 +575,15 @@ func nomsTestWriteRecursiveStruct(t *testing.T) {
 	)
 }
 
+func TestWriteTupleType(t *testing.T) {
+	assertEncoding(t,
+		[]interface{}{
+			TypeKind, TupleKind, uint64(2) /* len */, StringKind, NumberKind,
+		},
+		MakeTupleType(StringType, NumberType),
+	)
+}
+
 func TestWriteUnionList(t *testing.T) {
 	vrw := newTestValueStore()
 
@@ -0,0 +1,66 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package types
+
+import (
+	"encoding/binary"
+
+	"github.com/attic-labs/noms/go/hash"
+)
+
+// Int is a Noms Value wrapper around the primitive int64 type. Unlike Number,
+// it represents every 64-bit integer exactly.
+type Int int64
+
+// Value interface
+func (v Int) Value() Value {
+	return v
+}
+
+func (v Int) Equals(other Value) bool {
+	return v == other
+}
+
+func (v Int) Less(other Value) bool {
+	if v2, ok := other.(Int); ok {
+		return v < v2
+	}
+	return kindLess(IntKind, other.Kind())
+}
+
+func (v Int) Hash() hash.Hash {
+	return getHash(v)
+}
+
+func (v Int) WalkValues(cb ValueCallback) {
+}
+
+func (v Int) WalkRefs(cb RefCallback) {
+}
+
+func (v Int) typeOf() *Type {
+	return IntType
+}
+
+func (v Int) Kind() NomsKind {
+	return IntKind
+}
+
+func (v Int) valueReadWriter() ValueReadWriter {
+	return nil
+}
+
+func (v Int) writeTo(w nomsWriter) {
+	IntKind.writeTo(w)
+	w.writeInt(int64(v))
+}
+
+func (v Int) valueBytes() []byte {
+	// IntKind, int (Varint)
+	buff := make([]byte, 1+binary.MaxVarintLen64)
+	w := binaryNomsWriter{buff, 0}
+	v.writeTo(&w)
+	return buff[:w.offset]
+}
@@ -14,10 +14,8 @@ type kindAndHash interface {
 }
 
 func valueLess(v1, v2 kindAndHash) bool {
-	switch v2.Kind() {
-	case BoolKind, NumberKind, StringKind:
+	if isKindOrderedByValue(v2.Kind()) {
 		return false
-	default:
-		return v1.Hash().Less(v2.Hash())
 	}
+	return v1.Hash().Less(v2.Hash())
 }
@@ -0,0 +1,54 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package types
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxLinesBlobSize is the size of the largest Blob that Lines will read.
+const MaxLinesBlobSize = 16 << 20
+
+// Lines splits a String, or a Blob that holds UTF-8 text, into lines. Each
+// line keeps its trailing newline, so joining the lines gives back the text.
+// It returns false if |v| is any other value, or a Blob that's larger than
+// MaxLinesBlobSize or isn't text.
+func Lines(v Value) ([]string, bool) {
+	var text string
+	switch v := v.(type) {
+	case String:
+		text = string(v)
+	case Blob:
+		if v.Len() > MaxLinesBlobSize {
+			return nil, false
+		}
+		buf := &bytes.Buffer{}
+		v.Copy(buf)
+		if !utf8.Valid(buf.Bytes()) || bytes.IndexByte(buf.Bytes(), 0) >= 0 {
+			return nil, false
+		}
+		text = buf.String()
+	default:
+		return nil, false
+	}
+	if text == "" {
+		return []string{}, true
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines, true
+}
+
+// LineSplices returns the Splices that turn the lines |last| into |current|,
+// the way that List.Diff does for the elements of Lists. SpAt is an index
+// into |last| and SpFrom one into |current|.
+func LineSplices(last, current []string) []Splice {
+	return calcSplices(uint64(len(last)), uint64(len(current)), DEFAULT_MAX_SPLICE_MATRIX_SIZE,
+		func(i uint64, j uint64) bool { return last[i] == current[j] })
+}
@@ -0,0 +1,49 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package types
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/attic-labs/noms/go/chunks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines(t *testing.T) {
+	assert := assert.New(t)
+	vs := NewValueStore((&chunks.TestStorage{}).NewView())
+	defer vs.Close()
+
+	lines, ok := Lines(String("a\nb\n\nc"))
+	assert.True(ok)
+	assert.Equal([]string{"a\n", "b\n", "\n", "c"}, lines)
+
+	lines, ok = Lines(NewBlob(vs, strings.NewReader("a\nb\n")))
+	assert.True(ok)
+	assert.Equal([]string{"a\n", "b\n"}, lines)
+
+	lines, ok = Lines(String(""))
+	assert.True(ok)
+	assert.Empty(lines)
+
+	_, ok = Lines(NewBlob(vs, bytes.NewReader([]byte{'a', 0, 'b'})))
+	assert.False(ok)
+	_, ok = Lines(NewBlob(vs, bytes.NewReader([]byte{0xff, 0xfe})))
+	assert.False(ok)
+	_, ok = Lines(Number(1))
+	assert.False(ok)
+}
+
+func TestLineSplices(t *testing.T) {
+	assert := assert.New(t)
+
+	last := []string{"a\n", "b\n", "c\n", "d\n"}
+	current := []string{"a\n", "x\n", "c\n", "d\n", "e\n"}
+	assert.Equal([]Splice{{1, 1, 1, 1}, {4, 0, 1, 4}}, LineSplices(last, current))
+	assert.Equal([]Splice{{0, 4, 0, 0}}, LineSplices(last, []string{}))
+	assert.Empty(LineSplices(last, last))
+}
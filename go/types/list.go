@@ -216,10 +216,50 @@ func (l List) Iterator() ListIterator {
 // have reached its end on creation.
 func (l List) IteratorAt(index uint64) ListIterator {
 	return ListIterator{
-		newCursorAtIndex(l.sequence, index),
+		cursor: newCursorAtIndex(l.sequence, index),
 	}
 }
 
+// IteratorRange returns a ListIterator over the half-open range of indices [startIdx, endIdx).
+// endIdx is clamped to the length of the list.
+func (l List) IteratorRange(startIdx, endIdx uint64) ListIterator {
+	startIdx, endIdx = l.clampRange(startIdx, endIdx)
+	return ListIterator{
+		cursor: newCursorAtIndex(l.sequence, startIdx),
+		end:    newCursorAtIndex(l.sequence, endIdx),
+	}
+}
+
+// ReverseIterator returns a ListIterator that starts at the last element of the list and moves
+// towards the first.
+func (l List) ReverseIterator() ListIterator {
+	return l.ReverseIteratorRange(0, l.Len())
+}
+
+// ReverseIteratorRange returns a ListIterator over the half-open range of indices
+// [startIdx, endIdx) that starts at endIdx-1 and moves towards startIdx. endIdx is clamped to the
+// length of the list.
+func (l List) ReverseIteratorRange(startIdx, endIdx uint64) ListIterator {
+	startIdx, endIdx = l.clampRange(startIdx, endIdx)
+	cur := newCursorAtIndex(l.sequence, endIdx)
+	cur.retreat()
+	return ListIterator{
+		cursor:  cur,
+		end:     newCursorAtIndex(l.sequence, startIdx),
+		reverse: true,
+	}
+}
+
+func (l List) clampRange(startIdx, endIdx uint64) (uint64, uint64) {
+	if endIdx > l.Len() {
+		endIdx = l.Len()
+	}
+	if startIdx > endIdx {
+		startIdx = endIdx
+	}
+	return startIdx, endIdx
+}
+
 // Diff streams the diff from last to the current list to the changes channel. Caller can close
 // closeChan to cancel the diff operation.
 func (l List) Diff(last List, changes chan<- Splice, closeChan <-chan struct{}) {
@@ -11,17 +11,36 @@ import (
 // ListIterator can be used to efficiently iterate through a Noms List.
 type ListIterator struct {
 	cursor *sequenceCursor
+	// end, if not nil, is the position at which the iteration stops. It is the end of the range for
+	// forward iterators and the start of the range for reverse iterators.
+	end     *sequenceCursor
+	reverse bool
 }
 
 // Next returns subsequent Values from a List, starting with the index at which the iterator was
-// created. If there are no more Values, Next() returns nil.
+// created. If there are no more Values, Next() returns nil. Iterators created with ReverseIterator
+// or ReverseIteratorRange move towards the start of the List.
 func (li ListIterator) Next() (out Value) {
 	if li.cursor == nil {
 		d.Panic("Cannot use a nil ListIterator")
 	}
-	if li.cursor.valid() {
+	if li.cursor.valid() && li.inRange() {
 		out = li.cursor.current().(Value)
-		li.cursor.advance()
+		if li.reverse {
+			li.cursor.retreat()
+		} else {
+			li.cursor.advance()
+		}
 	}
 	return
 }
+
+func (li ListIterator) inRange() bool {
+	if li.end == nil {
+		return true
+	}
+	if li.reverse {
+		return li.cursor.compare(li.end) >= 0
+	}
+	return li.cursor.compare(li.end) < 0
+}
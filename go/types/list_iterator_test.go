@@ -27,3 +27,37 @@ func TestListIterator(t *testing.T) {
 	i = l.IteratorAt(l.Len())
 	assert.Nil(i.Next())
 }
+
+func TestListIteratorRange(t *testing.T) {
+	assert := assert.New(t)
+	vrw := newTestValueStore()
+
+	numbers := generateNumbersAsValues(10)
+	l := NewList(vrw, numbers...)
+
+	assert.True(iterToSlice(l.IteratorRange(2, 5)).Equals(numbers[2:5]))
+	assert.True(iterToSlice(l.IteratorRange(8, 20)).Equals(numbers[8:]))
+	assert.Nil(l.IteratorRange(5, 5).Next())
+	assert.Nil(l.IteratorRange(6, 5).Next())
+
+	reversed := func(vs ValueSlice) ValueSlice {
+		r := make(ValueSlice, len(vs))
+		for i, v := range vs {
+			r[len(vs)-1-i] = v
+		}
+		return r
+	}
+	assert.True(iterToSlice(l.ReverseIterator()).Equals(reversed(numbers)))
+	assert.True(iterToSlice(l.ReverseIteratorRange(2, 5)).Equals(reversed(numbers[2:5])))
+	assert.True(iterToSlice(l.ReverseIteratorRange(0, 20)).Equals(reversed(numbers)))
+	assert.Nil(l.ReverseIteratorRange(5, 5).Next())
+	assert.Nil(NewList(vrw).ReverseIterator().Next())
+
+	// Cross chunk boundaries.
+	numbers = generateNumbersAsValues(5000)
+	l = NewList(vrw, numbers...)
+	assert.True(l.asSequence().treeLevel() > 0, "expected a chunked list")
+	assert.True(iterToSlice(l.IteratorRange(100, 4900)).Equals(numbers[100:4900]))
+	assert.True(iterToSlice(l.ReverseIteratorRange(100, 4900)).Equals(reversed(numbers[100:4900])))
+	assert.True(iterToSlice(l.ReverseIterator()).Equals(reversed(numbers)))
+}
@@ -18,6 +18,14 @@ func MakePrimitiveType(k NomsKind) *Type {
 		return NumberType
 	case StringKind:
 		return StringType
+	case IntKind:
+		return IntType
+	case UintKind:
+		return UintType
+	case TimestampKind:
+		return TimestampType
+	case DecimalKind:
+		return DecimalType
 	case BlobKind:
 		return BlobType
 	case ValueKind:
@@ -50,6 +58,12 @@ func MakeMapType(keyType, valType *Type) *Type {
 	return simplifyType(makeCompoundType(MapKind, keyType, valType), false)
 }
 
+// MakeTupleType returns the type of Tuples whose elements have the types
+// |elemTypes|, in order.
+func MakeTupleType(elemTypes ...*Type) *Type {
+	return simplifyType(makeCompoundType(TupleKind, elemTypes...), false)
+}
+
 func MakeStructType(name string, fields ...StructField) *Type {
 	fs := structTypeFields(fields)
 	sort.Sort(fs)
@@ -76,6 +90,10 @@ func makePrimitiveType(k NomsKind) *Type {
 var BoolType = makePrimitiveType(BoolKind)
 var NumberType = makePrimitiveType(NumberKind)
 var StringType = makePrimitiveType(StringKind)
+var IntType = makePrimitiveType(IntKind)
+var UintType = makePrimitiveType(UintKind)
+var TimestampType = makePrimitiveType(TimestampKind)
+var DecimalType = makePrimitiveType(DecimalKind)
 var BlobType = makePrimitiveType(BlobKind)
 var TypeType = makePrimitiveType(TypeKind)
 var ValueType = makePrimitiveType(ValueKind)
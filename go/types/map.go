@@ -218,6 +218,32 @@ func (m Map) IteratorFrom(key Value) *MapIterator {
 	}
 }
 
+// IteratorRange returns a MapIterator over the entries whose keys are in the half-open range
+// [from, to). A nil from starts at the first entry and a nil to ends after the last entry.
+func (m Map) IteratorRange(from, to Value) *MapIterator {
+	return &MapIterator{
+		cursor: newCursorAtValue(m.orderedSequence, from, false, false),
+		bounds: orderedRange{from, to},
+	}
+}
+
+// ReverseIterator returns a MapIterator that starts at the last entry of the map and whose Next
+// moves towards the first entry.
+func (m Map) ReverseIterator() *MapIterator {
+	return m.ReverseIteratorRange(nil, nil)
+}
+
+// ReverseIteratorRange returns a MapIterator over the entries whose keys are in the half-open range
+// [from, to), in descending key order. It starts at the last entry whose key is < to, so e.g. the
+// latest N entries before some key can be read without computing their index.
+func (m Map) ReverseIteratorRange(from, to Value) *MapIterator {
+	return &MapIterator{
+		cursor:  newCursorBeforeValue(m.orderedSequence, to),
+		bounds:  orderedRange{from, to},
+		reverse: true,
+	}
+}
+
 type mapIterAllCallback func(key, value Value)
 
 func (m Map) IterAll(cb mapIterAllCallback) {
@@ -241,10 +267,63 @@ func (m Map) IterFrom(start Value, cb mapIterCallback) {
 	})
 }
 
+// IterRange calls cb for every entry whose key is in the half-open range [from, to), in ascending
+// key order. If cb returns true the iteration stops.
+func (m Map) IterRange(from, to Value, cb mapIterCallback) {
+	for it := m.IteratorRange(from, to); it.Valid(); it.Next() {
+		if cb(it.Entry()) {
+			return
+		}
+	}
+}
+
 func (m Map) Edit() *MapEditor {
 	return NewMapEditor(m)
 }
 
+// Merge returns a Map with the entries of m and other. If a key is in both maps the value from
+// other is used. The entries of the smaller map that differ from the larger one are applied to it,
+// and the chunks the two maps share are skipped.
+func (m Map) Merge(other Map) Map {
+	if m.Len() > other.Len() {
+		return newMap(mergeOrderedSequences(m.orderedSequence, other.orderedSequence, m.newChunker, func(mItem, otherItem sequenceItem) mergeAction {
+			if otherItem == nil {
+				return mergeKeepBase
+			}
+			return mergeUseOther
+		}))
+	}
+	return newMap(mergeOrderedSequences(other.orderedSequence, m.orderedSequence, m.newChunker, func(otherItem, mItem sequenceItem) mergeAction {
+		if otherItem == nil {
+			return mergeUseOther
+		}
+		return mergeKeepBase
+	}))
+}
+
+// Intersect returns a Map with the entries of m whose keys are also in other. The smaller map is
+// edited into the result, and the chunks the two maps share are skipped.
+func (m Map) Intersect(other Map) Map {
+	if m.Len() <= other.Len() {
+		return newMap(mergeOrderedSequences(m.orderedSequence, other.orderedSequence, m.newChunker, func(mItem, otherItem sequenceItem) mergeAction {
+			if mItem == nil || otherItem == nil {
+				return mergeDrop
+			}
+			return mergeKeepBase
+		}))
+	}
+	return newMap(mergeOrderedSequences(other.orderedSequence, m.orderedSequence, m.newChunker, func(otherItem, mItem sequenceItem) mergeAction {
+		if mItem == nil || otherItem == nil {
+			return mergeDrop
+		}
+		return mergeUseOther
+	}))
+}
+
+func (m Map) newChunker(cur *sequenceCursor, vrw ValueReadWriter) *sequenceChunker {
+	return newSequenceChunker(cur, 0, vrw, makeMapLeafChunkFn(vrw), newOrderedMetaSequenceChunkFn(MapKind, vrw), mapHashValueBytes)
+}
+
 func buildMapData(values []Value) mapEntrySlice {
 	if len(values) == 0 {
 		return mapEntrySlice{}
@@ -9,10 +9,19 @@ type MapIterator struct {
 	cursor       *sequenceCursor
 	currentKey   Value
 	currentValue Value
+	bounds       orderedRange
+	reverse      bool
 }
 
+// Valid returns true if the iterator is positioned at an entry whose key is within its bounds.
 func (mi *MapIterator) Valid() bool {
-	return mi.cursor.valid()
+	if !mi.cursor.valid() {
+		return false
+	}
+	if mi.bounds.from == nil && mi.bounds.to == nil {
+		return true
+	}
+	return mi.bounds.contains(mi.cursor.current().(mapEntry).key)
 }
 
 func (mi *MapIterator) Entry() (k Value, v Value) {
@@ -20,14 +29,14 @@ func (mi *MapIterator) Entry() (k Value, v Value) {
 }
 
 func (mi *MapIterator) Key() Value {
-	if !mi.cursor.valid() {
+	if !mi.Valid() {
 		return nil
 	}
 	return mi.cursor.current().(mapEntry).key
 }
 
 func (mi *MapIterator) Value() Value {
-	if !mi.cursor.valid() {
+	if !mi.Valid() {
 		return nil
 	}
 	return mi.cursor.current().(mapEntry).value
@@ -41,18 +50,27 @@ func (mi *MapIterator) Position() uint64 {
 }
 
 // Prev returns the previous entry from the Map. If there is no previous entry, Prev() returns nils.
+// For an iterator created with ReverseIterator or ReverseIteratorRange the previous entry is the one
+// with the next larger key.
 func (mi *MapIterator) Prev() bool {
-	if !mi.cursor.valid() {
-		return false
-	}
-	return mi.cursor.retreat()
+	return mi.step(!mi.reverse)
 }
 
 // Next returns the subsequent entries from the Map, starting with the entry at which the iterator
-// was created. If there are no more entries, Next() returns nils.
+// was created. If there are no more entries, Next() returns nils. For an iterator created with
+// ReverseIterator or ReverseIteratorRange the subsequent entry is the one with the next smaller key.
 func (mi *MapIterator) Next() bool {
-	if !mi.cursor.valid() {
+	return mi.step(mi.reverse)
+}
+
+func (mi *MapIterator) step(backward bool) bool {
+	if !mi.Valid() {
 		return false
 	}
-	return mi.cursor.advance()
+	if backward {
+		mi.cursor.retreat()
+	} else {
+		mi.cursor.advance()
+	}
+	return mi.Valid()
 }
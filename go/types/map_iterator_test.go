@@ -86,3 +86,119 @@ func TestMapIterator(t *testing.T) {
 		}
 	}
 }
+
+func TestMapIteratorRange(t *testing.T) {
+	assert := assert.New(t)
+
+	vrw := newTestValueStore()
+
+	me := NewMap(vrw).Edit()
+	for i := 0; i < 5; i++ {
+		me.Set(String(string(byte(65+i))), Number(i))
+	}
+
+	m := me.Map()
+
+	str := func(s string) Value {
+		if s == "" {
+			return nil
+		}
+		return String(s)
+	}
+
+	tc := []struct {
+		reverse  bool
+		from, to string
+		expected []string
+	}{
+		{false, "", "", []string{"A", "B", "C", "D", "E"}},
+		{false, "B", "D", []string{"B", "C"}},
+		{false, "AA", "DD", []string{"B", "C", "D"}},
+		{false, "", "C", []string{"A", "B"}},
+		{false, "C", "", []string{"C", "D", "E"}},
+		{false, "C", "C", []string{}},
+		{false, "D", "B", []string{}},
+		{false, "F", "", []string{}},
+		{true, "", "", []string{"E", "D", "C", "B", "A"}},
+		{true, "B", "D", []string{"C", "B"}},
+		{true, "AA", "DD", []string{"D", "C", "B"}},
+		{true, "", "C", []string{"B", "A"}},
+		{true, "C", "", []string{"E", "D", "C"}},
+		{true, "C", "C", []string{}},
+		{true, "", "A", []string{}},
+		{true, "F", "", []string{}},
+	}
+
+	for i, t := range tc {
+		lbl := fmt.Sprintf("test case %d", i)
+		var it *MapIterator
+		if t.reverse {
+			it = m.ReverseIteratorRange(str(t.from), str(t.to))
+		} else {
+			it = m.IteratorRange(str(t.from), str(t.to))
+		}
+		actual := []string{}
+		for ; it.Valid(); it.Next() {
+			actual = append(actual, string(it.Key().(String)))
+			assert.True(m.Get(it.Key()).Equals(it.Value()), lbl)
+		}
+		assert.Equal(t.expected, actual, lbl)
+		assert.Nil(it.Key(), lbl)
+		assert.False(it.Next(), lbl)
+		assert.False(it.Prev(), lbl)
+	}
+
+	// Prev moves against the direction of iteration.
+	it := m.ReverseIterator()
+	assert.True(it.Next())
+	assert.Equal(String("D"), it.Key())
+	assert.True(it.Prev())
+	assert.Equal(String("E"), it.Key())
+	assert.False(it.Prev())
+
+	actual := []string{}
+	m.IterRange(String("B"), String("E"), func(k, v Value) bool {
+		actual = append(actual, string(k.(String)))
+		return k.Equals(String("C"))
+	})
+	assert.Equal([]string{"B", "C"}, actual)
+
+	assert.False(NewMap(vrw).ReverseIterator().Valid())
+	assert.False(NewMap(vrw).IteratorRange(nil, nil).Valid())
+}
+
+func TestMapReverseIteratorChunked(t *testing.T) {
+	assert := assert.New(t)
+
+	vrw := newTestValueStore()
+
+	// A time series keyed by timestamp.
+	kvs := ValueSlice{}
+	for i := 0; i < 5000; i++ {
+		kvs = append(kvs, Number(i*10), Number(i))
+	}
+	m := NewMap(vrw, kvs...)
+	assert.True(m.asSequence().treeLevel() > 0, "expected a chunked map")
+
+	// The latest 100 points before T.
+	it := m.ReverseIteratorRange(nil, Number(30005))
+	for i := 3000; i > 2900; i-- {
+		assert.True(it.Valid())
+		assert.Equal(Number(i), it.Value())
+		it.Next()
+	}
+
+	it = m.ReverseIterator()
+	for i := 4999; i >= 0; i-- {
+		assert.Equal(Number(i), it.Value())
+		it.Next()
+	}
+	assert.False(it.Valid())
+
+	it = m.IteratorRange(Number(100), Number(49000))
+	for i := 10; i < 4900; i++ {
+		assert.Equal(Number(i), it.Value())
+		it.Next()
+	}
+	assert.False(it.Valid())
+}
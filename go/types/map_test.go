@@ -11,6 +11,7 @@ import (
 	"sort"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/attic-labs/noms/go/chunks"
 	"github.com/stretchr/testify/assert"
@@ -1560,6 +1561,23 @@ func TestMapIterFrom(t *testing.T) {
 	assert.True(kvs[98:].Equals(test(m1, Number(48), Number(1000))))
 	assert.True(kvs[0:0].Equals(test(m1, Number(100), Number(1000))))
 	assert.True(kvs[50:60].Equals(test(m1, Number(0), Number(8))))
+
+	// Ints beyond 2^53 keep their order, which Numbers cannot.
+	ikvs := ValueSlice{}
+	for i := int64(0); i < 100; i++ {
+		ikvs = append(ikvs, Int(1<<60+i), Number(i))
+	}
+	m2 := NewMap(vrw, ikvs...)
+	assert.True(ikvs[20:40].Equals(test(m2, Int(1<<60+10), Int(1<<60+19))))
+
+	// Timestamps are ordered by time, so Maps keyed by them can be range scanned.
+	start := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	tkvs := ValueSlice{}
+	for i := 0; i < 100; i++ {
+		tkvs = append(tkvs, Timestamp(start.Add(time.Duration(i)*time.Nanosecond).UnixNano()), Number(i))
+	}
+	m3 := NewMap(vrw, tkvs...)
+	assert.True(tkvs[20:40].Equals(test(m3, Timestamp(start.Add(10).UnixNano()), Timestamp(start.Add(19).UnixNano()))))
 }
 
 func TestMapAt(t *testing.T) {
@@ -1696,3 +1714,90 @@ func TestNestedEditing(t *testing.T) {
 		String("b"), NewMap(vrw, String("b"), NewSet(vrw)),
 	))) // do not remove empty
 }
+
+func TestMapMergeIntersect(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+
+	a := NewMap(vs).Edit()
+	b := NewMap(vs).Edit()
+	merged := NewMap(vs).Edit()
+	intersection := NewMap(vs).Edit()
+	for i := 0; i < 5000; i++ {
+		k := Number(i)
+		switch {
+		case i%2 == 0 && i%3 == 0:
+			a.Set(k, String("a"))
+			b.Set(k, String("b"))
+			merged.Set(k, String("b"))
+			intersection.Set(k, String("a"))
+		case i%2 == 0:
+			a.Set(k, String("a"))
+			merged.Set(k, String("a"))
+		case i%3 == 0:
+			b.Set(k, String("b"))
+			merged.Set(k, String("b"))
+		}
+	}
+	am, bm := a.Map(), b.Map()
+	assert.True(am.asSequence().treeLevel() > 0, "expected a chunked map")
+
+	assert.True(merged.Map().Equals(am.Merge(bm)))
+	assert.True(intersection.Map().Equals(am.Intersect(bm)))
+
+	// Maps that share most of their chunks.
+	other := am.Edit().Set(Number(1), String("b")).Set(Number(2), String("b")).Remove(Number(4)).Map()
+	assert.True(am.Edit().Set(Number(1), String("b")).Set(Number(2), String("b")).Map().Equals(am.Merge(other)))
+	assert.True(am.Edit().Remove(Number(4)).Map().Equals(am.Intersect(other)))
+	assert.True(am.Equals(other.Merge(am).Intersect(am)))
+
+	empty := NewMap(vs)
+	assert.True(am.Equals(am.Merge(empty)))
+	assert.True(am.Equals(empty.Merge(am)))
+	assert.True(empty.Equals(am.Intersect(empty)))
+	assert.True(am.Equals(am.Intersect(am)))
+}
+
+func TestMapMergeIntersectSizes(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+
+	bigEdit := NewMap(vs).Edit()
+	for i := 0; i < 20000; i++ {
+		bigEdit.Set(Number(i), String("big"))
+	}
+	big := bigEdit.Map()
+	for _, keys := range [][]Value{
+		{},
+		{Number(-1)},
+		{Number(-1), Number(5), Number(20000)},
+		generateNumbersAsValuesFromToBy(-100, 100, 7),
+		generateNumbersAsValuesFromToBy(-5000, 25000, 11),
+	} {
+		smallEdit := NewMap(vs).Edit()
+		for _, k := range keys {
+			smallEdit.Set(k, String("small"))
+		}
+		small := smallEdit.Map()
+
+		bigWins, smallWins := small.Edit(), big.Edit()
+		bigOnly, smallOnly := NewMap(vs).Edit(), NewMap(vs).Edit()
+		for _, k := range keys {
+			smallWins.Set(k, String("small"))
+			if big.Has(k) {
+				bigWins.Set(k, String("big"))
+				bigOnly.Set(k, String("big"))
+				smallOnly.Set(k, String("small"))
+			}
+		}
+		big.IterAll(func(k, v Value) {
+			if !small.Has(k) {
+				bigWins.Set(k, v)
+			}
+		})
+		assert.True(smallWins.Map().Equals(big.Merge(small)))
+		assert.True(bigWins.Map().Equals(small.Merge(big)))
+		assert.True(bigOnly.Map().Equals(big.Intersect(small)))
+		assert.True(smallOnly.Map().Equals(small.Intersect(big)))
+	}
+}
@@ -8,7 +8,8 @@ package types
 type NomsKind uint8
 
 // All supported kinds of Noms types are enumerated here.
-// The ordering of these (especially Bool, Number and String) is important for ordering of values.
+// The ordering of these (especially Bool, Number and String) is important for ordering of values: values of kinds that are ordered by value sort before all other values, first by kind and then by value.
+// Kinds are part of the serialization format, so new kinds must be added at the end.
 const (
 	BoolKind NomsKind = iota
 	NumberKind
@@ -29,22 +30,33 @@ const (
 
 	// Internal to decoder
 	hashKind
+
+	IntKind
+	UintKind
+	TimestampKind
+	DecimalKind
+	TupleKind
 )
 
 var KindToString = map[NomsKind]string{
-	BlobKind:   "Blob",
-	BoolKind:   "Bool",
-	CycleKind:  "Cycle",
-	ListKind:   "List",
-	MapKind:    "Map",
-	NumberKind: "Number",
-	RefKind:    "Ref",
-	SetKind:    "Set",
-	StructKind: "Struct",
-	StringKind: "String",
-	TypeKind:   "Type",
-	UnionKind:  "Union",
-	ValueKind:  "Value",
+	BlobKind:      "Blob",
+	BoolKind:      "Bool",
+	CycleKind:     "Cycle",
+	DecimalKind:   "Decimal",
+	IntKind:       "Int",
+	ListKind:      "List",
+	MapKind:       "Map",
+	NumberKind:    "Number",
+	RefKind:       "Ref",
+	SetKind:       "Set",
+	StructKind:    "Struct",
+	StringKind:    "String",
+	TimestampKind: "Timestamp",
+	TupleKind:     "Tuple",
+	TypeKind:      "Type",
+	UintKind:      "Uint",
+	UnionKind:     "Union",
+	ValueKind:     "Value",
 }
 
 // String returns the name of the kind.
@@ -52,10 +64,10 @@ func (k NomsKind) String() string {
 	return KindToString[k]
 }
 
-// IsPrimitiveKind returns true if k represents a Noms primitive type, which excludes collections (List, Map, Set), Refs, Structs, Symbolic and Unresolved types.
+// IsPrimitiveKind returns true if k represents a Noms primitive type, which excludes collections (List, Map, Set), Refs, Structs, Tuples, Symbolic and Unresolved types.
 func IsPrimitiveKind(k NomsKind) bool {
 	switch k {
-	case BoolKind, NumberKind, StringKind, BlobKind, ValueKind, TypeKind:
+	case BoolKind, NumberKind, StringKind, BlobKind, ValueKind, TypeKind, IntKind, UintKind, TimestampKind, DecimalKind:
 		return true
 	default:
 		return false
@@ -64,7 +76,17 @@ func IsPrimitiveKind(k NomsKind) bool {
 
 // isKindOrderedByValue determines if a value is ordered by its value instead of its hash.
 func isKindOrderedByValue(k NomsKind) bool {
-	return k <= StringKind
+	switch k {
+	case BoolKind, NumberKind, StringKind, IntKind, UintKind, TimestampKind, DecimalKind, TupleKind:
+		return true
+	default:
+		return false
+	}
+}
+
+// kindLess orders a value of kind |k1|, which must be ordered by value, before a value of a different kind |k2|.
+func kindLess(k1, k2 NomsKind) bool {
+	return !isKindOrderedByValue(k2) || k1 < k2
 }
 
 func (k NomsKind) writeTo(w nomsWriter) {
@@ -23,7 +23,7 @@
 //     1-byte  -- a NomsKind value that represents the type of value that is
 //                being encoded.
 //     The 1-byte NomsKind value determines what follows, if this value is
-//     BoolKind, NumberKind, or StringKind, the rest of the bytes are:
+//     a kind that is ordered by value, e.g. NumberKind, the rest of the bytes are:
 //         4-bytes -- uint32 length of the Value serialization
 //         n-bytes -- the serialized value
 //     If the NomsKind byte has any other value, it is followed by:
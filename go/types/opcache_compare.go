@@ -96,14 +96,20 @@ func compareEncodedKey(a, b []byte) int {
 		return res
 	}
 
-	// Now, we know that at least one of a and b is ordered by value. So if the
-	// kinds are different, we can sort just by comparing them.
+	// Now, we know that at least one of a and b is ordered by value, and values
+	// ordered by value sort before all others. So if the kinds are different, we
+	// can sort just by comparing them.
+	if !isKindOrderedByValue(aKind) {
+		return 1
+	} else if !isKindOrderedByValue(bKind) {
+		return -1
+	}
 	if res := compareKinds(aKind, bKind); res != 0 {
 		return res
 	}
 
-	// Now we know that we are comparing two values that are both Bools, Numbers,
-	// or Strings. Extract their length and create slices that just contain their
+	// Now we know that we are comparing two values of the same kind, which is
+	// ordered by value. Extract their length and create slices that just contain their
 	// Noms encodings.
 	lenA := binary.BigEndian.Uint32(a[1:5])
 	lenB := binary.BigEndian.Uint32(b[1:5])
@@ -142,6 +148,38 @@ func compareEncodedNomsValues(a, b []byte) int {
 			return -1
 		}
 		return 1
+	case IntKind, TimestampKind:
+		reader := binaryNomsReader{a[1:], 0}
+		aInt := reader.readInt()
+		reader.buff, reader.offset = b[1:], 0
+		bInt := reader.readInt()
+		switch {
+		case aInt < bInt:
+			return -1
+		case aInt > bInt:
+			return 1
+		}
+		return 0
+	case UintKind:
+		reader := binaryNomsReader{a[1:], 0}
+		aUint := reader.readUint()
+		reader.buff, reader.offset = b[1:], 0
+		bUint := reader.readUint()
+		switch {
+		case aUint < bUint:
+			return -1
+		case aUint > bUint:
+			return 1
+		}
+		return 0
+	case DecimalKind:
+		reader := binaryNomsReader{a[1:], 0}
+		aDec := reader.readDecimal()
+		reader.buff, reader.offset = b[1:], 0
+		bDec := reader.readDecimal()
+		return aDec.Cmp(bDec)
+	case TupleKind:
+		return compareTuples(Tuple{valueImpl{nil, a, nil}}, Tuple{valueImpl{nil, b, nil}})
 	case StringKind:
 		// Skip past uvarint-encoded string length
 		_, aCount := binary.Uvarint(a[1:])
@@ -30,6 +30,31 @@ func newCursorAtValue(seq orderedSequence, val Value, forInsertion bool, last bo
 	return newCursorAt(seq, key, forInsertion, last)
 }
 
+// newCursorBeforeValue returns a cursor at the last item of seq that is < val, or at the last item
+// of seq if val is nil. If there is no such item the cursor is positioned before the start of seq.
+func newCursorBeforeValue(seq orderedSequence, val Value) *sequenceCursor {
+	var cur *sequenceCursor
+	if val == nil {
+		cur = newCursorAtIndex(seq, seq.numLeaves())
+	} else {
+		// forInsertion descends into the last chunk if val is larger than every item, so the cursor
+		// always ends up in a leaf sequence.
+		cur = newCursorAtValue(seq, val, true, false)
+	}
+	cur.retreat()
+	return cur
+}
+
+// orderedRange is the half-open range [from, to) of values in an ordered sequence. A nil bound
+// leaves that side of the range open.
+type orderedRange struct {
+	from, to Value
+}
+
+func (r orderedRange) contains(v Value) bool {
+	return (r.from == nil || !v.Less(r.from)) && (r.to == nil || v.Less(r.to))
+}
+
 func newCursorAt(seq orderedSequence, key orderedKey, forInsertion bool, last bool) *sequenceCursor {
 	var cur *sequenceCursor
 	for {
@@ -17,6 +17,10 @@ const (
 	DiffChangeAdded DiffChangeType = iota
 	DiffChangeRemoved
 	DiffChangeModified
+	// DiffChangeMoved is never reported by the diffs in this package, but
+	// diff.Diff can report a value that was removed and added back elsewhere
+	// in the same collection as moved.
+	DiffChangeMoved
 )
 
 type ValueChanged struct {
@@ -247,3 +251,90 @@ func doFastForward(allowPastEnd bool, a *sequenceCursor, b *sequenceCursor) (aHa
 func isCurrentEqual(a *sequenceCursor, b *sequenceCursor) bool {
 	return a.seq.getCompareFn(b.seq)(a.idx, b.idx)
 }
+
+// walkOrderedSequences walks cursors over |a| and |b| together, in key order, and calls |cb| for
+// each key at which they differ: the key is only in |a| if |bItem| is nil, only in |b| if |aItem| is
+// nil, and in both with different values otherwise. |aCur| is at the key in |a|, or where it would
+// be inserted into |a|. Runs of identical items, including whole subtrees that |a| and |b| share, are
+// skipped without being read.
+func walkOrderedSequences(a, b orderedSequence, cb func(aCur *sequenceCursor, aItem, bItem sequenceItem)) {
+	aCur := newCursorAt(a, emptyKey, false, false)
+	bCur := newCursorAt(b, emptyKey, false, false)
+
+	for aCur.valid() && bCur.valid() {
+		fastForward(aCur, bCur)
+
+		for aCur.valid() && bCur.valid() && !isCurrentEqual(aCur, bCur) {
+			aKey := getCurrentKey(aCur)
+			bKey := getCurrentKey(bCur)
+			if bKey.Less(aKey) {
+				cb(aCur, nil, bCur.current())
+				bCur.advance()
+			} else if aKey.Less(bKey) {
+				cb(aCur, aCur.current(), nil)
+				aCur.advance()
+			} else {
+				cb(aCur, aCur.current(), bCur.current())
+				aCur.advance()
+				bCur.advance()
+			}
+		}
+	}
+
+	for aCur.valid() {
+		cb(aCur, aCur.current(), nil)
+		aCur.advance()
+	}
+	for bCur.valid() {
+		cb(aCur, nil, bCur.current())
+		bCur.advance()
+	}
+}
+
+// mergeAction is what mergeOrderedSequences puts at a key whose items differ between the base and
+// the other sequence.
+type mergeAction uint8
+
+const (
+	mergeKeepBase mergeAction = iota
+	mergeUseOther
+	mergeDrop
+)
+
+// mergeOrderedSequences returns |base| with the item at each key where it differs from |other|
+// replaced as |fn| decides. |baseItem| or |otherItem| is nil if the key is only in the other
+// sequence; keeping the base item of a key that isn't in |base| leaves it out. Items that are
+// identical in both sequences are kept. The result is built by resuming chunking of |base| at each
+// key that changes, so the chunks of |base| around and between them, and in particular every
+// subtree it shares with |other|, are reused as they are.
+func mergeOrderedSequences(base, other orderedSequence, newChunker newSequenceChunkerFn, fn func(baseItem, otherItem sequenceItem) mergeAction) orderedSequence {
+	if base.Hash() == other.Hash() {
+		return base
+	}
+
+	vrw := base.valueReadWriter()
+	var ch *sequenceChunker
+	walkOrderedSequences(base, other, func(cur *sequenceCursor, baseItem, otherItem sequenceItem) {
+		action := fn(baseItem, otherItem)
+		if action == mergeKeepBase || (baseItem == nil && action == mergeDrop) {
+			return
+		}
+
+		if ch == nil {
+			ch = newChunker(cur.clone(), vrw)
+		} else {
+			ch.advanceTo(cur.clone())
+		}
+		if baseItem != nil {
+			ch.Skip()
+		}
+		if action == mergeUseOther {
+			ch.Append(otherItem)
+		}
+	})
+
+	if ch == nil {
+		return base
+	}
+	return ch.Done().(orderedSequence)
+}
@@ -65,6 +65,15 @@ func constructPath(p Path, str string) (Path, error) {
 
 	switch op {
 	case '.':
+		if strings.HasPrefix(tail, ".") {
+			idx := fieldNameComponentRe.FindIndex([]byte(tail[1:]))
+			if idx == nil {
+				return Path{}, errors.New("Invalid field: " + tail[1:])
+			}
+			p = append(p, DescendantFieldPath{tail[1 : idx[1]+1]})
+			return constructPath(p, tail[idx[1]+1:])
+		}
+
 		idx := fieldNameComponentRe.FindIndex([]byte(tail))
 		if idx == nil {
 			return Path{}, errors.New("Invalid field: " + tail)
@@ -77,6 +86,29 @@ func constructPath(p Path, str string) (Path, error) {
 			return Path{}, errors.New("Path ends in [")
 		}
 
+		if strings.HasPrefix(tail, "*]") {
+			return constructPath(append(p, WildcardPath{}), tail[2:])
+		}
+
+		if strings.HasPrefix(tail, "?(") {
+			pp, rem, err := parsePredicatePath(tail[2:])
+			if err != nil {
+				return Path{}, err
+			}
+			return constructPath(append(p, pp), rem)
+		}
+
+		if parts := sliceRe.FindStringSubmatch(tail); parts != nil {
+			sp := SlicePath{ToEnd: parts[2] == ""}
+			if parts[1] != "" {
+				sp.Start, _ = strconv.ParseInt(parts[1], 10, 64)
+			}
+			if parts[2] != "" {
+				sp.End, _ = strconv.ParseInt(parts[2], 10, 64)
+			}
+			return constructPath(append(p, sp), tail[len(parts[0]):])
+		}
+
 		idx, h, rem, err := ParsePathIndex(tail)
 		if err != nil {
 			return Path{}, err
@@ -148,7 +180,16 @@ func constructPath(p Path, str string) (Path, error) {
 
 // Resolve resolves a path relative to some value.
 // A ValueReader is required to resolve paths that contain the @target annotation.
+// If the path is multi-valued, Resolve returns the first value ResolveAll would.
 func (p Path) Resolve(v Value, vr ValueReader) (resolved Value) {
+	if p.IsMultiValued() {
+		p.ResolveAll(v, vr, func(_ Path, mv Value) bool {
+			resolved = mv
+			return true
+		})
+		return
+	}
+
 	resolved = v
 	for _, part := range p {
 		if resolved == nil {
@@ -165,7 +206,8 @@ func (p Path) Equals(o Path) bool {
 		return false
 	}
 	for i, pp := range p {
-		if pp != o[i] {
+		// PredicatePath isn't comparable, but every part has a unique spelling.
+		if pp.String() != o[i].String() {
 			return false
 		}
 	}
@@ -221,7 +263,7 @@ func (fp FieldPath) String() string {
 	return fmt.Sprintf(".%s", fp.Name)
 }
 
-// IndexPath ndexes into Maps and Lists by key or index.
+// IndexPath ndexes into Maps and Lists by key or index, and into Sets by value.
 type IndexPath struct {
 	// The value of the index, e.g. `[42]` or `["value"]`. If Index is a negative
 	// number and the path is resolved in a List, it means index from the back.
@@ -276,6 +318,8 @@ func (ip IndexPath) Resolve(v Value, vr ValueReader) Value {
 	switch v := v.(type) {
 	case List:
 		return seqIndex(func(i uint64) Value { return v.Get(i) })
+	case Tuple:
+		return seqIndex(func(i uint64) Value { return v.Get(i) })
 	case *Type:
 		if cd, ok := v.Desc.(CompoundDesc); ok {
 			return seqIndex(func(i uint64) Value { return cd.ElemTypes[i] })
@@ -287,6 +331,10 @@ func (ip IndexPath) Resolve(v Value, vr ValueReader) Value {
 		if v.Has(ip.Index) {
 			return ip.Index
 		}
+	case Set:
+		if v.Has(ip.Index) {
+			return ip.Index
+		}
 	}
 
 	return nil
@@ -550,6 +598,8 @@ func getAbsoluteIndex(v Value, relIdx int64) (absIdx uint64, ok bool) {
 	switch v := v.(type) {
 	case Collection:
 		l = v.Len()
+	case Tuple:
+		l = v.Len()
 	case *Type:
 		if cd, cdOK := v.Desc.(CompoundDesc); cdOK {
 			l = uint64(len(cd.ElemTypes))
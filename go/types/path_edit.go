@@ -0,0 +1,294 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/attic-labs/noms/go/d"
+)
+
+type pathEditFn func(parent Value, part PathPart, at Path) (Value, error)
+
+// SetAtPath returns a copy of root in which the value at p is newValue. Every
+// ancestor of that value is rebuilt using the editor for its kind:
+//
+//   .field           sets or adds the field of a Struct
+//   [index], @at(i)  sets the element of a List, or appends to it if the index
+//                    is the length of the List
+//   [key], [#hash]   sets the value of a Map entry, adding it if needed
+//   [key]@key        changes the key of a Map entry, keeping its value
+//   [value], [#hash] replaces a member of a Set
+//   @target          writes newValue to vrw and replaces the Ref with a Ref to it
+//
+// If p is multi-valued, every value it matches is set. It's an error if any
+// part of p but the last doesn't resolve.
+func SetAtPath(root Value, p Path, newValue Value, vrw ValueReadWriter) (Value, error) {
+	d.PanicIfTrue(newValue == nil)
+	if p.IsEmpty() {
+		return newValue, nil
+	}
+	return editAtPath(root, p, vrw, func(parent Value, part PathPart, at Path) (Value, error) {
+		return setPathPart(parent, part, newValue, at, vrw)
+	})
+}
+
+// RemoveAtPath returns a copy of root without the value at p, which must
+// exist: a field is deleted from its Struct, an element from its List, an
+// entry from its Map and a member from its Set. Every ancestor of the value is
+// rebuilt as in SetAtPath. If p is multi-valued, every value it matches is
+// removed.
+func RemoveAtPath(root Value, p Path, vrw ValueReadWriter) (Value, error) {
+	if p.IsEmpty() {
+		return nil, errors.New("Cannot remove the root value")
+	}
+	return editAtPath(root, p, vrw, func(parent Value, part PathPart, at Path) (Value, error) {
+		if part.Resolve(parent, vrw) == nil {
+			return nil, fmt.Errorf("No value at %s", at)
+		}
+		return removePathPart(parent, part, at)
+	})
+}
+
+func editAtPath(root Value, p Path, vrw ValueReadWriter, edit pathEditFn) (Value, error) {
+	if !p.IsMultiValued() {
+		return editAtConcretePath(root, p, Path{}, vrw, edit)
+	}
+
+	paths := []Path{}
+	p.ResolveAll(root, vrw, func(cp Path, v Value) bool {
+		paths = append(paths, indexByKey(root, cp, vrw))
+		return false
+	})
+	// Edit the last match first so that removing a List element doesn't shift
+	// the indices of the matches before it. Set and Map matches are indexed by
+	// key, since their order changes when a member or key is replaced.
+	for i := len(paths) - 1; i >= 0; i-- {
+		var err error
+		root, err = editAtConcretePath(root, paths[i], Path{}, vrw, edit)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return root, nil
+}
+
+// indexByKey returns a copy of the concrete path p in which every @at
+// annotation into a Set or Map is replaced by the equivalent index by key.
+func indexByKey(v Value, p Path, vr ValueReader) Path {
+	res := make(Path, len(p))
+	for i, part := range p {
+		res[i] = part
+		if at, ok := part.(AtAnnotation); ok {
+			switch col := v.(type) {
+			case Set:
+				res[i] = pathIndexFor(col.At(mustAbsoluteIndex(col, at.Index)), false)
+			case Map:
+				k, _ := col.At(mustAbsoluteIndex(col, at.Index))
+				res[i] = pathIndexFor(k, at.IntoKey)
+			}
+		}
+		if i < len(p)-1 {
+			v = part.Resolve(v, vr)
+		}
+	}
+	return res
+}
+
+func editAtConcretePath(v Value, p, at Path, vrw ValueReadWriter, edit pathEditFn) (Value, error) {
+	at = at.Append(p[0])
+	if len(p) == 1 {
+		return edit(v, p[0], at)
+	}
+
+	child := p[0].Resolve(v, vrw)
+	if child == nil {
+		return nil, fmt.Errorf("No value at %s", at)
+	}
+	newChild, err := editAtConcretePath(child, p[1:], at, vrw, edit)
+	if err != nil {
+		return nil, err
+	}
+	return setPathPart(v, p[0], newChild, at, vrw)
+}
+
+func setPathPart(parent Value, part PathPart, nv Value, at Path, vrw ValueReadWriter) (Value, error) {
+	switch part := part.(type) {
+	case FieldPath:
+		if s, ok := parent.(Struct); ok {
+			return s.Set(part.Name, nv), nil
+		}
+	case IndexPath:
+		switch parent := parent.(type) {
+		case List, Tuple:
+			if part.IntoKey {
+				return nil, fmt.Errorf("Cannot change the index at %s", at)
+			}
+			if n, ok := part.Index.(Number); ok && float64(n) == float64(int64(n)) {
+				return setAtIndex(parent, int64(n), nv, at)
+			}
+		case Map:
+			if part.IntoKey {
+				return rekeyMapEntry(parent, part.Index, nv, at)
+			}
+			return parent.Edit().Set(part.Index, nv).Map(), nil
+		case Set:
+			return replaceSetMember(parent, part.Index, nv, at)
+		}
+	case HashIndexPath:
+		key := NewHashIndexIntoKeyPath(part.Hash).Resolve(parent, vrw)
+		switch parent := parent.(type) {
+		case Map:
+			if part.IntoKey {
+				return rekeyMapEntry(parent, key, nv, at)
+			}
+			if key == nil {
+				return nil, fmt.Errorf("No value at %s", at)
+			}
+			return parent.Edit().Set(key, nv).Map(), nil
+		case Set:
+			return replaceSetMember(parent, key, nv, at)
+		}
+	case AtAnnotation:
+		switch parent := parent.(type) {
+		case List, Tuple:
+			if part.IntoKey {
+				return nil, fmt.Errorf("Cannot change the index at %s", at)
+			}
+			return setAtIndex(parent, part.Index, nv, at)
+		case Map, Set:
+			ai, ok := getAbsoluteIndex(parent, part.Index)
+			if !ok {
+				return nil, fmt.Errorf("No value at %s", at)
+			}
+			if m, ok := parent.(Map); ok {
+				k, _ := m.At(ai)
+				if part.IntoKey {
+					return rekeyMapEntry(m, k, nv, at)
+				}
+				return m.Edit().Set(k, nv).Map(), nil
+			}
+			s := parent.(Set)
+			return replaceSetMember(s, s.At(ai), nv, at)
+		}
+	case TargetAnnotation:
+		if _, ok := parent.(Ref); ok {
+			if vrw == nil {
+				d.Panic("@target annotation requires a database to edit")
+			}
+			return vrw.WriteValue(nv), nil
+		}
+	}
+	return nil, fmt.Errorf("Cannot set %s in %s", at, parent.Kind())
+}
+
+func removePathPart(parent Value, part PathPart, at Path) (Value, error) {
+	switch part := part.(type) {
+	case FieldPath:
+		if s, ok := parent.(Struct); ok {
+			return s.Delete(part.Name), nil
+		}
+	case IndexPath:
+		switch parent := parent.(type) {
+		case List, Tuple:
+			return removeAtIndex(parent, int64(part.Index.(Number)))
+		case Map:
+			return parent.Edit().Remove(part.Index).Map(), nil
+		case Set:
+			return parent.Edit().Remove(part.Index).Set(), nil
+		}
+	case HashIndexPath:
+		key := NewHashIndexIntoKeyPath(part.Hash).Resolve(parent, nil)
+		switch parent := parent.(type) {
+		case Map:
+			return parent.Edit().Remove(key).Map(), nil
+		case Set:
+			return parent.Edit().Remove(key).Set(), nil
+		}
+	case AtAnnotation:
+		switch parent := parent.(type) {
+		case List, Tuple:
+			return removeAtIndex(parent, part.Index)
+		case Map:
+			k, _ := parent.At(uint64(mustAbsoluteIndex(parent, part.Index)))
+			return parent.Edit().Remove(k).Map(), nil
+		case Set:
+			return parent.Edit().Remove(parent.At(uint64(mustAbsoluteIndex(parent, part.Index)))).Set(), nil
+		}
+	}
+	return nil, fmt.Errorf("Cannot remove %s from %s", at, parent.Kind())
+}
+
+// setAtIndex sets the element at index of a List or Tuple. Setting the
+// element just past the end appends it.
+func setAtIndex(v Value, index int64, nv Value, at Path) (Value, error) {
+	l := uint64(0)
+	switch v := v.(type) {
+	case List:
+		l = v.Len()
+	case Tuple:
+		l = v.Len()
+	}
+
+	ai, ok := getAbsoluteIndex(v, index)
+	if !ok && index >= 0 && uint64(index) == l {
+		ai, ok = l, true
+	}
+	if !ok {
+		return nil, fmt.Errorf("Index out of range at %s", at)
+	}
+
+	switch v := v.(type) {
+	case List:
+		if ai == l {
+			return v.Edit().Append(nv).List(), nil
+		}
+		return v.Edit().Set(ai, nv).List(), nil
+	case Tuple:
+		vs := v.Values()
+		if ai == l {
+			vs = append(vs, nv)
+		} else {
+			vs[ai] = nv
+		}
+		return NewTuple(vs...), nil
+	}
+	panic("unreachable")
+}
+
+func removeAtIndex(v Value, index int64) (Value, error) {
+	ai := mustAbsoluteIndex(v, index)
+	switch v := v.(type) {
+	case List:
+		return v.Edit().Remove(ai, ai+1).List(), nil
+	case Tuple:
+		vs := v.Values()
+		return NewTuple(append(vs[:ai], vs[ai+1:]...)...), nil
+	}
+	panic("unreachable")
+}
+
+func mustAbsoluteIndex(v Value, index int64) uint64 {
+	ai, ok := getAbsoluteIndex(v, index)
+	d.PanicIfFalse(ok)
+	return ai
+}
+
+// rekeyMapEntry moves the value of the entry with key k in m to key nk.
+func rekeyMapEntry(m Map, k, nk Value, at Path) (Value, error) {
+	if k == nil || !m.Has(k) {
+		return nil, fmt.Errorf("No value at %s", at)
+	}
+	mv := m.Get(k)
+	return m.Edit().Remove(k).Set(nk, mv).Map(), nil
+}
+
+func replaceSetMember(s Set, v, nv Value, at Path) (Value, error) {
+	if v == nil || !s.Has(v) {
+		return nil, fmt.Errorf("No value at %s", at)
+	}
+	return s.Edit().Remove(v).Insert(nv).Set(), nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAtPath(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+
+	st := NewStruct("Key", StructData{"id": Number(1)})
+	root := NewStruct("Root", StructData{
+		"list":  NewList(vs, String("a"), String("b")),
+		"map":   NewMap(vs, String("k"), Number(1), st, Number(2)),
+		"set":   NewSet(vs, Number(1), Number(2)),
+		"tuple": NewTuple(String("x"), Number(1)),
+		"nested": NewStruct("", StructData{
+			"people": NewList(vs,
+				NewStruct("Person", StructData{"name": String("ann"), "age": Number(25)}),
+				NewStruct("Person", StructData{"name": String("bob"), "age": Number(35)}),
+			),
+		}),
+	})
+
+	test := func(path string, nv Value, expect Value) {
+		actual, err := SetAtPath(root, MustParsePath(path), nv, vs)
+		assert.NoError(err, path)
+		assert.True(expect.Equals(MustParsePath(path).Resolve(actual, vs)), path)
+
+		// Everything else is untouched.
+		if old := MustParsePath(path).Resolve(root, vs); old != nil {
+			restored, err := SetAtPath(actual, MustParsePath(path), old, vs)
+			assert.NoError(err, path)
+			assert.True(root.Equals(restored), path)
+		}
+	}
+
+	test(".list[1]", String("c"), String("c"))
+	test(".list[-1]", String("c"), String("c"))
+	test(".list@at(0)", String("c"), String("c"))
+	test(`.map["k"]`, Number(42), Number(42))
+	test(".map"+hashIdx(st), Number(42), Number(42))
+	test(".tuple[0]", String("y"), String("y"))
+	test(".nested.people[1].age", Number(36), Number(36))
+	test(".nested.people[0].email", String("ann@example.com"), String("ann@example.com"))
+	test(".field", Bool(true), Bool(true))
+
+	actual, err := SetAtPath(root, MustParsePath(".list[2]"), String("c"), vs)
+	assert.NoError(err)
+	assert.True(NewList(vs, String("a"), String("b"), String("c")).Equals(MustParsePath(".list").Resolve(actual, vs)))
+
+	actual, err = SetAtPath(root, MustParsePath(`.map["k"]@key`), String("j"), vs)
+	assert.NoError(err)
+	assert.True(NewMap(vs, String("j"), Number(1), st, Number(2)).Equals(MustParsePath(".map").Resolve(actual, vs)))
+
+	actual, err = SetAtPath(root, MustParsePath(".map"+hashIdx(st)+"@key"), String("s"), vs)
+	assert.NoError(err)
+	assert.True(NewMap(vs, String("k"), Number(1), String("s"), Number(2)).Equals(MustParsePath(".map").Resolve(actual, vs)))
+
+	actual, err = SetAtPath(root, MustParsePath(".set[2]"), Number(3), vs)
+	assert.NoError(err)
+	assert.True(NewSet(vs, Number(1), Number(3)).Equals(MustParsePath(".set").Resolve(actual, vs)))
+
+	actual, err = SetAtPath(root, MustParsePath(".set@at(0)"), Number(0), vs)
+	assert.NoError(err)
+	assert.True(NewSet(vs, Number(0), Number(2)).Equals(MustParsePath(".set").Resolve(actual, vs)))
+
+	// Replacing a member reorders the Set, so the other matches are found by
+	// value rather than by index.
+	set := NewSet(vs, Number(1), Number(2), Number(3), Number(4))
+	actual, err = SetAtPath(set, MustParsePath("[1:3]"), Number(0), vs)
+	assert.NoError(err)
+	assert.True(NewSet(vs, Number(0), Number(1), Number(4)).Equals(actual))
+	actual, err = RemoveAtPath(set, MustParsePath("[1:3]"), vs)
+	assert.NoError(err)
+	assert.True(NewSet(vs, Number(1), Number(4)).Equals(actual))
+
+	actual, err = SetAtPath(root, MustParsePath(".nested.people[*].age"), Number(0), vs)
+	assert.NoError(err)
+	assert.True(NewList(vs, Number(0), Number(0)).Equals(NewList(vs, MustParsePath(".nested.people[0].age").Resolve(actual, vs), MustParsePath(".nested.people[1].age").Resolve(actual, vs))))
+
+	actual, err = SetAtPath(root, MustParsePath(".nested.people[?(.age > 100)].age"), Number(0), vs)
+	assert.NoError(err)
+	assert.True(root.Equals(actual))
+
+	actual, err = SetAtPath(root, Path{}, Number(42), vs)
+	assert.NoError(err)
+	assert.True(Number(42).Equals(actual))
+
+	// Write through a Ref.
+	r := vs.WriteValue(NewStruct("", StructData{"n": Number(1)}))
+	withRef := NewStruct("", StructData{"ref": r})
+	actual, err = SetAtPath(withRef, MustParsePath(".ref@target.n"), Number(2), vs)
+	assert.NoError(err)
+	assert.True(Number(2).Equals(MustParsePath(".ref@target.n").Resolve(actual, vs)))
+	assert.True(Number(1).Equals(MustParsePath(".ref@target.n").Resolve(withRef, vs)))
+
+	errTest := func(path, expectError string) {
+		_, err := SetAtPath(root, MustParsePath(path), Number(1), vs)
+		if assert.Error(err, path) {
+			assert.Equal(expectError, err.Error())
+		}
+	}
+	errTest(".nothing.foo", "No value at .nothing")
+	errTest(".list[3]", "Index out of range at .list[3]")
+	errTest(".list[0]@key", "Cannot change the index at .list[0]@key")
+	errTest(".list.foo", "Cannot set .list.foo in List")
+	errTest(`.map["j"]@key`, `No value at .map["j"]@key`)
+	errTest(".set[3]", "No value at .set[3]")
+	errTest(".list@type", "Cannot set .list@type in List")
+}
+
+func TestRemoveAtPath(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+
+	st := NewStruct("Key", StructData{"id": Number(1)})
+	root := NewStruct("Root", StructData{
+		"list":  NewList(vs, String("a"), String("b"), String("c")),
+		"map":   NewMap(vs, String("k"), Number(1), st, Number(2)),
+		"set":   NewSet(vs, Number(1), st),
+		"tuple": NewTuple(String("x"), Number(1)),
+	})
+
+	test := func(path, parentPath string, expect Value) {
+		actual, err := RemoveAtPath(root, MustParsePath(path), vs)
+		assert.NoError(err, path)
+		assert.True(expect.Equals(MustParsePath(parentPath).Resolve(actual, vs)), path)
+	}
+
+	test(".list[1]", ".list", NewList(vs, String("a"), String("c")))
+	test(".list@at(-1)", ".list", NewList(vs, String("a"), String("b")))
+	test(".list[?(@ != \"b\")]", ".list", NewList(vs, String("b")))
+	test(".list[:2]", ".list", NewList(vs, String("c")))
+	test(`.map["k"]`, ".map", NewMap(vs, st, Number(2)))
+	test(`.map["k"]@key`, ".map", NewMap(vs, st, Number(2)))
+	test(".map"+hashIdx(st), ".map", NewMap(vs, String("k"), Number(1)))
+	test(".map@at(0)", ".map", NewMap(vs, st, Number(2)))
+	test(".set[1]", ".set", NewSet(vs, st))
+	test(".set"+hashIdx(st), ".set", NewSet(vs, Number(1)))
+	test(".tuple[0]", ".tuple", NewTuple(Number(1)))
+
+	actual, err := RemoveAtPath(root, MustParsePath(".list"), vs)
+	assert.NoError(err)
+	_, ok := actual.(Struct).MaybeGet("list")
+	assert.False(ok)
+
+	errTest := func(path, expectError string) {
+		_, err := RemoveAtPath(root, MustParsePath(path), vs)
+		if assert.Error(err, path) {
+			assert.Equal(expectError, err.Error())
+		}
+	}
+	errTest(".nothing", "No value at .nothing")
+	errTest(".list[3]", "No value at .list[3]")
+	errTest(`.map["j"]`, `No value at .map["j"]`)
+	errTest(".list@type", "Cannot remove .list@type from List")
+
+	_, err = RemoveAtPath(root, Path{}, vs)
+	assert.Error(err)
+}
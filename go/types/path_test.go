@@ -7,6 +7,7 @@ package types
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/attic-labs/noms/go/hash"
@@ -324,6 +325,22 @@ func TestPathParseSuccess(t *testing.T) {
 	test(".foo[0].bar[4.5][false]")
 	test(fmt.Sprintf(".foo[#%s]", h.String()))
 	test(fmt.Sprintf(".bar[#%s]@key", h.String()))
+	test("[*]")
+	test("[*]@key")
+	test(".rows[*].name")
+	test("..name")
+	test(".rows..name[0]")
+	test("[10:20]")
+	test("[-3:]")
+	test("[:-1]@key")
+	test("[:]")
+	test("[?(.age > 30)]")
+	test(`[?(.name == "bob")].age`)
+	test("[?(.email)]")
+	test("[?(@ >= 10)]@key")
+	test("[?([0][*] != false)]")
+	test(`[?(.name == "a)]\"<b")].age`)
+	test(`[?(["x>y"] > 1)]`)
 }
 
 func TestPathParseErrors(t *testing.T) {
@@ -389,6 +406,16 @@ func TestPathParseErrors(t *testing.T) {
 	test(".foo@at(", "@at annotation requires a position argument")
 	test(".foo@at(42", "@at annotation requires a position argument")
 	test(fmt.Sprintf(".foo[#%s]@soup", hash.Of([]byte{42}).String()), "Unsupported annotation: @soup")
+	test("..", "Invalid field: ")
+	test("..#", "Invalid field: #")
+	test("[*", "Invalid index: *")
+	test("[1:2", "Invalid index: 1:2")
+	test("[?(.age > 30]", "[?( is missing closing )]")
+	test("[?()]", "Invalid predicate: ")
+	test("[?(.age > )]", "Invalid predicate: .age > ")
+	test("[?(.age > thirty)]", "Invalid index: thirty")
+	test(fmt.Sprintf("[?(.id == #%s)]", hash.Of([]byte{42}).String()), "Invalid operand: #"+hash.Of([]byte{42}).String())
+	test("[?(age > 30)]", "Invalid operator: a")
 }
 
 func TestPathEquals(t *testing.T) {
@@ -398,12 +425,17 @@ func TestPathEquals(t *testing.T) {
 		`["one"]`,
 		`.two.three`,
 		`["yo"]@key`,
+		`[*].name`,
+		`[?(.age > 30)]`,
 	}
 	notEqualPaths := [][]string{
 		{`[1]`, `[2]`},
 		{`["one"]`, `["two"]`},
 		{`.two.three`, `.two.four`},
 		{`["yo"]@key`, `["yo"]`},
+		{`[*]`, `[*]@key`},
+		{`[?(.age > 30)]`, `[?(.age >= 30)]`},
+		{`[1:]`, `[1:2]`},
 	}
 
 	assert.True(Path{}.Equals(Path{}))
@@ -579,3 +611,158 @@ func TestPathAtAnnotation(t *testing.T) {
 	resolvesTo(Number(4.5), Number(2.3), "@at(-2)")
 	resolvesTo(String("bar"), String("two"), `@at(-1)`)
 }
+
+func assertResolvesAll(assert *assert.Assertions, ref Value, str string, expectPaths []string, expectValues ValueSlice) {
+	p, err := ParsePath(str)
+	assert.NoError(err)
+	assert.True(p.IsMultiValued(), str)
+
+	paths := []string{}
+	values := ValueSlice{}
+	p.ResolveAll(ref, nil, func(cp Path, v Value) bool {
+		assert.False(cp.IsMultiValued())
+		assert.True(v.Equals(cp.Resolve(ref, nil)), "%s doesn't resolve to %s", cp.String(), EncodedValue(v))
+		paths = append(paths, cp.String())
+		values = append(values, v)
+		return false
+	})
+	assert.Equal(expectPaths, paths, str)
+	assert.True(expectValues.Equals(values), "%s: expected %s, got %s", str, EncodedValue(NewTuple(expectValues...)), EncodedValue(NewTuple(values...)))
+}
+
+func TestPathWildcard(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+
+	person := func(name string, age int) Struct {
+		return NewStruct("Person", StructData{"name": String(name), "age": Number(age)})
+	}
+	l := NewList(vs, person("ann", 25), person("bob", 35), person("cat", 45))
+	v := NewStruct("", StructData{"rows": l})
+
+	assertResolvesAll(assert, v, ".rows[*].name",
+		[]string{".rows[0].name", ".rows[1].name", ".rows[2].name"},
+		ValueSlice{String("ann"), String("bob"), String("cat")})
+	assertResolvesAll(assert, v, ".rows[*]@key",
+		[]string{".rows[0]@key", ".rows[1]@key", ".rows[2]@key"},
+		ValueSlice{Number(0), Number(1), Number(2)})
+	assertResolvesAll(assert, person("ann", 25), "[*]",
+		[]string{".age", ".name"},
+		ValueSlice{Number(25), String("ann")})
+
+	m := NewMap(vs, String("a"), Number(1), String("b"), Number(2))
+	assertResolvesAll(assert, m, "[*]", []string{`["a"]`, `["b"]`}, ValueSlice{Number(1), Number(2)})
+	assertResolvesAll(assert, m, "[*]@key", []string{`["a"]@key`, `["b"]@key`}, ValueSlice{String("a"), String("b")})
+
+	st := NewStruct("", StructData{})
+	m2 := NewMap(vs, st, String("x"))
+	assertResolvesAll(assert, m2, "[*]@key", []string{hashIdx(st) + "@key"}, ValueSlice{st})
+
+	s := NewSet(vs, Number(1), st)
+	assertResolvesAll(assert, s, "[*]", []string{"[1]", hashIdx(st)}, ValueSlice{Number(1), st})
+
+	assertResolvesAll(assert, NewTuple(String("a"), Bool(true)), "[*]", []string{"[0]", "[1]"}, ValueSlice{String("a"), Bool(true)})
+	assertResolvesAll(assert, Number(42), "[*]", []string{}, ValueSlice{})
+
+	// Resolve returns the first match.
+	assertResolvesTo(assert, String("ann"), v, ".rows[*].name")
+	assertResolvesTo(assert, nil, v, ".rows[*].nothing")
+
+	// Stop after the first match.
+	cnt := 0
+	MustParsePath(".rows[*]").ResolveAll(v, nil, func(p Path, v Value) bool {
+		cnt++
+		return true
+	})
+	assert.Equal(1, cnt)
+}
+
+func TestPathSlice(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+
+	l := NewList(vs, generateNumbersAsValues(5)...)
+	assertResolvesAll(assert, l, "[1:3]", []string{"[1]", "[2]"}, ValueSlice{Number(1), Number(2)})
+	assertResolvesAll(assert, l, "[3:]", []string{"[3]", "[4]"}, ValueSlice{Number(3), Number(4)})
+	assertResolvesAll(assert, l, "[:2]@key", []string{"[0]@key", "[1]@key"}, ValueSlice{Number(0), Number(1)})
+	assertResolvesAll(assert, l, "[-2:]", []string{"[3]", "[4]"}, ValueSlice{Number(3), Number(4)})
+	assertResolvesAll(assert, l, "[-10:1]", []string{"[0]"}, ValueSlice{Number(0)})
+	assertResolvesAll(assert, l, "[4:100]", []string{"[4]"}, ValueSlice{Number(4)})
+	assertResolvesAll(assert, l, "[3:1]", []string{}, ValueSlice{})
+
+	s := NewSet(vs, String("a"), String("b"), String("c"))
+	assertResolvesAll(assert, s, "[1:]", []string{"@at(1)", "@at(2)"}, ValueSlice{String("b"), String("c")})
+
+	m := NewMap(vs, String("a"), Number(1), String("b"), Number(2), String("c"), Number(3))
+	assertResolvesAll(assert, m, "[:-1]", []string{"@at(0)", "@at(1)"}, ValueSlice{Number(1), Number(2)})
+	assertResolvesAll(assert, m, "[:-1]@key", []string{"@at(0)@key", "@at(1)@key"}, ValueSlice{String("a"), String("b")})
+
+	assertResolvesAll(assert, NewTuple(String("a"), Bool(true), Number(1)), "[1:]", []string{"[1]", "[2]"}, ValueSlice{Bool(true), Number(1)})
+
+	// Slices over chunked lists.
+	l = NewList(vs, generateNumbersAsValues(5000)...)
+	assertResolvesAll(assert, l, "[4000:4003]", []string{"[4000]", "[4001]", "[4002]"}, ValueSlice{Number(4000), Number(4001), Number(4002)})
+}
+
+func TestPathPredicate(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+
+	person := func(name string, age Value) Struct {
+		return NewStruct("Person", StructData{"name": String(name), "age": age})
+	}
+	l := NewList(vs, person("ann", Number(25)), person("bob", Number(35)), person("cat", Int(45)), NewStruct("Robot", StructData{"name": String("r2")}))
+
+	assertResolvesAll(assert, l, "[?(.age > 30)].name", []string{"[1].name", "[2].name"}, ValueSlice{String("bob"), String("cat")})
+	assertResolvesAll(assert, l, "[?(.age <= 35)].name", []string{"[0].name", "[1].name"}, ValueSlice{String("ann"), String("bob")})
+	assertResolvesAll(assert, l, `[?(.name == "ann")].age`, []string{"[0].age"}, ValueSlice{Number(25)})
+	assertResolvesAll(assert, l, `[?(.name != "ann")]@key`, []string{"[1]@key", "[2]@key", "[3]@key"}, ValueSlice{Number(1), Number(2), Number(3)})
+	assertResolvesAll(assert, l, "[?(.age)].name", []string{"[0].name", "[1].name", "[2].name"}, ValueSlice{String("ann"), String("bob"), String("cat")})
+	assertResolvesAll(assert, l, `[?(.name > 30)]`, []string{}, ValueSlice{})
+
+	m := NewMap(vs, String("a"), Number(1), String("b"), Number(20), String("c"), Number(3))
+	assertResolvesAll(assert, m, "[?(@ >= 3)]@key", []string{`["b"]@key`, `["c"]@key`}, ValueSlice{String("b"), String("c")})
+	assertResolvesAll(assert, m, "[?(@ < 3)]", []string{`["a"]`}, ValueSlice{Number(1)})
+
+	// A multi-valued condition holds if any of its values match.
+	nested := NewList(vs, NewList(vs, Number(1), Number(2)), NewList(vs, Number(3)))
+	assertResolvesAll(assert, nested, "[?([*] == 2)]", []string{"[0]"}, ValueSlice{NewList(vs, Number(1), Number(2))})
+
+	// Quoted strings can contain )] and operators.
+	l = NewList(vs, person("a)]b", Number(1)), person("x<y", Number(2)))
+	assertResolvesAll(assert, l, `[?(.name == "a)]b")].age`, []string{"[0].age"}, ValueSlice{Number(1)})
+	assertResolvesAll(assert, l, `[?(.name != "x<y")].age`, []string{"[0].age"}, ValueSlice{Number(1)})
+	m = NewMap(vs, String("a>b"), Number(1))
+	assertResolvesAll(assert, NewList(vs, m), `[?(["a>b"] == 1)]`, []string{"[0]"}, ValueSlice{m})
+
+	// Integers are compared exactly.
+	l = NewList(vs, Int(1<<53+1), Number(1<<53), Uint(1<<53))
+	assertResolvesAll(assert, l, "[?(@ > 9007199254740992)]", []string{"[0]"}, ValueSlice{Int(1<<53 + 1)})
+	assertResolvesAll(assert, l, "[?(@ == 9007199254740992)]", []string{"[1]", "[2]"}, ValueSlice{Number(1 << 53), Uint(1 << 53)})
+	assert.True(compareWithOp(Uint(math.MaxUint64), ">", Int(-1)))
+	assert.True(compareWithOp(Int(math.MaxInt64), "<", Uint(math.MaxInt64+1)))
+	assert.False(compareWithOp(Number(math.NaN()), "<", Int(1)))
+}
+
+func TestPathDescendantField(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+
+	leaf := NewStruct("", StructData{"name": String("leaf")})
+	v := NewStruct("", StructData{
+		"name": String("root"),
+		"kids": NewList(vs,
+			NewStruct("", StructData{"name": String("kid"), "pet": leaf}),
+			NewMap(vs, String("k"), leaf),
+		),
+	})
+
+	assertResolvesAll(assert, v, "..name",
+		[]string{".name", ".kids[0].name", ".kids[0].pet.name", `.kids[1]["k"].name`},
+		ValueSlice{String("root"), String("kid"), String("leaf"), String("leaf")})
+	assertResolvesAll(assert, v, ".kids..name",
+		[]string{".kids[0].name", ".kids[0].pet.name", `.kids[1]["k"].name`},
+		ValueSlice{String("kid"), String("leaf"), String("leaf")})
+	assertResolvesAll(assert, v, "..pet..name", []string{".kids[0].pet.name"}, ValueSlice{String("leaf")})
+	assertResolvesAll(assert, v, "..nothing", []string{}, ValueSlice{})
+}
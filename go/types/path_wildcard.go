@@ -0,0 +1,504 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/attic-labs/noms/go/hash"
+)
+
+// For a slice like [10:20], 1st capture group is the start and 2nd is the end.
+var sliceRe = regexp.MustCompile(`^(-?\d*):(-?\d*)\]`)
+
+// The predicate operators, two-character ones first so that they're preferred
+// when scanning for one.
+var predicateOps = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// multiValuedPathPart is a PathPart that can resolve to any number of values.
+type multiValuedPathPart interface {
+	PathPart
+	// resolveAll calls cb with each value the part resolves to relative to v,
+	// together with the Path from v to that value. It returns true if cb
+	// stopped the iteration.
+	resolveAll(v Value, vr ValueReader, cb pathMatchCallback) (stop bool)
+}
+
+type pathMatchCallback func(p Path, v Value) (stop bool)
+
+// ResolveAll calls cb with every value that p resolves to relative to v, in
+// order, together with the concrete Path to that value. The concrete path
+// contains no wildcards, slices, predicates or recursive descents, so it
+// resolves to exactly that value. If cb returns true the iteration stops.
+// A ValueReader is required to resolve paths that contain the @target
+// annotation.
+func (p Path) ResolveAll(v Value, vr ValueReader, cb func(p Path, v Value) (stop bool)) {
+	resolveAll(p, Path{}, v, vr, cb)
+}
+
+func resolveAll(p, prefix Path, v Value, vr ValueReader, cb pathMatchCallback) bool {
+	if len(p) == 0 {
+		return cb(prefix, v)
+	}
+	if mp, ok := p[0].(multiValuedPathPart); ok {
+		return mp.resolveAll(v, vr, func(sub Path, sv Value) bool {
+			return resolveAll(p[1:], prefix.concat(sub), sv, vr, cb)
+		})
+	}
+	sv := p[0].Resolve(v, vr)
+	if sv == nil {
+		return false
+	}
+	return resolveAll(p[1:], prefix.Append(p[0]), sv, vr, cb)
+}
+
+// IsMultiValued returns true if p contains a wildcard, slice, predicate or
+// recursive descent, and so may resolve to more than one value.
+func (p Path) IsMultiValued() bool {
+	for _, part := range p {
+		if _, ok := part.(multiValuedPathPart); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Path) concat(o Path) Path {
+	p1 := make(Path, len(p), len(p)+len(o))
+	copy(p1, p)
+	return append(p1, o...)
+}
+
+func firstMatch(mp multiValuedPathPart, v Value, vr ValueReader) (res Value) {
+	mp.resolveAll(v, vr, func(_ Path, mv Value) bool {
+		res = mv
+		return true
+	})
+	return
+}
+
+// iterChildren calls cb with each element of a List, Set, Map or Tuple, or
+// each field of a Struct, and the PathPart that resolves to it. key is the
+// index of a List or Tuple element, the key of a Map entry, the Set value
+// itself or the name of a Struct field. If intoKey is true the PathPart
+// resolves to key instead, and Structs have no children.
+func iterChildren(v Value, intoKey bool, cb func(pp PathPart, key, elem Value) (stop bool)) (stop bool) {
+	switch v := v.(type) {
+	case List:
+		v.Iter(func(elem Value, i uint64) bool {
+			stop = cb(newIndexPath(Number(i), intoKey), Number(i), elem)
+			return stop
+		})
+	case Tuple:
+		for i, elem := range v.Values() {
+			if cb(newIndexPath(Number(i), intoKey), Number(i), elem) {
+				return true
+			}
+		}
+	case Set:
+		v.Iter(func(elem Value) bool {
+			stop = cb(pathIndexFor(elem, false), elem, elem)
+			return stop
+		})
+	case Map:
+		v.Iter(func(k, mv Value) bool {
+			stop = cb(pathIndexFor(k, intoKey), k, mv)
+			return stop
+		})
+	case Struct:
+		if intoKey {
+			return
+		}
+		v.IterFields(func(name string, fv Value) bool {
+			stop = cb(NewFieldPath(name), String(name), fv)
+			return stop
+		})
+	}
+	return
+}
+
+// WildcardPath resolves to every element of a List, Set, Map or Tuple, or
+// every field value of a Struct, e.g. `[*]`.
+type WildcardPath struct {
+	// Whether the path resolves to the keys of a Map and the indices of a List
+	// or Tuple rather than to their values, given by a `@key` annotation.
+	IntoKey bool
+}
+
+func (wp WildcardPath) Resolve(v Value, vr ValueReader) Value {
+	return firstMatch(wp, v, vr)
+}
+
+func (wp WildcardPath) resolveAll(v Value, vr ValueReader, cb pathMatchCallback) bool {
+	return iterChildren(v, wp.IntoKey, func(pp PathPart, key, elem Value) bool {
+		if wp.IntoKey {
+			return cb(Path{pp}, key)
+		}
+		return cb(Path{pp}, elem)
+	})
+}
+
+func (wp WildcardPath) String() (str string) {
+	str = "[*]"
+	if wp.IntoKey {
+		str += "@key"
+	}
+	return
+}
+
+func (wp WildcardPath) setIntoKey(v bool) keyIndexable {
+	wp.IntoKey = v
+	return wp
+}
+
+// SlicePath resolves to the elements of a List or Tuple, or the entries of a
+// Set or Map, whose position is in the half-open range [Start, End), e.g.
+// `[10:20]`. Negative positions count from the end, like @at, and positions
+// past the end are clamped. An omitted start, `[:20]`, is 0 and an omitted
+// end, `[10:]`, extends the slice to the end.
+type SlicePath struct {
+	Start int64
+	End   int64
+	// Whether the end was omitted, in which case End is ignored.
+	ToEnd bool
+	// IntoKey see WildcardPath.IntoKey.
+	IntoKey bool
+}
+
+func (sp SlicePath) Resolve(v Value, vr ValueReader) Value {
+	return firstMatch(sp, v, vr)
+}
+
+// bounds returns the absolute positions of the slice in a sequence of length l.
+func (sp SlicePath) bounds(l uint64) (start, end uint64) {
+	abs := func(i int64) uint64 {
+		if i < 0 {
+			if uint64(-i) > l {
+				return 0
+			}
+			return l - uint64(-i)
+		}
+		if uint64(i) > l {
+			return l
+		}
+		return uint64(i)
+	}
+
+	start, end = abs(sp.Start), l
+	if !sp.ToEnd {
+		end = abs(sp.End)
+	}
+	if start > end {
+		start = end
+	}
+	return
+}
+
+func (sp SlicePath) resolveAll(v Value, vr ValueReader, cb pathMatchCallback) bool {
+	indexed := func(i uint64, elem Value) bool {
+		if sp.IntoKey {
+			return cb(Path{newIndexPath(Number(i), true)}, Number(i))
+		}
+		return cb(Path{newIndexPath(Number(i), false)}, elem)
+	}
+
+	switch v := v.(type) {
+	case List:
+		start, end := sp.bounds(v.Len())
+		it := v.IteratorRange(start, end)
+		for i := start; i < end; i++ {
+			if indexed(i, it.Next()) {
+				return true
+			}
+		}
+	case Tuple:
+		start, end := sp.bounds(v.Len())
+		elems := v.Values()
+		for i := start; i < end; i++ {
+			if indexed(i, elems[i]) {
+				return true
+			}
+		}
+	case Set:
+		start, end := sp.bounds(v.Len())
+		it := v.IteratorAt(start)
+		for i := start; i < end; i++ {
+			if cb(Path{NewAtAnnotation(int64(i))}, it.Next()) {
+				return true
+			}
+		}
+	case Map:
+		start, end := sp.bounds(v.Len())
+		it := v.IteratorAt(start)
+		for i := start; i < end; i++ {
+			k, mv := it.Entry()
+			if sp.IntoKey {
+				mv = k
+			}
+			if cb(Path{AtAnnotation{int64(i), sp.IntoKey}}, mv) {
+				return true
+			}
+			it.Next()
+		}
+	}
+	return false
+}
+
+func (sp SlicePath) String() (str string) {
+	str = "["
+	if sp.Start != 0 {
+		str += strconv.FormatInt(sp.Start, 10)
+	}
+	str += ":"
+	if !sp.ToEnd {
+		str += strconv.FormatInt(sp.End, 10)
+	}
+	str += "]"
+	if sp.IntoKey {
+		str += "@key"
+	}
+	return
+}
+
+func (sp SlicePath) setIntoKey(v bool) keyIndexable {
+	sp.IntoKey = v
+	return sp
+}
+
+// PredicatePath resolves to the elements of a List, Set, Map or Tuple, or the
+// field values of a Struct, for which a condition holds, e.g.
+// `[?(.age > 30)]`. The condition resolves Path relative to the element and
+// compares the result with Operand using Op, one of ==, !=, <, <=, > or >=.
+// If Path is multi-valued the condition holds if any of its values match. An
+// empty Op means the condition holds if Path resolves to anything at all, e.g.
+// `[?(.email)]`. The element itself is spelled `@`, e.g. `[?(@ >= 10)]`.
+type PredicatePath struct {
+	Path    Path
+	Op      string
+	Operand Value
+	// IntoKey see WildcardPath.IntoKey. The condition is still applied to the
+	// values.
+	IntoKey bool
+}
+
+func (pp PredicatePath) Resolve(v Value, vr ValueReader) Value {
+	return firstMatch(pp, v, vr)
+}
+
+func (pp PredicatePath) resolveAll(v Value, vr ValueReader, cb pathMatchCallback) bool {
+	return iterChildren(v, pp.IntoKey, func(part PathPart, key, elem Value) bool {
+		if !pp.holds(elem, vr) {
+			return false
+		}
+		if pp.IntoKey {
+			return cb(Path{part}, key)
+		}
+		return cb(Path{part}, elem)
+	})
+}
+
+func (pp PredicatePath) holds(elem Value, vr ValueReader) (holds bool) {
+	pp.Path.ResolveAll(elem, vr, func(_ Path, v Value) bool {
+		holds = pp.Op == "" || compareWithOp(v, pp.Op, pp.Operand)
+		return holds
+	})
+	return
+}
+
+// compareWithOp compares a and b with one of the predicate operators. Values
+// of different kinds are never ordered, except that all the number kinds can
+// be compared with each other. Such comparisons are exact, e.g. Int(1<<53+1) is
+// greater than Number(1<<53).
+func compareWithOp(a Value, op string, b Value) bool {
+	if an, bn, ok := numberValues(a, b); ok {
+		c := an.Cmp(bn)
+		switch op {
+		case "==":
+			return c == 0
+		case "!=":
+			return c != 0
+		case "<":
+			return c < 0
+		case "<=":
+			return c <= 0
+		case ">":
+			return c > 0
+		case ">=":
+			return c >= 0
+		}
+	}
+
+	switch op {
+	case "==":
+		return a.Equals(b)
+	case "!=":
+		return !a.Equals(b)
+	}
+	if a.Kind() != b.Kind() {
+		return false
+	}
+	switch op {
+	case "<":
+		return a.Less(b)
+	case "<=":
+		return !b.Less(a)
+	case ">":
+		return b.Less(a)
+	case ">=":
+		return !a.Less(b)
+	}
+	return false
+}
+
+// numberValues returns a and b as big.Floats if they're both numbers of one
+// kind or another, other than NaN.
+func numberValues(a, b Value) (an, bn *big.Float, ok bool) {
+	if an, ok = numberValue(a); ok {
+		bn, ok = numberValue(b)
+	}
+	return
+}
+
+func numberValue(v Value) (*big.Float, bool) {
+	switch v := v.(type) {
+	case Number:
+		if math.IsNaN(float64(v)) {
+			return nil, false
+		}
+		return big.NewFloat(float64(v)), true
+	case Int:
+		return new(big.Float).SetInt64(int64(v)), true
+	case Uint:
+		return new(big.Float).SetUint64(uint64(v)), true
+	}
+	return nil, false
+}
+
+func (pp PredicatePath) String() (str string) {
+	cond := pp.Path.String()
+	if cond == "" {
+		cond = "@"
+	}
+	if pp.Op != "" {
+		cond = fmt.Sprintf("%s %s %s", cond, pp.Op, EncodedIndexValue(pp.Operand))
+	}
+	str = fmt.Sprintf("[?(%s)]", cond)
+	if pp.IntoKey {
+		str += "@key"
+	}
+	return
+}
+
+func (pp PredicatePath) setIntoKey(v bool) keyIndexable {
+	pp.IntoKey = v
+	return pp
+}
+
+// parsePredicatePath parses the condition of a predicate, i.e. what follows
+// `[?(`, and returns the remainder after the closing `)]`.
+func parsePredicatePath(str string) (pp PredicatePath, rem string, err error) {
+	end := indexUnquoted(str, func(s string) bool { return strings.HasPrefix(s, ")]") })
+	if end < 0 {
+		return PredicatePath{}, "", errors.New("[?( is missing closing )]")
+	}
+	cond, rem := str[:end], str[end+2:]
+
+	pathStr := strings.TrimSpace(cond)
+	opStart := indexUnquoted(cond, func(s string) bool {
+		for _, op := range predicateOps {
+			if strings.HasPrefix(s, op) {
+				pp.Op = op
+				return true
+			}
+		}
+		return false
+	})
+	if opStart >= 0 {
+		pathStr = strings.TrimSpace(cond[:opStart])
+		operand := strings.TrimSpace(cond[opStart+len(pp.Op):])
+		if operand == "" {
+			return PredicatePath{}, "", fmt.Errorf("Invalid predicate: %s", cond)
+		}
+		var h hash.Hash
+		var opRem string
+		pp.Operand, h, opRem, err = ParsePathIndex(operand)
+		if err != nil {
+			return PredicatePath{}, "", err
+		}
+		if !h.IsEmpty() || opRem != "" {
+			return PredicatePath{}, "", fmt.Errorf("Invalid operand: %s", operand)
+		}
+	}
+
+	if pathStr == "" {
+		return PredicatePath{}, "", fmt.Errorf("Invalid predicate: %s", cond)
+	}
+	if pathStr[0] == '@' && (len(pathStr) == 1 || pathStr[1] == '.' || pathStr[1] == '[') {
+		pathStr = pathStr[1:]
+	}
+	pp.Path = Path{}
+	if pathStr != "" {
+		pp.Path, err = ParsePath(pathStr)
+		if err != nil {
+			return PredicatePath{}, "", err
+		}
+	}
+	return pp, rem, nil
+}
+
+// indexUnquoted returns the index of the first byte of str outside of a quoted
+// string for which match holds of the rest of str, or -1 if there isn't one.
+func indexUnquoted(str string, match func(s string) bool) int {
+	quoted := false
+	for i := 0; i < len(str); i++ {
+		switch {
+		case quoted && str[i] == '\\':
+			i++
+		case str[i] == '"':
+			quoted = !quoted
+		case !quoted && match(str[i:]):
+			return i
+		}
+	}
+	return -1
+}
+
+// DescendantFieldPath resolves to the values of every Struct field called Name
+// in the value it's resolved in and the values nested in it, in depth-first
+// order, e.g. `..name`. Refs are not followed.
+type DescendantFieldPath struct {
+	Name string
+}
+
+func (dp DescendantFieldPath) Resolve(v Value, vr ValueReader) Value {
+	return firstMatch(dp, v, vr)
+}
+
+func (dp DescendantFieldPath) resolveAll(v Value, vr ValueReader, cb pathMatchCallback) bool {
+	return dp.descend(v, Path{}, cb)
+}
+
+func (dp DescendantFieldPath) descend(v Value, prefix Path, cb pathMatchCallback) bool {
+	if s, ok := v.(Struct); ok {
+		if fv, ok := s.MaybeGet(dp.Name); ok {
+			if cb(prefix.Append(NewFieldPath(dp.Name)), fv) {
+				return true
+			}
+		}
+	}
+	return iterChildren(v, false, func(pp PathPart, key, elem Value) bool {
+		return dp.descend(elem, prefix.Append(pp), cb)
+	})
+}
+
+func (dp DescendantFieldPath) String() string {
+	return fmt.Sprintf("..%s", dp.Name)
+}
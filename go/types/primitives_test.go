@@ -5,7 +5,9 @@
 package types
 
 import (
+	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -15,6 +17,10 @@ func TestPrimitives(t *testing.T) {
 		Bool(true), Bool(false),
 		Number(0), Number(-1),
 		Number(-0.1), Number(0.1),
+		Int(0), Int(-1),
+		Uint(0), Uint(1),
+		Timestamp(0), Timestamp(1),
+		Decimal{}, mustParseDecimal("0.1"),
 	}
 
 	for i := range data {
@@ -35,9 +41,39 @@ func TestPrimitivesType(t *testing.T) {
 	}{
 		{Bool(false), BoolKind},
 		{Number(0), NumberKind},
+		{Int(0), IntKind},
+		{Uint(0), UintKind},
+		{Timestamp(0), TimestampKind},
+		{Decimal{}, DecimalKind},
 	}
 
 	for _, d := range data {
 		assert.True(t, TypeOf(d.v).Equals(MakePrimitiveType(d.k)))
 	}
 }
+
+func TestNewTimestamp(t *testing.T) {
+	assert := assert.New(t)
+
+	ts, err := NewTimestamp(time.Unix(42, 1))
+	assert.NoError(err)
+	assert.Equal(Timestamp(42e9+1), ts)
+	assert.True(time.Unix(42, 1).Equal(ts.Time()))
+
+	ts, err = NewTimestamp(time.Unix(0, math.MinInt64))
+	assert.NoError(err)
+	assert.Equal(Timestamp(math.MinInt64), ts)
+	ts, err = NewTimestamp(time.Unix(0, math.MaxInt64))
+	assert.NoError(err)
+	assert.Equal(Timestamp(math.MaxInt64), ts)
+
+	for _, tm := range []time.Time{
+		{},
+		time.Unix(0, math.MinInt64).Add(-1),
+		time.Unix(0, math.MaxInt64).Add(1),
+		time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		_, err := NewTimestamp(tm)
+		assert.Error(err, tm.String())
+	}
+}
@@ -7,9 +7,8 @@ package types
 import (
 	"sync"
 
+	"github.com/attic-labs/noms/go/d"
 	"github.com/attic-labs/noms/go/sloppy"
-
-	"github.com/kch42/buzhash"
 )
 
 const (
@@ -26,10 +25,12 @@ var (
 	chunkConfigMu = &sync.Mutex{}
 )
 
-func chunkingConfig() (pattern, window uint32) {
+// defaultChunkingConfig is DefaultChunkingConfig, unless a test has asked for
+// smaller chunks.
+func defaultChunkingConfig() ChunkingConfig {
 	chunkConfigMu.Lock()
 	defer chunkConfigMu.Unlock()
-	return chunkPattern, chunkWindow
+	return ChunkingConfig{TargetSize: chunkPattern + 1, Window: chunkWindow, Hash: buzHashName}
 }
 
 func smallTestChunks() {
@@ -46,9 +47,14 @@ func normalProductionChunks() {
 
 type rollingValueHasher struct {
 	bw              binaryNomsWriter
-	bz              *buzhash.BuzHash
+	newHash         NewRollingHashFn
+	rh              RollingHash
 	crossedBoundary bool
 	pattern, window uint32
+	minSize         uint64
+	maxSize         uint64
+	hashed          uint64
+	countHashed     bool
 	salt            byte
 	sl              *sloppy.Sloppy
 }
@@ -61,17 +67,29 @@ func hashValueByte(item sequenceItem, rv *rollingValueHasher) {
 	rv.HashByte(item.(byte))
 }
 
-func newRollingValueHasher(salt byte) *rollingValueHasher {
-	pattern, window := chunkingConfig()
+func newRollingValueHasher(cfg ChunkingConfig, salt byte) *rollingValueHasher {
+	cfg = cfg.withDefaults()
+	newHash, ok := rollingHashFn(cfg.Hash)
+	d.PanicIfFalse(ok)
 	w := newBinaryNomsWriter()
 
 	rv := &rollingValueHasher{
 		bw:      w,
-		bz:      buzhash.NewBuzHash(window),
-		pattern: pattern,
-		window:  window,
+		newHash: newHash,
+		rh:      newHash(cfg.Window),
+		pattern: cfg.TargetSize - 1,
+		window:  cfg.Window,
+		minSize: uint64(cfg.MinSize),
+		maxSize: uint64(cfg.MaxSize),
 		salt:    salt,
 	}
+	// Blob bytes are hashed without being written to bw, so they only count
+	// towards the size of a chunk if the config bounds it. Otherwise chunks
+	// are the same as before sizes could be configured.
+	rv.countHashed = cfg.MinSize > 0 || cfg.MaxSize > 0
+	if rv.maxSize == 0 {
+		rv.maxSize = maxChunkSize
+	}
 
 	rv.sl = sloppy.New(rv.HashByte)
 
@@ -80,18 +98,26 @@ func newRollingValueHasher(salt byte) *rollingValueHasher {
 
 func (rv *rollingValueHasher) HashByte(b byte) bool {
 	if !rv.crossedBoundary {
-		rv.bz.HashByte(b ^ rv.salt)
-		rv.crossedBoundary = (rv.bz.Sum32()&rv.pattern == rv.pattern)
-		if rv.bw.offset > maxChunkSize {
-			rv.crossedBoundary = true
+		rv.hashed++
+		sum := rv.rh.HashByte(b ^ rv.salt)
+		size, full := uint64(rv.bw.offset), false
+		if rv.countHashed {
+			if rv.hashed > size {
+				size = rv.hashed
+			}
+			full = size >= rv.maxSize
+		} else {
+			full = size > rv.maxSize
 		}
+		rv.crossedBoundary = (sum&rv.pattern == rv.pattern && size >= rv.minSize) || full
 	}
 	return rv.crossedBoundary
 }
 
 func (rv *rollingValueHasher) Reset() {
 	rv.crossedBoundary = false
-	rv.bz = buzhash.NewBuzHash(rv.window)
+	rv.rh = rv.newHash(rv.window)
+	rv.hashed = 0
 	rv.bw.reset()
 	rv.sl.Reset()
 }
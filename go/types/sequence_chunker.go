@@ -46,7 +46,7 @@ func newSequenceChunker(cur *sequenceCursor, level uint64, vrw ValueReadWriter,
 		makeChunk, parentMakeChunk,
 		true,
 		hashValueBytes,
-		newRollingValueHasher(byte(level % 256)),
+		newRollingValueHasher(chunkingConfigFor(vrw), byte(level%256)),
 		false,
 		nil,
 	}
@@ -167,10 +167,81 @@ func (s Set) IteratorFrom(val Value) SetIterator {
 	}
 }
 
+// IteratorRange returns a SetIterator over the values in the half-open range [from, to). A nil
+// from starts at the first value and a nil to ends after the last value.
+func (s Set) IteratorRange(from, to Value) SetIterator {
+	return &setIterator{
+		cursor: newCursorAtValue(s.orderedSequence, from, false, false),
+		s:      s,
+		bounds: orderedRange{from, to},
+	}
+}
+
+// ReverseIterator returns a SetIterator that returns the values of the set in descending order.
+func (s Set) ReverseIterator() SetIterator {
+	return s.ReverseIteratorRange(nil, nil)
+}
+
+// ReverseIteratorRange returns a SetIterator over the values in the half-open range [from, to), in
+// descending order. SkipTo(v) on the returned iterator moves to the next value <= v.
+func (s Set) ReverseIteratorRange(from, to Value) SetIterator {
+	return &reverseSetIterator{
+		cursor: newCursorBeforeValue(s.orderedSequence, to),
+		s:      s,
+		bounds: orderedRange{from, to},
+	}
+}
+
 func (s Set) Edit() *SetEditor {
 	return NewSetEditor(s)
 }
 
+// Union returns a Set with the values that are in s or in other. The values of the smaller set
+// that aren't in the larger one are added to it, and the chunks the two sets share are skipped.
+func (s Set) Union(other Set) Set {
+	if s.Len() < other.Len() {
+		s, other = other, s
+	}
+	return newSet(mergeOrderedSequences(s.orderedSequence, other.orderedSequence, s.newChunker, func(sItem, otherItem sequenceItem) mergeAction {
+		if sItem == nil {
+			return mergeUseOther
+		}
+		return mergeKeepBase
+	}))
+}
+
+// Intersect returns a Set with the values that are in both s and other. The values of the smaller
+// set that aren't in the larger one are removed from it, and the chunks the two sets share are
+// skipped.
+func (s Set) Intersect(other Set) Set {
+	if s.Len() > other.Len() {
+		s, other = other, s
+	}
+	return newSet(mergeOrderedSequences(s.orderedSequence, other.orderedSequence, s.newChunker, func(sItem, otherItem sequenceItem) mergeAction {
+		return mergeDrop
+	}))
+}
+
+// Difference returns a Set with the values that are in s but not in other. The chunks the two sets
+// share are skipped.
+func (s Set) Difference(other Set) Set {
+	if other.Empty() {
+		return s
+	}
+	vrw := s.orderedSequence.valueReadWriter()
+	ch := s.newChunker(nil, vrw)
+	walkOrderedSequences(s.orderedSequence, other.orderedSequence, func(cur *sequenceCursor, sItem, otherItem sequenceItem) {
+		if otherItem == nil {
+			ch.Append(sItem)
+		}
+	})
+	return newSet(ch.Done().(orderedSequence))
+}
+
+func (s Set) newChunker(cur *sequenceCursor, vrw ValueReadWriter) *sequenceChunker {
+	return newSequenceChunker(cur, 0, vrw, makeSetLeafChunkFn(vrw), newOrderedMetaSequenceChunkFn(SetKind, vrw), hashValueBytes)
+}
+
 func buildSetData(values ValueSlice) ValueSlice {
 	if len(values) == 0 {
 		return ValueSlice{}
@@ -35,10 +35,15 @@ type setIterator struct {
 	s            Set
 	cursor       *sequenceCursor
 	currentValue Value
+	bounds       orderedRange
+}
+
+func (si *setIterator) valid() bool {
+	return si.cursor.valid() && (si.bounds.to == nil || si.cursor.current().(Value).Less(si.bounds.to))
 }
 
 func (si *setIterator) Next() Value {
-	if si.cursor.valid() {
+	if si.valid() {
 		si.currentValue = si.cursor.current().(Value)
 		si.cursor.advance()
 	} else {
@@ -49,13 +54,13 @@ func (si *setIterator) Next() Value {
 
 func (si *setIterator) SkipTo(v Value) Value {
 	d.PanicIfTrue(v == nil)
-	if si.cursor.valid() {
+	if si.valid() {
 		if compareValue(v, si.currentValue) <= 0 {
 			return si.Next()
 		}
 
 		si.cursor = newCursorAtValue(si.s.orderedSequence, v, true, false)
-		if si.cursor.valid() {
+		if si.valid() {
 			si.currentValue = si.cursor.current().(Value)
 			si.cursor.advance()
 		} else {
@@ -67,6 +72,48 @@ func (si *setIterator) SkipTo(v Value) Value {
 	return si.currentValue
 }
 
+// reverseSetIterator is a SetIterator that returns values in descending order. Its SkipTo(v)
+// retreats to the next value <= v. It can't be combined with forward iterators in a UnionIterator
+// or IntersectionIterator.
+type reverseSetIterator struct {
+	s            Set
+	cursor       *sequenceCursor
+	currentValue Value
+	bounds       orderedRange
+}
+
+func (si *reverseSetIterator) valid() bool {
+	return si.cursor.valid() && (si.bounds.from == nil || !si.cursor.current().(Value).Less(si.bounds.from))
+}
+
+func (si *reverseSetIterator) Next() Value {
+	if si.valid() {
+		si.currentValue = si.cursor.current().(Value)
+		si.cursor.retreat()
+	} else {
+		si.currentValue = nil
+	}
+	return si.currentValue
+}
+
+func (si *reverseSetIterator) SkipTo(v Value) Value {
+	d.PanicIfTrue(v == nil)
+	if !si.valid() {
+		si.currentValue = nil
+		return nil
+	}
+	if si.currentValue == nil || !v.Less(si.currentValue) {
+		return si.Next()
+	}
+
+	// Position at the last value <= v.
+	si.cursor = newCursorAtValue(si.s.orderedSequence, v, true, false)
+	if !si.cursor.valid() || !si.cursor.current().(Value).Equals(v) {
+		si.cursor.retreat()
+	}
+	return si.Next()
+}
+
 // iterState contains iterator and it's current value
 type iterState struct {
 	i SetIterator
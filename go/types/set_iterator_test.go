@@ -366,3 +366,60 @@ func iterize(iters []SetIterator, newIter newIterFunc, cntr *int) SetIterator {
 	}
 	return iterize(newIters, newIter, cntr)
 }
+
+func TestSetIteratorRange(t *testing.T) {
+	assert := assert.New(t)
+
+	vs := newTestValueStore()
+
+	numbers := generateNumbersAsValuesFromToBy(0, 20, 2)
+	s := NewSet(vs, numbers...)
+
+	assert.True(iterToSlice(s.IteratorRange(Number(4), Number(10))).Equals(ValueSlice{Number(4), Number(6), Number(8)}))
+	assert.True(iterToSlice(s.IteratorRange(Number(3), Number(9))).Equals(ValueSlice{Number(4), Number(6), Number(8)}))
+	assert.True(iterToSlice(s.IteratorRange(nil, Number(3))).Equals(ValueSlice{Number(0), Number(2)}))
+	assert.True(iterToSlice(s.IteratorRange(Number(15), nil)).Equals(ValueSlice{Number(16), Number(18)}))
+	assert.Nil(s.IteratorRange(Number(4), Number(4)).Next())
+
+	i := s.IteratorRange(Number(4), Number(10))
+	assert.Equal(Number(4), i.Next())
+	assert.Equal(Number(6), i.SkipTo(Number(5)))
+	assert.Nil(i.SkipTo(Number(10)))
+	assert.Nil(i.Next())
+
+	assert.True(iterToSlice(s.ReverseIterator()).Equals(ValueSlice{Number(18), Number(16), Number(14), Number(12), Number(10), Number(8), Number(6), Number(4), Number(2), Number(0)}))
+	assert.True(iterToSlice(s.ReverseIteratorRange(Number(4), Number(10))).Equals(ValueSlice{Number(8), Number(6), Number(4)}))
+	assert.True(iterToSlice(s.ReverseIteratorRange(Number(3), Number(9))).Equals(ValueSlice{Number(8), Number(6), Number(4)}))
+	assert.Nil(s.ReverseIteratorRange(nil, Number(0)).Next())
+
+	i = s.ReverseIterator()
+	assert.Panics(func() { i.SkipTo(nil) })
+	assert.Equal(Number(18), i.SkipTo(Number(30)))
+	assert.Equal(Number(10), i.SkipTo(Number(11)))
+	assert.Equal(Number(8), i.SkipTo(Number(10)))
+	assert.Equal(Number(6), i.Next())
+	assert.Equal(Number(0), i.SkipTo(Number(1)))
+	assert.Nil(i.SkipTo(Number(-1)))
+	assert.Nil(i.Next())
+
+	i = s.ReverseIteratorRange(Number(4), nil)
+	assert.Equal(Number(18), i.Next())
+	assert.Nil(i.SkipTo(Number(3)))
+
+	assert.Nil(NewSet(vs).ReverseIterator().Next())
+
+	// Cross chunk boundaries.
+	numbers = generateNumbersAsValues(5000)
+	s = NewSet(vs, numbers...)
+	assert.True(s.asSequence().treeLevel() > 0, "expected a chunked set")
+	assert.True(iterToSlice(s.IteratorRange(Number(100), Number(4900))).Equals(numbers[100:4900]))
+	vals := iterToSlice(s.ReverseIteratorRange(Number(100), Number(4900)))
+	assert.Equal(4800, len(vals))
+	for j, v := range vals {
+		assert.Equal(Number(4899-j), v)
+	}
+	i = s.ReverseIterator()
+	assert.Equal(Number(4999), i.Next())
+	assert.Equal(Number(2000), i.SkipTo(Number(2000)))
+	assert.Equal(Number(1999), i.Next())
+}
@@ -12,6 +12,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/attic-labs/noms/go/chunks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -1117,3 +1118,102 @@ func TestSetWithNil(t *testing.T) {
 		NewSet(vs, Number(42), nil)
 	})
 }
+
+func TestSetUnionIntersectDifference(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+
+	evens := NewSet(vs, generateNumbersAsValuesFromToBy(0, 5000, 2)...)
+	threes := NewSet(vs, generateNumbersAsValuesFromToBy(0, 5000, 3)...)
+	assert.True(evens.asSequence().treeLevel() > 0, "expected a chunked set")
+
+	union := NewSet(vs).Edit()
+	intersection := NewSet(vs).Edit()
+	difference := NewSet(vs).Edit()
+	for i := 0; i < 5000; i++ {
+		n := Number(i)
+		switch {
+		case i%2 == 0 && i%3 == 0:
+			intersection.Insert(n)
+			union.Insert(n)
+		case i%2 == 0:
+			difference.Insert(n)
+			union.Insert(n)
+		case i%3 == 0:
+			union.Insert(n)
+		}
+	}
+
+	assert.True(union.Set().Equals(evens.Union(threes)))
+	assert.True(union.Set().Equals(threes.Union(evens)))
+	assert.True(intersection.Set().Equals(evens.Intersect(threes)))
+	assert.True(intersection.Set().Equals(threes.Intersect(evens)))
+	assert.True(difference.Set().Equals(evens.Difference(threes)))
+
+	// Sets that share most of their chunks.
+	other := evens.Edit().Insert(Number(1), Number(4999)).Remove(Number(2500)).Set()
+	assert.True(evens.Edit().Insert(Number(1), Number(4999)).Set().Equals(evens.Union(other)))
+	assert.True(evens.Edit().Remove(Number(2500)).Set().Equals(evens.Intersect(other)))
+	assert.True(NewSet(vs, Number(2500)).Equals(evens.Difference(other)))
+
+	empty := NewSet(vs)
+	assert.True(evens.Equals(evens.Union(evens)))
+	assert.True(evens.Equals(evens.Union(empty)))
+	assert.True(evens.Equals(evens.Intersect(evens)))
+	assert.True(empty.Equals(evens.Intersect(empty)))
+	assert.True(empty.Equals(evens.Difference(evens)))
+	assert.True(evens.Equals(evens.Difference(empty)))
+	assert.True(empty.Equals(empty.Difference(evens)))
+}
+
+func TestSetUnionIntersectDifferenceSizes(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+
+	// Sets of very different sizes, and so heights, and ones that only overlap at their ends.
+	big := NewSet(vs, generateNumbersAsValuesFromToBy(0, 20000, 1)...)
+	for _, vals := range [][]Value{
+		{},
+		{Number(-1)},
+		{Number(-1), Number(5), Number(20000)},
+		generateNumbersAsValuesFromToBy(-100, 100, 7),
+		generateNumbersAsValuesFromToBy(19900, 20100, 3),
+		generateNumbersAsValuesFromToBy(-5000, 25000, 11),
+	} {
+		small := NewSet(vs, vals...)
+		union := big.Edit().Insert(vals...).Set()
+		intersection := NewSet(vs).Edit()
+		difference := NewSet(vs).Edit()
+		for _, v := range vals {
+			if big.Has(v) {
+				intersection.Insert(v)
+			} else {
+				difference.Insert(v)
+			}
+		}
+		assert.True(union.Equals(big.Union(small)))
+		assert.True(union.Equals(small.Union(big)))
+		assert.True(intersection.Set().Equals(big.Intersect(small)))
+		assert.True(intersection.Set().Equals(small.Intersect(big)))
+		assert.True(difference.Set().Equals(small.Difference(big)))
+		assert.True(big.Edit().Remove(vals...).Set().Equals(big.Difference(small)))
+	}
+}
+
+func TestSetUnionSkipsSharedChunks(t *testing.T) {
+	assert := assert.New(t)
+	ts := &chunks.TestStorage{}
+	vs := NewValueStore(ts.NewView())
+	a := NewSet(vs, generateNumbersAsValuesFromToBy(0, 50000, 1)...)
+	b := a.Edit().Remove(Number(25000)).Insert(Number(50000)).Set()
+	aRef, bRef := vs.WriteValue(a), vs.WriteValue(b)
+	vs.Commit(vs.Root(), vs.Root())
+
+	cs := ts.NewView()
+	vs = NewValueStore(cs)
+	a, b = vs.ReadValue(aRef.TargetHash()).(Set), vs.ReadValue(bRef.TargetHash()).(Set)
+	union := a.Union(b)
+	// Only the chunks on the paths to the two differences need to be read.
+	assert.True(cs.Reads < 20, "%d reads", cs.Reads)
+	assert.True(a.Edit().Insert(Number(50000)).Set().Equals(union))
+}
@@ -115,9 +115,9 @@ func cloneTypeTreeAndReplaceNamedStructs(t *Type, namedStructs map[string]struct
 	rec = func(t *Type) *Type {
 		kind := t.TargetKind()
 		switch kind {
-		case BoolKind, NumberKind, StringKind, BlobKind, ValueKind, TypeKind:
+		case BoolKind, NumberKind, StringKind, BlobKind, ValueKind, TypeKind, IntKind, UintKind, TimestampKind, DecimalKind:
 			return t
-		case ListKind, MapKind, RefKind, SetKind, UnionKind:
+		case ListKind, MapKind, RefKind, SetKind, TupleKind, UnionKind:
 			elemTypes := make(typeSlice, len(t.Desc.(CompoundDesc).ElemTypes))
 			for i, et := range t.Desc.(CompoundDesc).ElemTypes {
 				elemTypes[i] = rec(et)
@@ -167,10 +167,10 @@ func cloneTypeTreeAndReplaceNamedStructs(t *Type, namedStructs map[string]struct
 func foldUnions(t *Type, seenStructs typeset, intersectStructs bool) *Type {
 	kind := t.TargetKind()
 	switch kind {
-	case BoolKind, NumberKind, StringKind, BlobKind, ValueKind, TypeKind, CycleKind:
+	case BoolKind, NumberKind, StringKind, BlobKind, ValueKind, TypeKind, CycleKind, IntKind, UintKind, TimestampKind, DecimalKind:
 		break
 
-	case ListKind, MapKind, RefKind, SetKind:
+	case ListKind, MapKind, RefKind, SetKind, TupleKind:
 		elemTypes := t.Desc.(CompoundDesc).ElemTypes
 		for i, et := range elemTypes {
 			elemTypes[i] = foldUnions(et, seenStructs, intersectStructs)
@@ -211,6 +211,7 @@ func foldUnionImpl(ts typeset, seenStructs typeset, intersectStructs bool) *Type
 	type how struct {
 		k NomsKind
 		n string
+		l int
 	}
 	out := make(typeSlice, 0, len(ts))
 	groups := map[how]typeset{}
@@ -221,6 +222,8 @@ func foldUnionImpl(ts typeset, seenStructs typeset, intersectStructs bool) *Type
 			h = how{k: t.TargetKind()}
 		case StructKind:
 			h = how{k: t.TargetKind(), n: t.Desc.(StructDesc).Name}
+		case TupleKind:
+			h = how{k: t.TargetKind(), l: len(t.Desc.(CompoundDesc).ElemTypes)}
 		default:
 			out = append(out, t)
 			continue
@@ -249,6 +252,8 @@ func foldUnionImpl(ts typeset, seenStructs typeset, intersectStructs bool) *Type
 			r = foldMapTypesForUnion(ts, seenStructs, intersectStructs)
 		case StructKind:
 			r = foldStructTypes(h.n, ts, seenStructs, intersectStructs)
+		case TupleKind:
+			r = foldTupleTypesForUnion(h.l, ts, seenStructs, intersectStructs)
 		}
 		out = append(out, r)
 	}
@@ -293,6 +298,25 @@ func foldMapTypesForUnion(ts, seenStructs typeset, intersectStructs bool) *Type
 	return makeCompoundType(MapKind, kt, vt)
 }
 
+func foldTupleTypesForUnion(l int, ts, seenStructs typeset, intersectStructs bool) *Type {
+	elemTypes := make([]typeset, l)
+	for i := range elemTypes {
+		elemTypes[i] = make(typeset, len(ts))
+	}
+	for t := range ts {
+		d.PanicIfFalse(t.TargetKind() == TupleKind)
+		for i, et := range t.Desc.(CompoundDesc).ElemTypes {
+			elemTypes[i].add(et)
+		}
+	}
+
+	folded := make(typeSlice, l)
+	for i, ets := range elemTypes {
+		folded[i] = foldUnionImpl(ets, seenStructs, intersectStructs)
+	}
+	return makeCompoundType(TupleKind, folded...)
+}
+
 func foldStructTypesFieldsOnly(name string, ts, seenStructs typeset, intersectStructs bool) structTypeFields {
 	fieldset := make([]structTypeFields, len(ts))
 	i := 0
@@ -78,6 +78,8 @@ func TestSimplifyType(t *testing.T) {
 		testSame(makeCompoundType(SetKind, BoolType))
 		testSame(makeCompoundType(RefKind, BoolType))
 		testSame(makeCompoundType(MapKind, BoolType, NumberType))
+		testSame(makeCompoundType(TupleKind))
+		testSame(makeCompoundType(TupleKind, BoolType, NumberType))
 
 		{
 			// Cannot do equals on cycle types
@@ -96,6 +98,13 @@ func TestSimplifyType(t *testing.T) {
 		test(makeCompoundType(ListKind, makeUnionType(BoolType)), makeCompoundType(ListKind, BoolType))
 		test(makeCompoundType(ListKind, makeUnionType(BoolType, BoolType)), makeCompoundType(ListKind, BoolType))
 
+		// Tuple types are folded element by element, but only if they have the same length.
+		test(
+			makeUnionType(makeCompoundType(TupleKind, BoolType, StringType), makeCompoundType(TupleKind, NumberType, StringType)),
+			makeCompoundType(TupleKind, makeUnionType(BoolType, NumberType), StringType),
+		)
+		testSame(makeUnionType(makeCompoundType(TupleKind, BoolType), makeCompoundType(TupleKind, BoolType, StringType)))
+
 		testSame(makeStructType("", nil))
 		testSame(makeStructType("", structTypeFields{}))
 		testSame(makeStructType("", structTypeFields{
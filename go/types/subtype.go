@@ -75,6 +75,10 @@ func isSubtypeDetails(requiredType, concreteType *Type, hasExtra bool, parentStr
 
 	if desc, ok := requiredType.Desc.(CompoundDesc); ok {
 		concreteElemTypes := concreteType.Desc.(CompoundDesc).ElemTypes
+		if len(desc.ElemTypes) != len(concreteElemTypes) {
+			// Only Tuple types can differ in length.
+			return false, hasExtra
+		}
 		for i, t := range desc.ElemTypes {
 			isSub, hasMore := compoundSubtype(t, concreteElemTypes[i], hasExtra, parentStructTypes)
 			if !isSub {
@@ -196,7 +200,7 @@ func IsValueSubtypeOfDetails(v Value, t *Type) (bool, bool) {
 
 func isValueSubtypeOfDetails(v Value, t *Type, hasExtra bool) (bool, bool) {
 	switch t.TargetKind() {
-	case BoolKind, NumberKind, StringKind, BlobKind, TypeKind:
+	case BoolKind, NumberKind, StringKind, BlobKind, TypeKind, IntKind, UintKind, TimestampKind, DecimalKind:
 		return v.Kind() == t.TargetKind(), hasExtra
 	case ValueKind:
 		return true, hasExtra
@@ -325,6 +329,18 @@ func isValueSubtypeOfDetails(v Value, t *Type, hasExtra bool) (bool, bool) {
 				return true, hasExtra
 			}
 			return isMetaSequenceSubtypeOf(v.sequence.(metaSequence), t, hasExtra)
+		case Tuple:
+			if v.Len() != uint64(len(desc.ElemTypes)) {
+				return false, hasExtra
+			}
+			for i, ev := range v.Values() {
+				isSub, hasMore := isValueSubtypeOfDetails(ev, desc.ElemTypes[i], hasExtra)
+				if !isSub {
+					return false, hasExtra
+				}
+				hasExtra = hasExtra || hasMore
+			}
+			return true, hasExtra
 		}
 	}
 	panic("unreachable")
@@ -342,3 +358,108 @@ func isMetaSequenceSubtypeOf(ms metaSequence, t *Type, hasExtra bool) (bool, boo
 	}
 	return true, hasExtra
 }
+
+// ValueSubtypeMismatches returns the paths, relative to v, of the innermost
+// values that keep v from being a subtype of t. It returns nil if
+// IsValueSubtypeOf(v, t). When a Struct is missing a required field, the
+// returned path names that field. At most |limit| paths are returned, unless
+// |limit| is 0.
+func ValueSubtypeMismatches(v Value, t *Type, limit int) []Path {
+	mismatches := []Path{}
+	collectSubtypeMismatches(v, t, Path{}, limit, &mismatches)
+	if len(mismatches) == 0 {
+		return nil
+	}
+	return mismatches
+}
+
+func collectSubtypeMismatches(v Value, t *Type, p Path, limit int, mismatches *[]Path) {
+	full := func() bool {
+		return limit > 0 && len(*mismatches) >= limit
+	}
+	if full() || IsValueSubtypeOf(v, t) {
+		return
+	}
+	report := func(p Path) bool {
+		*mismatches = append(*mismatches, p)
+		return full()
+	}
+	// Types that are not checked element-wise below, or whose outermost kind
+	// is already wrong, are reported at the current path.
+	if v.Kind() != t.TargetKind() {
+		report(p)
+		return
+	}
+
+	// Collections stop being iterated as soon as the limit is reached.
+	switch desc := t.Desc.(type) {
+	case StructDesc:
+		s := v.(Struct)
+		if desc.Name != "" && desc.Name != s.Name() {
+			report(p)
+			return
+		}
+		for _, f := range desc.fields {
+			fp := p.Append(NewFieldPath(f.Name))
+			fv, ok := s.MaybeGet(f.Name)
+			if !ok {
+				if !f.Optional && report(fp) {
+					return
+				}
+				continue
+			}
+			collectSubtypeMismatches(fv, f.Type, fp, limit, mismatches)
+			if full() {
+				return
+			}
+		}
+	case CompoundDesc:
+		switch v := v.(type) {
+		case Map:
+			kt, vt := desc.ElemTypes[0], desc.ElemTypes[1]
+			v.Iter(func(k, mv Value) bool {
+				if !IsValueSubtypeOf(k, kt) && report(p.Append(pathIndexFor(k, true))) {
+					return true
+				}
+				collectSubtypeMismatches(mv, vt, p.Append(pathIndexFor(k, false)), limit, mismatches)
+				return full()
+			})
+		case Set:
+			et := desc.ElemTypes[0]
+			v.Iter(func(sv Value) bool {
+				collectSubtypeMismatches(sv, et, p.Append(pathIndexFor(sv, false)), limit, mismatches)
+				return full()
+			})
+		case List:
+			et := desc.ElemTypes[0]
+			v.Iter(func(lv Value, idx uint64) bool {
+				collectSubtypeMismatches(lv, et, p.Append(NewIndexPath(Number(idx))), limit, mismatches)
+				return full()
+			})
+		case Tuple:
+			if v.Len() != uint64(len(desc.ElemTypes)) {
+				report(p)
+				break
+			}
+			for i, ev := range v.Values() {
+				collectSubtypeMismatches(ev, desc.ElemTypes[i], p.Append(NewIndexPath(Number(i))), limit, mismatches)
+				if full() {
+					return
+				}
+			}
+		default:
+			report(p)
+		}
+	default:
+		report(p)
+	}
+}
+
+// pathIndexFor returns the PathPart that addresses the entry |k| of a Map or
+// Set, or its key if |intoKey| is true.
+func pathIndexFor(k Value, intoKey bool) PathPart {
+	if ValueCanBePathIndex(k) {
+		return newIndexPath(k, intoKey)
+	}
+	return newHashIndexPath(k.Hash(), intoKey)
+}
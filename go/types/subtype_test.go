@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/attic-labs/noms/go/chunks"
 	"github.com/attic-labs/noms/go/d"
 	"github.com/stretchr/testify/assert"
 )
@@ -820,3 +821,46 @@ func TestIsValueSubtypeOfDetails(tt *testing.T) {
 	test("x z", "x? y?", true, true)
 	test("x", "x y", false, false)
 }
+
+func TestValueSubtypeMismatches(tt *testing.T) {
+	a := assert.New(tt)
+	vs := newTestValueStore()
+
+	pathStrings := func(paths []Path) []string {
+		strs := []string{}
+		for _, p := range paths {
+			strs = append(strs, p.String())
+		}
+		return strs
+	}
+
+	t := MakeStructType("Row",
+		StructField{"id", NumberType, false},
+		StructField{"name", StringType, false},
+		StructField{"tags", MakeListType(StringType), true},
+	)
+
+	good := NewStruct("Row", StructData{"id": Number(1), "name": String("a")})
+	a.Nil(ValueSubtypeMismatches(good, t, 0))
+	a.Equal([]string{""}, pathStrings(ValueSubtypeMismatches(Number(1), t, 0)))
+
+	bad := NewStruct("Row", StructData{
+		"id":   String("1"),
+		"tags": NewList(vs, String("x"), Number(2), String("y"), Bool(true)),
+	})
+	a.Equal([]string{".id", ".name", ".tags[1]", ".tags[3]"}, pathStrings(ValueSubtypeMismatches(bad, t, 0)))
+	a.Equal([]string{".id", ".name"}, pathStrings(ValueSubtypeMismatches(bad, t, 2)))
+
+	m := NewMap(vs, String("a"), good, Number(2), bad)
+	a.Equal([]string{"[2]@key", "[2].id", "[2].name", "[2].tags[1]", "[2].tags[3]"}, pathStrings(ValueSubtypeMismatches(m, MakeMapType(StringType, t), 0)))
+
+	// Once the limit is reached, the rest of a collection isn't read.
+	ts := &chunks.TestStorage{}
+	vs2 := NewValueStore(ts.NewView())
+	r := vs2.WriteValue(NewList(vs2, generateNumbersAsValues(50000)...))
+	vs2.Commit(vs2.Root(), vs2.Root())
+	cs := ts.NewView()
+	l := NewValueStore(cs).ReadValue(r.TargetHash())
+	a.Equal([]string{"[0]", "[1]"}, pathStrings(ValueSubtypeMismatches(l, MakeListType(StringType), 2)))
+	a.True(cs.Reads < 10, "%d reads", cs.Reads)
+}
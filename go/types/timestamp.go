@@ -0,0 +1,89 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/attic-labs/noms/go/hash"
+)
+
+// Timestamp is a Noms Value representing a point in time with nanosecond
+// precision. It is stored as the number of nanoseconds since the Unix epoch,
+// so it covers the years 1678 to 2262. Timestamps are ordered by time.
+type Timestamp int64
+
+var (
+	minTimestampTime = time.Unix(0, math.MinInt64)
+	maxTimestampTime = time.Unix(0, math.MaxInt64)
+)
+
+// NewTimestamp returns the Timestamp of |t|, or an error if |t| is outside
+// the range a Timestamp covers. Note that this includes the zero time.Time.
+func NewTimestamp(t time.Time) (Timestamp, error) {
+	if t.Before(minTimestampTime) || t.After(maxTimestampTime) {
+		return 0, fmt.Errorf("%s is out of the range of a Timestamp, %s to %s", t.Format(time.RFC3339Nano), minTimestampTime.UTC().Format(time.RFC3339Nano), maxTimestampTime.UTC().Format(time.RFC3339Nano))
+	}
+	return Timestamp(t.UnixNano()), nil
+}
+
+// Time returns |v| as a time.Time in UTC.
+func (v Timestamp) Time() time.Time {
+	return time.Unix(0, int64(v)).UTC()
+}
+
+// Value interface
+func (v Timestamp) Value() Value {
+	return v
+}
+
+func (v Timestamp) Equals(other Value) bool {
+	return v == other
+}
+
+func (v Timestamp) Less(other Value) bool {
+	if v2, ok := other.(Timestamp); ok {
+		return v < v2
+	}
+	return kindLess(TimestampKind, other.Kind())
+}
+
+func (v Timestamp) Hash() hash.Hash {
+	return getHash(v)
+}
+
+func (v Timestamp) WalkValues(cb ValueCallback) {
+}
+
+func (v Timestamp) WalkRefs(cb RefCallback) {
+}
+
+func (v Timestamp) typeOf() *Type {
+	return TimestampType
+}
+
+func (v Timestamp) Kind() NomsKind {
+	return TimestampKind
+}
+
+func (v Timestamp) valueReadWriter() ValueReadWriter {
+	return nil
+}
+
+func (v Timestamp) writeTo(w nomsWriter) {
+	TimestampKind.writeTo(w)
+	w.writeInt(int64(v))
+}
+
+func (v Timestamp) valueBytes() []byte {
+	// TimestampKind, nanoseconds (Varint)
+	buff := make([]byte, 1+binary.MaxVarintLen64)
+	w := binaryNomsWriter{buff, 0}
+	v.writeTo(&w)
+	return buff[:w.offset]
+}
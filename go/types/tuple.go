@@ -0,0 +1,148 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package types
+
+import (
+	"github.com/attic-labs/noms/go/d"
+)
+
+// Tuple is a fixed length sequence of Values, typically used as a composite
+// key in a Map or Set. Unlike Lists and Structs, Tuples are ordered by value:
+// two Tuples are compared element by element, and a Tuple that is a prefix of
+// another sorts first. This makes it possible to range scan a Map keyed by
+// Tuples with IteratorFrom, e.g. all the keys that start with a given element.
+type Tuple struct {
+	valueImpl
+}
+
+// readTuple reads the data provided by a decoder and moves the decoder forward.
+func readTuple(dec *valueDecoder) Tuple {
+	start := dec.pos()
+	skipTuple(dec)
+	end := dec.pos()
+	return Tuple{valueImpl{dec.vrw, dec.byteSlice(start, end), nil}}
+}
+
+func skipTuple(dec *valueDecoder) {
+	dec.skipKind()
+	count := dec.readCount()
+	for i := uint64(0); i < count; i++ {
+		dec.skipValue()
+	}
+}
+
+func walkTuple(r *refWalker, cb RefCallback) {
+	r.skipKind()
+	count := r.readCount()
+	for i := uint64(0); i < count; i++ {
+		r.walkValue(cb)
+	}
+}
+
+func readTupleTypeOfValue(dec *valueDecoder) *Type {
+	dec.skipKind()
+	count := dec.readCount()
+	elemTypes := make(typeSlice, count)
+	for i := uint64(0); i < count; i++ {
+		elemTypes[i] = dec.readTypeOfValue()
+	}
+	return makeCompoundType(TupleKind, elemTypes...)
+}
+
+// NewTuple returns a Tuple of |vs|.
+func NewTuple(vs ...Value) Tuple {
+	var vrw ValueReadWriter
+	w := newBinaryNomsWriter()
+	TupleKind.writeTo(&w)
+	w.writeCount(uint64(len(vs)))
+	for _, v := range vs {
+		if vrw == nil {
+			vrw = v.(valueReadWriter).valueReadWriter()
+		}
+		v.writeTo(&w)
+	}
+	return Tuple{valueImpl{vrw, w.data(), nil}}
+}
+
+func (t Tuple) decoderSkipToValues() (valueDecoder, uint64) {
+	dec := t.decoder()
+	dec.skipKind()
+	count := dec.readCount()
+	return dec, count
+}
+
+// Len is the number of elements in the tuple.
+func (t Tuple) Len() uint64 {
+	_, count := t.decoderSkipToValues()
+	return count
+}
+
+// Get returns the element at index |idx|, which must be less than Len().
+func (t Tuple) Get(idx uint64) Value {
+	dec, count := t.decoderSkipToValues()
+	d.PanicIfFalse(idx < count)
+	for i := uint64(0); i < idx; i++ {
+		dec.skipValue()
+	}
+	return dec.readValue()
+}
+
+// Values returns the elements of the tuple.
+func (t Tuple) Values() ValueSlice {
+	dec, count := t.decoderSkipToValues()
+	vs := make(ValueSlice, count)
+	for i := uint64(0); i < count; i++ {
+		vs[i] = dec.readValue()
+	}
+	return vs
+}
+
+// Value interface
+func (t Tuple) Value() Value {
+	return t
+}
+
+func (t Tuple) Less(other Value) bool {
+	if t2, ok := other.(Tuple); ok {
+		return compareTuples(t, t2) < 0
+	}
+	return kindLess(TupleKind, other.Kind())
+}
+
+func (t Tuple) WalkValues(cb ValueCallback) {
+	dec, count := t.decoderSkipToValues()
+	for i := uint64(0); i < count; i++ {
+		cb(dec.readValue())
+	}
+}
+
+func (t Tuple) typeOf() *Type {
+	dec := t.decoder()
+	return readTupleTypeOfValue(&dec)
+}
+
+// compareTuples compares |a| and |b| element by element and returns -1, 0 or
+// 1. Elements are compared with Less, so elements that are not ordered by
+// value are compared by hash.
+func compareTuples(a, b Tuple) int {
+	aDec, aCount := a.decoderSkipToValues()
+	bDec, bCount := b.decoderSkipToValues()
+	for i := uint64(0); i < aCount && i < bCount; i++ {
+		av, bv := aDec.readValue(), bDec.readValue()
+		if av.Less(bv) {
+			return -1
+		}
+		if bv.Less(av) {
+			return 1
+		}
+	}
+	switch {
+	case aCount < bCount:
+		return -1
+	case aCount > bCount:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,113 @@
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package types
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTupleGet(t *testing.T) {
+	assert := assert.New(t)
+
+	tup := NewTuple(String("a"), Number(1), Bool(true))
+	assert.Equal(uint64(3), tup.Len())
+	assert.True(String("a").Equals(tup.Get(0)))
+	assert.True(Number(1).Equals(tup.Get(1)))
+	assert.True(Bool(true).Equals(tup.Get(2)))
+	assert.Panics(func() { tup.Get(3) })
+	assert.True(ValueSlice{String("a"), Number(1), Bool(true)}.Equals(tup.Values()))
+
+	assert.Equal(uint64(0), NewTuple().Len())
+	assert.False(NewTuple().Equals(NewTuple(String("a"))))
+	assert.True(NewTuple(String("a")).Equals(NewTuple(String("a"))))
+}
+
+func TestTupleType(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+
+	tup := NewTuple(String("a"), NewList(vs, Number(1)))
+	assert.True(MakeTupleType(StringType, MakeListType(NumberType)).Equals(TypeOf(tup)))
+	assert.True(MakeTupleType().Equals(TypeOf(NewTuple())))
+
+	assert.True(IsValueSubtypeOf(tup, MakeTupleType(StringType, MakeListType(NumberType))))
+	assert.True(IsValueSubtypeOf(tup, MakeTupleType(StringType, ValueType)))
+	assert.False(IsValueSubtypeOf(tup, MakeTupleType(StringType)))
+	assert.False(IsValueSubtypeOf(tup, MakeTupleType(NumberType, ValueType)))
+
+	assert.True(IsSubtype(MakeTupleType(StringType, ValueType), TypeOf(tup)))
+	assert.False(IsSubtype(MakeTupleType(StringType), TypeOf(tup)))
+	assert.False(IsSubtype(MakeTupleType(StringType, ValueType, ValueType), TypeOf(tup)))
+
+	assert.True(ContainCommonSupertype(MakeTupleType(StringType, NumberType), MakeTupleType(StringType, MakeUnionType(NumberType, BoolType))))
+	assert.False(ContainCommonSupertype(MakeTupleType(StringType, NumberType), MakeTupleType(StringType, BoolType)))
+	assert.False(ContainCommonSupertype(MakeTupleType(StringType), MakeTupleType(StringType, BoolType)))
+
+	// A Map with Tuple keys of different lengths has a union of Tuple types as key type.
+	m := NewMap(vs, NewTuple(String("a")), Number(1), NewTuple(String("a"), Number(1)), Number(2), NewTuple(Bool(true)), Number(3))
+	assert.True(MakeMapType(MakeUnionType(MakeTupleType(MakeUnionType(BoolType, StringType)), MakeTupleType(StringType, NumberType)), NumberType).Equals(TypeOf(m)))
+}
+
+func TestTuplePath(t *testing.T) {
+	assert := assert.New(t)
+
+	tup := NewTuple(String("a"), Number(1))
+	assert.True(Number(1).Equals(MustParsePath("[1]").Resolve(tup, nil)))
+	assert.True(String("a").Equals(MustParsePath("[-2]").Resolve(tup, nil)))
+	assert.Nil(MustParsePath("[2]").Resolve(tup, nil))
+}
+
+func TestTupleMapRangeScan(t *testing.T) {
+	assert := assert.New(t)
+	vs := newTestValueStore()
+
+	regions := []string{"ap-south", "eu-west", "us-east"}
+	kvs := ValueSlice{}
+	for _, r := range regions {
+		for day := 0; day < 1000; day++ {
+			kvs = append(kvs, NewTuple(String(r), Number(day)), String(fmt.Sprintf("%s/%d", r, day)))
+		}
+	}
+
+	// Build the map both ways, the GraphBuilder sorts its keys in the op cache.
+	gb := NewGraphBuilder(vs, MapKind)
+	for i := len(kvs) - 2; i >= 0; i -= 2 {
+		gb.MapSet(nil, kvs[i], kvs[i+1])
+	}
+	m := NewMap(vs, kvs...)
+	assert.True(m.Equals(gb.Build()))
+	assert.Equal(uint64(3000), m.Len())
+	assert.True(m.asSequence().treeLevel() > 0, "expected a chunked map")
+
+	// Keys are ordered by region and then by day.
+	last := Value(nil)
+	m.IterAll(func(k, v Value) {
+		if last != nil {
+			assert.True(last.Less(k))
+		}
+		last = k
+	})
+
+	// Scanning from a prefix of the key starts at the first key with that prefix.
+	it := m.IteratorFrom(NewTuple(String("eu-west")))
+	for day := 0; day < 1000; day++ {
+		assert.True(it.Valid())
+		assert.True(NewTuple(String("eu-west"), Number(day)).Equals(it.Key()))
+		it.Next()
+	}
+	assert.True(NewTuple(String("us-east"), Number(0)).Equals(it.Key()))
+
+	it = m.IteratorFrom(NewTuple(String("us-east"), Number(998)))
+	assert.True(String("us-east/998").Equals(it.Value()))
+	it.Next()
+	it.Next()
+	assert.False(it.Valid())
+
+	assert.True(String("ap-south/500").Equals(m.Get(NewTuple(String("ap-south"), Number(500)))))
+	assert.False(m.Has(NewTuple(String("ap-south"), Number(1000))))
+}
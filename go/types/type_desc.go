@@ -48,7 +48,7 @@ func (p PrimitiveDesc) isSimplifiedInner() bool {
 	return true
 }
 
-// CompoundDesc describes a List, Map, Set, Ref, or Union type.
+// CompoundDesc describes a List, Map, Set, Ref, Tuple or Union type.
 // ElemTypes indicates what type or types are in the container indicated by kind, e.g. Map key and value or Set element.
 type CompoundDesc struct {
 	kind      NomsKind
@@ -67,7 +67,7 @@ func (c CompoundDesc) walkValues(cb ValueCallback) {
 
 func (c CompoundDesc) writeTo(w nomsWriter, t *Type, seenStructs map[string]*Type) {
 	c.kind.writeTo(w)
-	if c.kind == UnionKind {
+	if c.kind == UnionKind || c.kind == TupleKind {
 		w.writeCount(uint64(len(c.ElemTypes)))
 	}
 	for _, t := range c.ElemTypes {
@@ -223,6 +223,9 @@ func unionLess(ti, tj *Type) bool {
 			return ti.Desc.(StructDesc).Name < tj.Desc.(StructDesc).Name
 		case CycleKind:
 			return ti.Desc.(CycleDesc) < tj.Desc.(CycleDesc)
+		case TupleKind:
+			// Tuple types are only folded together if they have the same length.
+			return len(ti.Desc.(CompoundDesc).ElemTypes) < len(tj.Desc.(CompoundDesc).ElemTypes)
 		default:
 			panic("unreachable") // We should have folded all other types into one.
 		}
@@ -190,6 +190,18 @@ func (r *valueDecoder) readValue() Value {
 	case NumberKind:
 		r.skipKind()
 		return r.readNumber()
+	case IntKind:
+		r.skipKind()
+		return Int(r.readInt())
+	case UintKind:
+		r.skipKind()
+		return Uint(r.readUint())
+	case TimestampKind:
+		r.skipKind()
+		return Timestamp(r.readInt())
+	case DecimalKind:
+		r.skipKind()
+		return r.readDecimal()
 	case StringKind:
 		r.skipKind()
 		return String(r.readString())
@@ -203,6 +215,8 @@ func (r *valueDecoder) readValue() Value {
 		return newSet(r.readSetSequence())
 	case StructKind:
 		return r.readStruct()
+	case TupleKind:
+		return readTuple(r)
 	case TypeKind:
 		r.skipKind()
 		return r.readType()
@@ -224,6 +238,18 @@ func (r *valueDecoder) skipValue() {
 	case NumberKind:
 		r.skipKind()
 		r.skipNumber()
+	case IntKind:
+		r.skipKind()
+		r.skipInt()
+	case UintKind:
+		r.skipKind()
+		r.skipUint()
+	case TimestampKind:
+		r.skipKind()
+		r.skipInt()
+	case DecimalKind:
+		r.skipKind()
+		r.skipDecimal()
 	case StringKind:
 		r.skipKind()
 		r.skipString()
@@ -237,6 +263,8 @@ func (r *valueDecoder) skipValue() {
 		r.skipSet()
 	case StructKind:
 		r.skipStruct()
+	case TupleKind:
+		skipTuple(r)
 	case TypeKind:
 		r.skipKind()
 		r.skipType()
@@ -263,6 +291,22 @@ func (r *valueDecoder) readTypeOfValue() *Type {
 		r.skipKind()
 		r.skipNumber()
 		return NumberType
+	case IntKind:
+		r.skipKind()
+		r.skipInt()
+		return IntType
+	case UintKind:
+		r.skipKind()
+		r.skipUint()
+		return UintType
+	case TimestampKind:
+		r.skipKind()
+		r.skipInt()
+		return TimestampType
+	case DecimalKind:
+		r.skipKind()
+		r.skipDecimal()
+		return DecimalType
 	case StringKind:
 		r.skipKind()
 		r.skipString()
@@ -272,6 +316,8 @@ func (r *valueDecoder) readTypeOfValue() *Type {
 		return r.readValue().typeOf()
 	case StructKind:
 		return readStructTypeOfValue(r)
+	case TupleKind:
+		return readTupleTypeOfValue(r)
 	case TypeKind:
 		r.skipKind()
 		r.skipType()
@@ -295,10 +341,10 @@ func (r *valueDecoder) isValueSameTypeForSure(t *Type) bool {
 	}
 
 	switch k {
-	case BlobKind, BoolKind, NumberKind, StringKind:
+	case BlobKind, BoolKind, NumberKind, StringKind, IntKind, UintKind, TimestampKind, DecimalKind:
 		r.skipValue()
 		return true
-	case ListKind, MapKind, RefKind, SetKind:
+	case ListKind, MapKind, RefKind, SetKind, TupleKind:
 		// TODO: Maybe do some simple cases here too. Performance metrics should determine
 		// what is going to be worth doing.
 		// https://github.com/attic-labs/noms/issues/3776
@@ -395,6 +441,8 @@ func (r *typedBinaryNomsReader) readTypeInner(seenStructs map[string]*Type) *Typ
 		return makeCompoundType(SetKind, r.readTypeInner(seenStructs))
 	case StructKind:
 		return r.readStructType(seenStructs)
+	case TupleKind:
+		return makeCompoundType(TupleKind, r.readTypeSlice(seenStructs)...)
 	case UnionKind:
 		return r.readUnionType(seenStructs)
 	case CycleKind:
@@ -419,7 +467,7 @@ func (r *typedBinaryNomsReader) skipTypeInner() {
 		r.skipTypeInner()
 	case StructKind:
 		r.skipStructType()
-	case UnionKind:
+	case UnionKind, TupleKind:
 		r.skipUnionType()
 	case CycleKind:
 		r.skipString()
@@ -467,12 +515,16 @@ func (r *typedBinaryNomsReader) skipStructType() {
 }
 
 func (r *typedBinaryNomsReader) readUnionType(seenStructs map[string]*Type) *Type {
+	return makeUnionType(r.readTypeSlice(seenStructs)...)
+}
+
+func (r *typedBinaryNomsReader) readTypeSlice(seenStructs map[string]*Type) typeSlice {
 	l := r.readCount()
 	ts := make(typeSlice, l)
 	for i := uint64(0); i < l; i++ {
 		ts[i] = r.readTypeInner(seenStructs)
 	}
-	return makeUnionType(ts...)
+	return ts
 }
 
 func (r *typedBinaryNomsReader) skipUnionType() {
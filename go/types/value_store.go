@@ -53,6 +53,7 @@ type ValueStore struct {
 	unresolvedRefs       hash.HashSet
 	enforceCompleteness  bool
 	decodedChunks        *sizecache.SizeCache
+	chunking             *ChunkingConfig
 
 	versOnce sync.Once
 }
@@ -109,6 +110,27 @@ func (lvs *ValueStore) SetEnforceCompleteness(enforce bool) {
 	lvs.enforceCompleteness = enforce
 }
 
+// SetChunkingConfig makes the collections created with lvs be chunked with
+// cfg rather than DefaultChunkingConfig. It should be called before lvs is
+// used, since collections chunked with different configs can't be compared by
+// hash.
+func (lvs *ValueStore) SetChunkingConfig(cfg ChunkingConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	lvs.chunking = &cfg
+	return nil
+}
+
+// ChunkingConfig returns the config that collections created with lvs are
+// chunked with.
+func (lvs *ValueStore) ChunkingConfig() ChunkingConfig {
+	if lvs.chunking == nil {
+		return defaultChunkingConfig()
+	}
+	return *lvs.chunking
+}
+
 func (lvs *ValueStore) ChunkStore() chunks.ChunkStore {
 	return lvs.cs
 }
@@ -122,6 +122,18 @@ func (r *refWalker) walkValue(cb RefCallback) {
 	case NumberKind:
 		r.skipKind()
 		r.skipNumber()
+	case IntKind:
+		r.skipKind()
+		r.skipInt()
+	case UintKind:
+		r.skipKind()
+		r.skipUint()
+	case TimestampKind:
+		r.skipKind()
+		r.skipInt()
+	case DecimalKind:
+		r.skipKind()
+		r.skipDecimal()
 	case StringKind:
 		r.skipKind()
 		r.skipString()
@@ -135,6 +147,8 @@ func (r *refWalker) walkValue(cb RefCallback) {
 		r.walkSet(cb)
 	case StructKind:
 		r.walkStruct(cb)
+	case TupleKind:
+		walkTuple(r, cb)
 	case TypeKind:
 		r.skipKind()
 		r.skipType()
@@ -59,9 +59,14 @@ func (dt DateTime) MarshalNomsType() (*types.Type, error) {
 }
 
 // UnmarshalNoms makes DateTime implement marshal.Unmarshaler and it allows
-// Noms struct with type DateTimeType able to be unmarshaled onto a DateTime
-// Go struct
+// Noms struct with type DateTimeType, as well as types.Timestamp, able to be
+// unmarshaled onto a DateTime Go struct
 func (dt *DateTime) UnmarshalNoms(v types.Value) error {
+	if ts, ok := v.(types.Timestamp); ok {
+		*dt = DateTime{ts.Time()}
+		return nil
+	}
+
 	strct := struct {
 		SecSinceEpoch float64
 	}{}
@@ -54,6 +54,10 @@ func TestUnmarshal(t *testing.T) {
 		"secSinceEpoch": types.Number(42),
 		"extra":         types.String("field"),
 	}), time.Unix(42, 0))
+
+	var dt DateTime
+	assert.NoError(marshal.Unmarshal(types.Timestamp(42e9+1), &dt))
+	assert.True(dt.Equal(time.Unix(42, 1)))
 }
 
 func TestUnmarshalInvalid(t *testing.T) {
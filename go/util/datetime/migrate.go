@@ -0,0 +1,33 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package datetime
+
+import (
+	"github.com/attic-labs/noms/go/d"
+	"github.com/attic-labs/noms/go/marshal"
+	"github.com/attic-labs/noms/go/migration"
+	"github.com/attic-labs/noms/go/types"
+)
+
+// MigrateToTimestamps returns a copy of |v| in which every DateTime struct is
+// replaced by the equivalent types.Timestamp. Values reachable through Refs
+// are migrated as well and written to |vrw|, so the result may refer to new
+// chunks. Parts of |v| whose type has no DateTime struct are left untouched,
+// as are DateTimes out of the range of a Timestamp.
+func MigrateToTimestamps(vrw types.ValueReadWriter, v types.Value) types.Value {
+	nv, err := migration.RewriteStructs(vrw, v, datetypename, func(s types.Struct) (types.Value, error) {
+		if !types.IsValueSubtypeOf(s, DateTimeType) {
+			return s, nil
+		}
+		var dt DateTime
+		marshal.MustUnmarshal(s, &dt)
+		if ts, err := types.NewTimestamp(dt.Time); err == nil {
+			return ts, nil
+		}
+		return s, nil
+	})
+	d.PanicIfError(err)
+	return nv
+}
@@ -0,0 +1,59 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package datetime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attic-labs/noms/go/marshal"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateToTimestamps(t *testing.T) {
+	assert := assert.New(t)
+
+	vs := newTestValueStore()
+	defer vs.Close()
+
+	dt := func(sec int64) types.Value {
+		return marshal.MustMarshal(vs, DateTime{time.Unix(sec, 0)})
+	}
+	ts := func(sec int64) types.Value {
+		return types.Timestamp(sec * 1e9)
+	}
+
+	assert.True(ts(42).Equals(MigrateToTimestamps(vs, dt(42))))
+
+	// DateTimes a Timestamp can't represent are left as is.
+	old := dt(-1e11)
+	assert.True(old.Equals(MigrateToTimestamps(vs, old)))
+
+	// Values without DateTimes are returned as is.
+	plain := types.NewStruct("Person", types.StructData{"name": types.String("bob"), "age": types.Number(42)})
+	assert.True(plain.Equals(MigrateToTimestamps(vs, plain)))
+
+	person := func(born types.Value) types.Value {
+		return types.NewStruct("Person", types.StructData{"name": types.String("bob"), "born": born})
+	}
+	v := types.NewStruct("", types.StructData{
+		"list":   types.NewList(vs, dt(1), types.Number(2), dt(3)),
+		"set":    types.NewSet(vs, dt(1), dt(2)),
+		"map":    types.NewMap(vs, dt(1), person(dt(2)), types.String("a"), person(dt(3))),
+		"ref":    vs.WriteValue(person(dt(4))),
+		"number": types.Number(5),
+	})
+	expected := types.NewStruct("", types.StructData{
+		"list":   types.NewList(vs, ts(1), types.Number(2), ts(3)),
+		"set":    types.NewSet(vs, ts(1), ts(2)),
+		"map":    types.NewMap(vs, ts(1), person(ts(2)), types.String("a"), person(ts(3))),
+		"ref":    types.NewRef(person(ts(4))),
+		"number": types.Number(5),
+	})
+	migrated := MigrateToTimestamps(vs, v)
+	assert.True(expected.Equals(migrated), types.EncodedValue(migrated))
+	assert.True(person(ts(4)).Equals(migrated.(types.Struct).Get("ref").(types.Ref).TargetValue(vs)))
+}
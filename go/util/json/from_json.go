@@ -8,12 +8,13 @@ import (
 	"encoding/json"
 	"io"
 	"reflect"
+	"strconv"
 
 	"github.com/attic-labs/noms/go/d"
 	"github.com/attic-labs/noms/go/types"
 )
 
-func nomsValueFromDecodedJSONBase(vrw types.ValueReadWriter, o interface{}, useStruct bool) types.Value {
+func nomsValueFromDecodedJSONBase(vrw types.ValueReadWriter, o interface{}, opts FromOptions) types.Value {
 	switch o := o.(type) {
 	case string:
 		return types.String(o)
@@ -21,12 +22,29 @@ func nomsValueFromDecodedJSONBase(vrw types.ValueReadWriter, o interface{}, useS
 		return types.Bool(o)
 	case float64:
 		return types.Number(o)
+	case json.Number:
+		if opts.Ints {
+			if i, err := strconv.ParseInt(string(o), 10, 64); err == nil {
+				return types.Int(i)
+			}
+			if u, err := strconv.ParseUint(string(o), 10, 64); err == nil {
+				return types.Uint(u)
+			}
+		}
+		if opts.Decimals {
+			dec, err := types.ParseDecimal(string(o))
+			d.PanicIfError(err)
+			return dec
+		}
+		f, err := o.Float64()
+		d.PanicIfError(err)
+		return types.Number(f)
 	case nil:
 		return nil
 	case []interface{}:
 		items := make([]types.Value, 0, len(o))
 		for _, v := range o {
-			nv := nomsValueFromDecodedJSONBase(vrw, v, useStruct)
+			nv := nomsValueFromDecodedJSONBase(vrw, v, opts)
 			if nv != nil {
 				items = append(items, nv)
 			}
@@ -34,11 +52,11 @@ func nomsValueFromDecodedJSONBase(vrw types.ValueReadWriter, o interface{}, useS
 		return types.NewList(vrw, items...)
 	case map[string]interface{}:
 		var v types.Value
-		if useStruct {
+		if opts.Structs {
 			structName := ""
 			fields := make(types.StructData, len(o))
 			for k, v := range o {
-				nv := nomsValueFromDecodedJSONBase(vrw, v, useStruct)
+				nv := nomsValueFromDecodedJSONBase(vrw, v, opts)
 				if nv != nil {
 					k := types.EscapeStructField(k)
 					fields[k] = nv
@@ -48,7 +66,7 @@ func nomsValueFromDecodedJSONBase(vrw types.ValueReadWriter, o interface{}, useS
 		} else {
 			kv := make([]types.Value, 0, len(o)*2)
 			for k, v := range o {
-				nv := nomsValueFromDecodedJSONBase(vrw, v, useStruct)
+				nv := nomsValueFromDecodedJSONBase(vrw, v, opts)
 				if nv != nil {
 					kv = append(kv, types.String(k), nv)
 				}
@@ -70,6 +88,7 @@ func nomsValueFromDecodedJSONBase(vrw types.ValueReadWriter, o interface{}, useS
 // Currently, the only types supported are the Go versions of legal JSON types:
 // Primitives:
 //  - float64
+//  - json.Number, which becomes a Number
 //  - bool
 //  - string
 //  - nil
@@ -78,11 +97,14 @@ func nomsValueFromDecodedJSONBase(vrw types.ValueReadWriter, o interface{}, useS
 //  - []interface{}
 //  - map[string]interface{}
 func NomsValueFromDecodedJSON(vrw types.ValueReadWriter, o interface{}, useStruct bool) types.Value {
-	return nomsValueFromDecodedJSONBase(vrw, o, useStruct)
+	return nomsValueFromDecodedJSONBase(vrw, o, FromOptions{Structs: useStruct})
 }
 
 func FromJSON(r io.Reader, vrw types.ValueReadWriter, opts FromOptions) (types.Value, error) {
 	dec := json.NewDecoder(r)
+	if opts.Ints || opts.Decimals {
+		dec.UseNumber()
+	}
 	// TODO: This is pretty inefficient. It would be better to parse the JSON directly into Noms values,
 	// rather than going through a pile of Go interfaces.
 	var pile interface{}
@@ -90,11 +112,18 @@ func FromJSON(r io.Reader, vrw types.ValueReadWriter, opts FromOptions) (types.V
 	if err != nil {
 		return nil, err
 	}
-	return NomsValueFromDecodedJSON(vrw, pile, opts.Structs), nil
+	return nomsValueFromDecodedJSONBase(vrw, pile, opts), nil
 }
 
 // FromOptions controls how FromJSON works.
 type FromOptions struct {
 	// If true, JSON objects are decoded into Noms Structs. Otherwise, they are decoded into Maps.
 	Structs bool
+	// If true, JSON numbers that are integers are decoded into Noms Ints, or
+	// Uints if they are too large for an Int. Otherwise, all numbers are
+	// decoded into Noms Numbers.
+	Ints bool
+	// If true, JSON numbers are decoded into Noms Decimals, so that they keep
+	// their exact value. Integers are still decoded into Ints if Ints is set.
+	Decimals bool
 }
@@ -5,6 +5,7 @@
 package json
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/attic-labs/noms/go/chunks"
@@ -38,6 +39,34 @@ func (suite *LibTestSuite) TestPrimitiveTypes() {
 	suite.False(NomsValueFromDecodedJSON(vs, 1.7, false).Equals(types.Bool(true)))
 }
 
+func (suite *LibTestSuite) TestInts() {
+	v, err := FromJSON(strings.NewReader("[-4611686018427387905, 18446744073709551615, 1e3, 1.5]"), suite.vs, FromOptions{Ints: true})
+	suite.NoError(err)
+	suite.True(types.NewList(suite.vs, types.Int(-1<<62-1), types.Uint(1<<64-1), types.Number(1000), types.Number(1.5)).Equals(v))
+
+	v, err = FromJSON(strings.NewReader("[42]"), suite.vs, FromOptions{})
+	suite.NoError(err)
+	suite.True(types.NewList(suite.vs, types.Number(42)).Equals(v))
+}
+
+func (suite *LibTestSuite) TestDecimals() {
+	v, err := FromJSON(strings.NewReader("[0.1, 12345678901234567890.05, 1e3]"), suite.vs, FromOptions{Decimals: true})
+	suite.NoError(err)
+	suite.True(types.NewList(suite.vs, mustParseDecimal("0.1"), mustParseDecimal("12345678901234567890.05"), mustParseDecimal("1000")).Equals(v))
+
+	v, err = FromJSON(strings.NewReader("[0.1, 42]"), suite.vs, FromOptions{Ints: true, Decimals: true})
+	suite.NoError(err)
+	suite.True(types.NewList(suite.vs, mustParseDecimal("0.1"), types.Int(42)).Equals(v))
+}
+
+func mustParseDecimal(s string) types.Decimal {
+	d, err := types.ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
 func (suite *LibTestSuite) TestCompositeTypes() {
 	vs := suite.vs
 
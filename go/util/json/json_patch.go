@@ -0,0 +1,412 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/attic-labs/noms/go/diff"
+	"github.com/attic-labs/noms/go/types"
+)
+
+// PatchOperation is an operation of an RFC 6902 JSON Patch. Op is one of
+// "add", "remove", "replace", "move", "copy" or "test", and Path and From are
+// JSON Pointers (RFC 6901).
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Patch is an RFC 6902 JSON Patch, whose operations are applied in order.
+type Patch []PatchOperation
+
+// ReadPatch reads a JSON Patch from |r|. Numbers in values are kept as
+// json.Number, so that ApplyPatch can decode them according to its options.
+func ReadPatch(r io.Reader) (Patch, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	p := Patch{}
+	if err := dec.Decode(&p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// WritePatch writes |p| to |w| as JSON, indenting it with |indent|.
+func WritePatch(p Patch, w io.Writer, indent string) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", indent)
+	return enc.Encode(p)
+}
+
+// DiffToPatch returns a JSON Patch that turns |v1| into |v2|, made from the
+// diff.Diff between them. See FromDiffPatch.
+func DiffToPatch(v1, v2 types.Value, opts ToOptions) (Patch, error) {
+	return FromDiffPatch(v1, diff.NewPatch(v1, v2), opts)
+}
+
+// FromDiffPatch converts |dp|, a diff.Patch made by a left-right diff.Diff of
+// |root| and some other value, into a JSON Patch that can be applied to the
+// JSON form of |root|. Added, removed and modified Differences become add,
+// remove and replace operations. Moved ones become move operations, followed
+// by a replace if the value changed as it moved. Only changes to structs,
+// Maps with String keys and Lists can be expressed with JSON Pointers, so
+// it's an error if |dp| changes a Set, or a Map with other keys, or if it
+// moves a List element. Values are encoded as ToJSON does with |opts|.
+func FromDiffPatch(root types.Value, dp diff.Patch, opts ToOptions) (Patch, error) {
+	// Diff gives the indexes of removed and modified List elements in the old
+	// List and those of added elements in the new one, but JSON Patch
+	// operations apply in order, so the former need to be offset by the
+	// number of elements that have been added and removed before them.
+	offsets := map[string]int{}
+
+	p := make(Patch, 0, len(dp))
+	for _, dif := range dp {
+		ptr, err := diffPathToPointer(root, dif.Path, dif.ChangeType, offsets)
+		if err != nil {
+			return nil, err
+		}
+
+		op := PatchOperation{Path: ptr}
+		if dif.ChangeType == types.DiffChangeMoved {
+			// A value moved within a List shifts the indexes of those between
+			// its old and new places, which Diff doesn't account for.
+			if _, ok := dif.Path[:len(dif.Path)-1].Resolve(root, nil).(types.List); ok {
+				return nil, fmt.Errorf("Cannot convert the move to %s within a List", dif.Path)
+			}
+			if op.From, err = diffPathToPointer(root, dif.FromPath, dif.ChangeType, offsets); err != nil {
+				return nil, err
+			}
+			op.Op = "move"
+			p = append(p, op)
+			if dif.OldValue.Equals(dif.NewValue) {
+				continue
+			}
+			op = PatchOperation{Op: "replace", Path: ptr}
+		}
+		switch dif.ChangeType {
+		case types.DiffChangeAdded:
+			op.Op = "add"
+		case types.DiffChangeRemoved:
+			op.Op = "remove"
+		case types.DiffChangeModified:
+			op.Op = "replace"
+		}
+		if dif.NewValue != nil {
+			if op.Value, err = toPile(dif.NewValue, opts); err != nil {
+				return nil, fmt.Errorf("Cannot encode the value at %s: %s", dif.Path, err)
+			}
+		}
+		p = append(p, op)
+	}
+	return p, nil
+}
+
+func diffPathToPointer(root types.Value, path types.Path, ct types.DiffChangeType, offsets map[string]int) (string, error) {
+	tokens := make([]string, len(path))
+	v := root
+	for i, part := range path {
+		last := i == len(path)-1
+		var err error
+		switch v.(type) {
+		case types.Struct:
+			if fp, ok := part.(types.FieldPath); ok {
+				tokens[i] = fp.Name
+			} else {
+				err = fmt.Errorf("Invalid path part %s for a struct", part)
+			}
+		case types.Map:
+			if ip, ok := part.(types.IndexPath); ok && !ip.IntoKey && ip.Index.Kind() == types.StringKind {
+				tokens[i] = string(ip.Index.(types.String))
+			} else {
+				err = fmt.Errorf("Only Maps with String keys can be patched with JSON Patch")
+			}
+		case types.List:
+			ip, ok := part.(types.IndexPath)
+			if !ok || ip.Index.Kind() != types.NumberKind {
+				err = fmt.Errorf("Invalid path part %s for a List", part)
+				break
+			}
+			listKey := path[:i].String()
+			idx := int(ip.Index.(types.Number))
+			if !last || ct != types.DiffChangeAdded {
+				idx += offsets[listKey]
+			}
+			tokens[i] = strconv.Itoa(idx)
+			if last {
+				switch ct {
+				case types.DiffChangeAdded:
+					offsets[listKey]++
+				case types.DiffChangeRemoved:
+					offsets[listKey]--
+				}
+			}
+		default:
+			err = fmt.Errorf("%s values can't be patched with JSON Patch", types.KindToString[v.Kind()])
+		}
+		if err != nil {
+			return "", fmt.Errorf("Cannot convert %s to a JSON Pointer: %s", path, err)
+		}
+
+		if !last {
+			if v = part.Resolve(v, nil); v == nil {
+				return "", fmt.Errorf("Cannot convert %s to a JSON Pointer: %s not found", path, path[:i+1])
+			}
+		}
+	}
+	return tokensToPointer(tokens), nil
+}
+
+// ToDiffPatch applies |p| to |root| and returns the diff.Patch between |root|
+// and the result, so that JSON Patches can be used where Noms expects a
+// diff.Patch, e.g. with diff.Apply.
+func ToDiffPatch(vrw types.ValueReadWriter, root types.Value, p Patch, opts FromOptions) (diff.Patch, error) {
+	v, err := ApplyPatch(vrw, root, p, opts)
+	if err != nil {
+		return nil, err
+	}
+	return diff.NewPatch(root, v), nil
+}
+
+// ApplyPatch applies the operations of |p| to |root| in order and returns the
+// result. JSON Pointers can point into structs, Maps with String keys and
+// Lists, in which "-" points past the last element. Values are decoded as
+// FromJSON does with |opts|, including those compared by "test" operations,
+// and JSON objects that are added to structs become struct fields. It's an
+// error if an operation fails, in which case none of |p| is applied.
+func ApplyPatch(vrw types.ValueReadWriter, root types.Value, p Patch, opts FromOptions) (types.Value, error) {
+	for i, op := range p {
+		var err error
+		if root, err = applyOperation(vrw, root, op, opts); err != nil {
+			return nil, fmt.Errorf("Operation %d (%s %s) failed: %s", i, op.Op, op.Path, err)
+		}
+	}
+	return root, nil
+}
+
+func applyOperation(vrw types.ValueReadWriter, root types.Value, op PatchOperation, opts FromOptions) (types.Value, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	value := func() (types.Value, error) {
+		v := nomsValueFromDecodedJSONBase(vrw, op.Value, opts)
+		if v == nil {
+			return nil, fmt.Errorf("Missing value")
+		}
+		return v, nil
+	}
+
+	switch op.Op {
+	case "add":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		return addAtPointer(vrw, root, path, v)
+	case "remove":
+		_, root, err = removeAtPointer(vrw, root, path)
+		return root, err
+	case "replace":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := getAtPointer(root, path); err != nil {
+			return nil, err
+		}
+		if len(path) == 0 {
+			return v, nil
+		}
+		return updateAtPointer(vrw, root, path, func(parent types.Value, token string) (types.Value, error) {
+			return setChild(vrw, parent, token, v, false)
+		})
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		var v types.Value
+		if op.Op == "move" {
+			if len(from) < len(path) && tokensToPointer(path[:len(from)]) == op.From {
+				return nil, fmt.Errorf("Cannot move a value into itself")
+			}
+			v, root, err = removeAtPointer(vrw, root, from)
+		} else {
+			v, err = getAtPointer(root, from)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return addAtPointer(vrw, root, path, v)
+	case "test":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		actual, err := getAtPointer(root, path)
+		if err != nil {
+			return nil, err
+		}
+		if !actual.Equals(v) {
+			return nil, fmt.Errorf("Expected %s, found %s", types.EncodedValue(v), types.EncodedValue(actual))
+		}
+		return root, nil
+	}
+	return nil, fmt.Errorf("Invalid operation %q", op.Op)
+}
+
+func addAtPointer(vrw types.ValueReadWriter, root types.Value, path []string, v types.Value) (types.Value, error) {
+	if len(path) == 0 {
+		return v, nil
+	}
+	return updateAtPointer(vrw, root, path, func(parent types.Value, token string) (types.Value, error) {
+		return setChild(vrw, parent, token, v, true)
+	})
+}
+
+func removeAtPointer(vrw types.ValueReadWriter, root types.Value, path []string) (removed, newRoot types.Value, err error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("Cannot remove the root value")
+	}
+	if removed, err = getAtPointer(root, path); err != nil {
+		return nil, nil, err
+	}
+	newRoot, err = updateAtPointer(vrw, root, path, func(parent types.Value, token string) (types.Value, error) {
+		switch parent := parent.(type) {
+		case types.Struct:
+			return parent.Delete(types.EscapeStructField(token)), nil
+		case types.Map:
+			return parent.Edit().Remove(types.String(token)).Map(), nil
+		case types.List:
+			idx, err := listIndex(parent, token, false)
+			if err != nil {
+				return nil, err
+			}
+			return parent.Edit().RemoveAt(idx).List(), nil
+		}
+		panic("unreachable")
+	})
+	return removed, newRoot, err
+}
+
+// updateAtPointer replaces the parent of the value at |path| with the result
+// of |leaf|, and its ancestors with copies that contain the new value.
+func updateAtPointer(vrw types.ValueReadWriter, v types.Value, path []string, leaf func(parent types.Value, token string) (types.Value, error)) (types.Value, error) {
+	if len(path) == 1 {
+		return leaf(v, path[0])
+	}
+	child, err := getChild(v, path[0])
+	if err != nil {
+		return nil, err
+	}
+	if child, err = updateAtPointer(vrw, child, path[1:], leaf); err != nil {
+		return nil, err
+	}
+	return setChild(vrw, v, path[0], child, false)
+}
+
+func getAtPointer(v types.Value, path []string) (types.Value, error) {
+	for _, token := range path {
+		var err error
+		if v, err = getChild(v, token); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+func getChild(v types.Value, token string) (types.Value, error) {
+	var child types.Value
+	switch v := v.(type) {
+	case types.Struct:
+		child, _ = v.MaybeGet(types.EscapeStructField(token))
+	case types.Map:
+		child, _ = v.MaybeGet(types.String(token))
+	case types.List:
+		idx, err := listIndex(v, token, false)
+		if err != nil {
+			return nil, err
+		}
+		child = v.Get(idx)
+	default:
+		return nil, fmt.Errorf("%s values can't be patched with JSON Patch", types.KindToString[v.Kind()])
+	}
+	if child == nil {
+		return nil, fmt.Errorf("%q not found", token)
+	}
+	return child, nil
+}
+
+// setChild sets the child of |v| named by |token| to |child|. If |insert| is
+// true, |child| is inserted into Lists rather than replacing an element.
+func setChild(vrw types.ValueReadWriter, v types.Value, token string, child types.Value, insert bool) (types.Value, error) {
+	switch v := v.(type) {
+	case types.Struct:
+		return v.Set(types.EscapeStructField(token), child), nil
+	case types.Map:
+		return v.Edit().Set(types.String(token), child).Map(), nil
+	case types.List:
+		idx, err := listIndex(v, token, insert)
+		if err != nil {
+			return nil, err
+		}
+		if insert {
+			return v.Edit().Insert(idx, child).List(), nil
+		}
+		return v.Edit().Set(idx, child).List(), nil
+	}
+	return nil, fmt.Errorf("%s values can't be patched with JSON Patch", types.KindToString[v.Kind()])
+}
+
+// listIndex parses |token| as an index into |l|. If |insert| is true, the
+// index can be the length of |l|, which "-" stands for.
+func listIndex(l types.List, token string, insert bool) (uint64, error) {
+	if insert && token == "-" {
+		return l.Len(), nil
+	}
+	idx, err := strconv.ParseUint(token, 10, 64)
+	if err != nil || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("Invalid List index %q", token)
+	}
+	if idx > l.Len() || (!insert && idx == l.Len()) {
+		return 0, fmt.Errorf("List index %d out of bounds", idx)
+	}
+	return idx, nil
+}
+
+var (
+	pointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+func tokensToPointer(tokens []string) string {
+	buf := &strings.Builder{}
+	for _, t := range tokens {
+		buf.WriteByte('/')
+		pointerEscaper.WriteString(buf, t)
+	}
+	return buf.String()
+}
+
+func parsePointer(ptr string) ([]string, error) {
+	if ptr == "" {
+		return nil, nil
+	}
+	if ptr[0] != '/' {
+		return nil, fmt.Errorf("Invalid JSON Pointer %q", ptr)
+	}
+	tokens := strings.Split(ptr[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = pointerUnescaper.Replace(t)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,187 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package json
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/attic-labs/noms/go/chunks"
+	"github.com/attic-labs/noms/go/diff"
+	"github.com/attic-labs/noms/go/types"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestJSONPatchSuite(t *testing.T) {
+	suite.Run(t, &JSONPatchSuite{})
+}
+
+type JSONPatchSuite struct {
+	suite.Suite
+	vs *types.ValueStore
+}
+
+func (suite *JSONPatchSuite) SetupTest() {
+	st := &chunks.TestStorage{}
+	suite.vs = types.NewValueStore(st.NewView())
+}
+
+func (suite *JSONPatchSuite) TearDownTest() {
+	suite.vs.Close()
+}
+
+func (suite *JSONPatchSuite) fromJSON(s string) types.Value {
+	v, err := FromJSON(strings.NewReader(s), suite.vs, FromOptions{})
+	suite.NoError(err)
+	return v
+}
+
+func (suite *JSONPatchSuite) readPatch(s string) Patch {
+	p, err := ReadPatch(strings.NewReader(s))
+	suite.NoError(err)
+	return p
+}
+
+func (suite *JSONPatchSuite) TestApplyPatch() {
+	// Examples from RFC 6902, Appendix A.
+	tc := []struct {
+		doc, patch, exp string
+	}{
+		{`{"foo": "bar"}`, `[{"op": "add", "path": "/baz", "value": "qux"}]`, `{"baz": "qux", "foo": "bar"}`},
+		{`{"foo": ["bar", "baz"]}`, `[{"op": "add", "path": "/foo/1", "value": "qux"}]`, `{"foo": ["bar", "qux", "baz"]}`},
+		{`{"baz": "qux", "foo": "bar"}`, `[{"op": "remove", "path": "/baz"}]`, `{"foo": "bar"}`},
+		{`{"foo": ["bar", "qux", "baz"]}`, `[{"op": "remove", "path": "/foo/1"}]`, `{"foo": ["bar", "baz"]}`},
+		{`{"baz": "qux", "foo": "bar"}`, `[{"op": "replace", "path": "/baz", "value": "boo"}]`, `{"baz": "boo", "foo": "bar"}`},
+		{`{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`, `[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`,
+			`{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`},
+		{`{"foo": ["all", "grass", "cows", "eat"]}`, `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`, `{"foo": ["all", "cows", "eat", "grass"]}`},
+		{`{"baz": "qux", "foo": ["a", 2, "c"]}`, `[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2}]`,
+			`{"baz": "qux", "foo": ["a", 2, "c"]}`},
+		{`{"foo": "bar"}`, `[{"op": "add", "path": "/child", "value": {"grandchild": {}}}]`, `{"foo": "bar", "child": {"grandchild": {}}}`},
+		{`{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`, `{"foo": ["bar", ["abc", "def"]]}`},
+		{`{"/": 1, "m~n": 2}`, `[{"op": "copy", "from": "/~1", "path": "/m~0n"}]`, `{"/": 1, "m~n": 1}`},
+		{`{"foo": "bar"}`, `[{"op": "replace", "path": "", "value": [1]}]`, `[1]`},
+	}
+
+	for _, t := range tc {
+		v, err := ApplyPatch(suite.vs, suite.fromJSON(t.doc), suite.readPatch(t.patch), FromOptions{})
+		suite.NoError(err, t.patch)
+		suite.True(suite.fromJSON(t.exp).Equals(v), "%s: %s", t.patch, types.EncodedValue(v))
+	}
+}
+
+func (suite *JSONPatchSuite) TestApplyPatchStructs() {
+	s := types.NewStruct("Person", types.StructData{"name": types.String("bob"), "tags": types.NewList(suite.vs, types.String("a"))})
+	p := suite.readPatch(`[
+		{"op": "replace", "path": "/name", "value": "carol"},
+		{"op": "add", "path": "/tags/0", "value": 42},
+		{"op": "add", "path": "/age", "value": 30}
+	]`)
+	v, err := ApplyPatch(suite.vs, s, p, FromOptions{Ints: true})
+	suite.NoError(err)
+	exp := types.NewStruct("Person", types.StructData{
+		"name": types.String("carol"),
+		"tags": types.NewList(suite.vs, types.Int(42), types.String("a")),
+		"age":  types.Int(30),
+	})
+	suite.True(exp.Equals(v))
+}
+
+func (suite *JSONPatchSuite) TestApplyPatchErrors() {
+	doc := suite.fromJSON(`{"foo": ["bar"], "baz": "qux"}`)
+	for _, p := range []string{
+		`[{"op": "add", "path": "/foo/2", "value": 1}]`,
+		`[{"op": "add", "path": "/foo/01", "value": 1}]`,
+		`[{"op": "add", "path": "/missing/a", "value": 1}]`,
+		`[{"op": "add", "path": "/a"}]`,
+		`[{"op": "remove", "path": "/foo/1"}]`,
+		`[{"op": "remove", "path": ""}]`,
+		`[{"op": "replace", "path": "/a", "value": 1}]`,
+		`[{"op": "move", "from": "/foo", "path": "/foo/0"}]`,
+		`[{"op": "test", "path": "/baz", "value": "quux"}]`,
+		`[{"op": "frob", "path": "/baz"}]`,
+		`[{"op": "remove", "path": "baz"}]`,
+	} {
+		_, err := ApplyPatch(suite.vs, doc, suite.readPatch(p), FromOptions{})
+		suite.Error(err, p)
+	}
+
+	_, err := ApplyPatch(suite.vs, types.NewSet(suite.vs, types.String("a")), suite.readPatch(`[{"op": "remove", "path": "/a"}]`), FromOptions{})
+	suite.Error(err)
+}
+
+func (suite *JSONPatchSuite) TestDiffToPatch() {
+	tc := []struct {
+		v1, v2 string
+	}{
+		{`{"a": 1, "b": "x"}`, `{"a": 2, "c": true}`},
+		{`[1, 2, 3, 4, 5]`, `[0, 2, 4, 5, 6, 7]`},
+		{`[1, 2, 3, 4, 5, 6, 7, 8]`, `[9, 2, 10, 11, 4, 6, 8]`},
+		{`{"l": [{"a": 1}, {"a": 2}, 3], "m": {"a/b": {"c~": 1}}}`, `{"l": [0, {"a": 1}, {"a": 3}], "m": {"a/b": {"c~": 2}}}`},
+		{`{"a": 1}`, `[1]`},
+	}
+
+	for _, t := range tc {
+		v1, v2 := suite.fromJSON(t.v1), suite.fromJSON(t.v2)
+		p, err := DiffToPatch(v1, v2, ToOptions{Lists: true, Maps: true})
+		suite.NoError(err)
+
+		buf := &bytes.Buffer{}
+		suite.NoError(WritePatch(p, buf, ""))
+		v, err := ApplyPatch(suite.vs, v1, suite.readPatch(buf.String()), FromOptions{})
+		suite.NoError(err)
+		suite.True(v2.Equals(v), "%s -> %s: %s", t.v1, t.v2, buf.String())
+
+		dp, err := ToDiffPatch(suite.vs, v1, p, FromOptions{})
+		suite.NoError(err)
+		suite.True(v2.Equals(diff.Apply(v1, dp)))
+	}
+
+	p, err := DiffToPatch(suite.fromJSON(`{"a/b": [1]}`), suite.fromJSON(`{"a/b": [2]}`), ToOptions{Lists: true, Maps: true})
+	suite.NoError(err)
+	suite.Equal(Patch{{Op: "replace", Path: "/a~1b/0", Value: float64(2)}}, p)
+
+	_, err = DiffToPatch(types.NewSet(suite.vs, types.Number(1)), types.NewSet(suite.vs, types.Number(2)), ToOptions{})
+	suite.Error(err)
+	_, err = DiffToPatch(types.NewMap(suite.vs, types.Number(1), types.Number(1)), types.NewMap(suite.vs, types.Number(1), types.Number(2)), ToOptions{})
+	suite.Error(err)
+}
+
+func (suite *JSONPatchSuite) TestFromDiffPatchMoves() {
+	diffMoves := func(v1, v2 types.Value) diff.Patch {
+		dChan := make(chan diff.Difference)
+		go func() {
+			diff.DiffWithOptions(v1, v2, dChan, make(chan struct{}), diff.Options{LeftRight: true, DetectMoves: true, MoveSimilarity: 0.5})
+			close(dChan)
+		}()
+		dp := diff.Patch{}
+		for dif := range dChan {
+			dp = append(dp, dif)
+		}
+		return dp
+	}
+
+	v1 := suite.fromJSON(`{"a": {"x": 1, "y": 2}, "b": [1, 2]}`)
+	v2 := suite.fromJSON(`{"c": {"x": 1, "y": 2}, "b": [1, 2]}`)
+	p, err := FromDiffPatch(v1, diffMoves(v1, v2), ToOptions{Maps: true})
+	suite.NoError(err)
+	suite.Equal(Patch{{Op: "move", From: "/a", Path: "/c"}}, p)
+
+	v1 = types.NewStruct("", types.StructData{"owner": types.NewStruct("", types.StructData{"name": types.String("bob"), "age": types.Number(30), "city": types.String("nyc")})})
+	v2 = types.NewStruct("", types.StructData{"author": types.NewStruct("", types.StructData{"name": types.String("bob"), "age": types.Number(31), "city": types.String("nyc")})})
+	p, err = FromDiffPatch(v1, diffMoves(v1, v2), ToOptions{Structs: true})
+	suite.NoError(err)
+	suite.Len(p, 2)
+	suite.Equal("move", p[0].Op)
+	suite.Equal("replace", p[1].Op)
+	v, err := ApplyPatch(suite.vs, v1, p, FromOptions{Structs: true})
+	suite.NoError(err)
+	suite.True(v2.Equals(v))
+
+	v1, v2 = suite.fromJSON(`["a", "b", "c"]`), suite.fromJSON(`["b", "c", "a"]`)
+	_, err = FromDiffPatch(v1, diffMoves(v1, v2), ToOptions{Lists: true})
+	suite.Error(err)
+}
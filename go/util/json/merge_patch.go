@@ -0,0 +1,72 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package json
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/attic-labs/noms/go/types"
+)
+
+// MergePatch reads an RFC 7386 JSON Merge Patch from |r| and applies it to
+// |target|. Each member of a JSON object in the patch is set on the struct or
+// String-keyed Map at the same place in |target|, or removed from it if the
+// member is null. Any other JSON value replaces the value it's merged into,
+// as does an object that's merged into a value that isn't a struct or Map.
+// Values are decoded as FromJSON does with |opts|, and so are the structs or
+// Maps made for such objects.
+func MergePatch(vrw types.ValueReadWriter, target types.Value, r io.Reader, opts FromOptions) (types.Value, error) {
+	dec := json.NewDecoder(r)
+	if opts.Ints || opts.Decimals {
+		dec.UseNumber()
+	}
+	var pile interface{}
+	if err := dec.Decode(&pile); err != nil {
+		return nil, err
+	}
+	return mergePatch(vrw, target, pile, opts), nil
+}
+
+func mergePatch(vrw types.ValueReadWriter, target types.Value, patch interface{}, opts FromOptions) types.Value {
+	obj, ok := patch.(map[string]interface{})
+	if !ok {
+		return nomsValueFromDecodedJSONBase(vrw, patch, opts)
+	}
+
+	switch t := target.(type) {
+	case types.Struct:
+		for k, v := range obj {
+			name := types.EscapeStructField(k)
+			if v == nil {
+				t = t.Delete(name)
+				continue
+			}
+			child, _ := t.MaybeGet(name)
+			t = t.Set(name, mergePatch(vrw, child, v, opts))
+		}
+		return t
+	case types.Map:
+		if k, _ := t.First(); k == nil || k.Kind() == types.StringKind {
+			me := t.Edit()
+			for k, v := range obj {
+				if v == nil {
+					me.Remove(types.String(k))
+					continue
+				}
+				child, _ := t.MaybeGet(types.String(k))
+				me.Set(types.String(k), mergePatch(vrw, child, v, opts))
+			}
+			return me.Map()
+		}
+	}
+
+	// Merging into anything else is like merging into an empty object, which
+	// is what |obj| is without its null members.
+	if opts.Structs {
+		return mergePatch(vrw, types.NewStruct("", nil), obj, opts)
+	}
+	return mergePatch(vrw, types.NewMap(vrw), obj, opts)
+}
@@ -0,0 +1,50 @@
+// Copyright 2017 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package json
+
+import (
+	"strings"
+
+	"github.com/attic-labs/noms/go/types"
+)
+
+func (suite *JSONPatchSuite) TestMergePatch() {
+	// Examples from RFC 7386, Appendix A.
+	tc := []struct {
+		target, patch, exp string
+	}{
+		{`{"a": "b"}`, `{"a": "c"}`, `{"a": "c"}`},
+		{`{"a": "b"}`, `{"b": "c"}`, `{"a": "b", "b": "c"}`},
+		{`{"a": "b"}`, `{"a": null}`, `{}`},
+		{`{"a": "b", "b": "c"}`, `{"a": null}`, `{"b": "c"}`},
+		{`{"a": ["b"]}`, `{"a": "c"}`, `{"a": "c"}`},
+		{`{"a": "c"}`, `{"a": ["b"]}`, `{"a": ["b"]}`},
+		{`{"a": {"b": "c"}}`, `{"a": {"b": "d", "c": null}}`, `{"a": {"b": "d"}}`},
+		{`{"a": [{"b": "c"}]}`, `{"a": [1]}`, `{"a": [1]}`},
+		{`["a", "b"]`, `["c", "d"]`, `["c", "d"]`},
+		{`{"a": "b"}`, `["c"]`, `["c"]`},
+		{`{"e": null}`, `{"a": 1}`, `{"a": 1}`},
+		{`[1, 2]`, `{"a": "b", "c": null}`, `{"a": "b"}`},
+		{`{}`, `{"a": {"bb": {"ccc": null}}}`, `{"a": {"bb": {}}}`},
+	}
+
+	for _, t := range tc {
+		v, err := MergePatch(suite.vs, suite.fromJSON(t.target), strings.NewReader(t.patch), FromOptions{})
+		suite.NoError(err)
+		suite.True(suite.fromJSON(t.exp).Equals(v), "%s: %s", t.patch, types.EncodedValue(v))
+	}
+
+	s := types.NewStruct("Person", types.StructData{"name": types.String("bob"), "age": types.Int(30)})
+	v, err := MergePatch(suite.vs, s, strings.NewReader(`{"age": 31, "name": null, "address": {"city": "Oakland"}}`), FromOptions{Structs: true, Ints: true})
+	suite.NoError(err)
+	exp := types.NewStruct("Person", types.StructData{
+		"age":     types.Int(31),
+		"address": types.NewStruct("", types.StructData{"city": types.String("Oakland")}),
+	})
+	suite.True(exp.Equals(v), types.EncodedValue(v))
+
+	_, err = MergePatch(suite.vs, s, strings.NewReader(`{`), FromOptions{})
+	suite.Error(err)
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/attic-labs/noms/go/types"
 )
@@ -37,8 +38,13 @@ type ToOptions struct {
 	Sets bool
 	// Enable support for encoding Noms Structs. Structs are encoded as JSON objects.
 	Structs bool
+	// Enable support for encoding Noms Tuples. Tuples are encoded as JSON arrays.
+	Tuples bool
 	// String to use for indent when pretty-printing
 	Indent string
+	// Encode Noms Decimals as JSON strings instead of JSON numbers, for
+	// readers that would lose precision parsing numbers.
+	DecimalStrings bool
 }
 
 func toPile(v types.Value, opts ToOptions) (ret interface{}, err error) {
@@ -47,6 +53,17 @@ func toPile(v types.Value, opts ToOptions) (ret interface{}, err error) {
 		return bool(v), nil
 	case types.Number:
 		return float64(v), nil
+	case types.Int:
+		return int64(v), nil
+	case types.Uint:
+		return uint64(v), nil
+	case types.Timestamp:
+		return v.Time().Format(time.RFC3339Nano), nil
+	case types.Decimal:
+		if opts.DecimalStrings {
+			return v.String(), nil
+		}
+		return json.Number(v.String()), nil
 	case types.String:
 		return string(v), nil
 	case types.Struct:
@@ -102,6 +119,17 @@ func toPile(v types.Value, opts ToOptions) (ret interface{}, err error) {
 			return false
 		})
 		return r, err
+	case types.Tuple:
+		if !opts.Tuples {
+			return nil, errors.New("Tuple marshaling not enabled")
+		}
+		r := make([]interface{}, v.Len())
+		for i, cv := range v.Values() {
+			if r[i], err = toPile(cv, opts); err != nil {
+				return nil, err
+			}
+		}
+		return r, nil
 	case types.Set:
 		if !opts.Sets {
 			return nil, errors.New("Set marshaling not enabled")
@@ -43,6 +43,11 @@ func (suite *ToJSONSuite) TestToJSON() {
 		{"false", types.Bool(false), ToOptions{}, "false", ""},
 		{"42", types.Number(42), ToOptions{}, "42", ""},
 		{"88.8", types.Number(88.8), ToOptions{}, "88.8", ""},
+		{"int", types.Int(-1<<62 - 1), ToOptions{}, "-4611686018427387905", ""},
+		{"uint", types.Uint(1<<64 - 1), ToOptions{}, "18446744073709551615", ""},
+		{"timestamp", types.Timestamp(1501801626123456789), ToOptions{}, `"2017-08-03T23:07:06.123456789Z"`, ""},
+		{"decimal", mustParseDecimal("-12345678901234567890.05"), ToOptions{}, "-12345678901234567890.05", ""},
+		{"decimal string", mustParseDecimal("0.1"), ToOptions{DecimalStrings: true}, `"0.1"`, ""},
 		{"empty string", types.String(""), ToOptions{}, `""`, ""},
 		{"foobar", types.String("foobar"), ToOptions{}, `"foobar"`, ""},
 		{"strings with newlines", types.String(`"\nmonkey`), ToOptions{}, `"\"\\nmonkey"`, ""},
@@ -58,6 +63,9 @@ func (suite *ToJSONSuite) TestToJSON() {
 		{"sets when not enabled", types.NewSet(suite.vs), ToOptions{}, "", "Set marshaling not enabled"},
 		{"set nested errors", types.NewSet(suite.vs, types.NewList(suite.vs)), ToOptions{Sets: true}, "", "List marshaling not enabled"},
 		{"empty set", types.NewSet(suite.vs), ToOptions{Sets: true}, "[]", ""},
+		{"tuple", types.NewTuple(types.String("foo"), types.Int(42)), ToOptions{}, "", "Tuple marshaling not enabled"},
+		{"non-empty tuple", types.NewTuple(types.String("foo"), types.Int(42)), ToOptions{Tuples: true}, `["foo",42]`, ""},
+		{"empty tuple", types.NewTuple(), ToOptions{Tuples: true}, "[]", ""},
 		{"non-empty set", types.NewSet(suite.vs, types.Number(42), types.String("foo")), ToOptions{Sets: true}, `[42,"foo"]`, ""},
 		{"maps when not enabled", types.NewMap(suite.vs), ToOptions{}, "", "Map marshaling not enabled"},
 		{"map nested errors", types.NewMap(suite.vs, types.String("foo"), types.NewSet(suite.vs)), ToOptions{Maps: true}, "", "Set marshaling not enabled"},
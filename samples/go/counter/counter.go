@@ -11,13 +11,22 @@ import (
 	"github.com/attic-labs/kingpin"
 
 	"github.com/attic-labs/noms/go/config"
+	"github.com/attic-labs/noms/go/crdt"
+	"github.com/attic-labs/noms/go/datas"
+	"github.com/attic-labs/noms/go/merge"
 	"github.com/attic-labs/noms/go/types"
 	"github.com/attic-labs/noms/go/util/verbose"
 )
 
+// migratedReplica is the replica that the count is credited to when it's
+// migrated from a Number. It's the same for every replica, so that replicas
+// that migrate the same count concurrently don't add it up twice.
+const migratedReplica = "migrated"
+
 func main() {
 	app := kingpin.New("counter", "")
 	dsStr := app.Arg("ds", "dataset to count in").Required().String()
+	replica := app.Flag("replica", "name of this replica, which must be unique among those that count concurrently - defaults to the hostname").String()
 	verbose.RegisterVerboseFlags(app)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -29,16 +38,32 @@ func main() {
 	}
 	defer db.Close()
 
-	newVal := uint64(1)
+	if *replica == "" {
+		*replica, _ = os.Hostname()
+	}
+
+	// The count is a GCounter, so that concurrent increments are merged.
+	counter := crdt.NewGCounter(db)
 	if lastVal, ok := ds.MaybeHeadValue(); ok {
-		newVal = uint64(lastVal.(types.Number)) + 1
+		if n, ok := lastVal.(types.Number); ok {
+			// The count used to be a Number.
+			counter = counter.Inc(migratedReplica, uint64(n))
+		} else if counter, err = crdt.GCounterFromValue(lastVal); err != nil {
+			fmt.Fprintf(os.Stderr, "Could not read count: %s\n", err)
+			return
+		}
 	}
 
-	_, err = db.CommitValue(ds, types.Number(newVal))
+	ds, err = db.Commit(ds, counter.Inc(*replica, 1).Value(), datas.CommitOptions{Policy: merge.NewThreeWay(merge.None)})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error committing: %s\n", err)
 		return
 	}
 
-	fmt.Println(newVal)
+	counter, err = crdt.GCounterFromValue(ds.HeadValue())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not read count: %s\n", err)
+		return
+	}
+	fmt.Println(counter.Count())
 }
@@ -7,7 +7,10 @@ package main
 import (
 	"testing"
 
+	"github.com/attic-labs/noms/go/crdt"
+	"github.com/attic-labs/noms/go/merge"
 	"github.com/attic-labs/noms/go/spec"
+	"github.com/attic-labs/noms/go/types"
 	"github.com/attic-labs/noms/go/util/clienttest"
 	"github.com/stretchr/testify/suite"
 )
@@ -33,3 +36,27 @@ func (s *counterTestSuite) TestCounter() {
 	s.Equal("3\n", stdout)
 	s.Equal("", stderr)
 }
+
+func (s *counterTestSuite) TestMigrateNumber() {
+	sp, err := spec.ForDataset(spec.CreateValueSpecString("nbs", s.DBDir, "counter"))
+	s.NoError(err)
+	defer sp.Close()
+	db := sp.GetDatabase()
+	ds, err := db.CommitValue(sp.GetDataset(), types.Number(41))
+	s.NoError(err)
+	parent := ds.HeadValue()
+
+	// Replicas that migrate the same Number concurrently count it once.
+	args := []string{"--replica", "a", sp.String()}
+	stdout, stderr := s.MustRun(main, args)
+	s.Equal("42\n", stdout)
+	s.Equal("", stderr)
+
+	a := crdt.NewGCounter(db).Inc(migratedReplica, 41).Inc("a", 1)
+	b := crdt.NewGCounter(db).Inc(migratedReplica, 41).Inc("b", 1)
+	merged, err := merge.ThreeWay(a.Value(), b.Value(), parent, db, nil, nil)
+	s.NoError(err)
+	c, err := crdt.GCounterFromValue(merged)
+	s.NoError(err)
+	s.Equal(uint64(43), c.Count())
+}
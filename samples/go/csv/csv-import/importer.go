@@ -46,6 +46,7 @@ func main() {
 	path := app.Flag("path", "noms path to blob to import").Short('p').String()
 	noProgress := app.Flag("no-progress", "prevents progress from being output if true").Bool()
 	destType := app.Flag("dest-type", "the destination type to import to. can be 'list' or 'map:<pk>', where <pk> is a list of comma-delimited column headers or indexes (0-based) used to uniquely identify a row").Default("list").String()
+	tupleKey := app.Flag("tuple-key", "with 'map:<pk>', key each row by a Tuple of its primary key columns instead of nesting a Map per column, so that the map can be range scanned by key prefix").Bool()
 	skipRecords := app.Flag("skip-records", "number of records to skip at beginning of file").Uint()
 	limit := app.Flag("limit-records", "maximum number of records to process").Default(fmt.Sprintf("%d", math.MaxUint32)).Uint64()
 	performCommit := app.Flag("commit", "commit the data to head of the dataset (otherwise only write the data to the dataset)").Default("true").Bool()
@@ -171,7 +172,9 @@ func main() {
 	defer db.Close()
 
 	var value types.Value
-	if dest == destMap {
+	if dest == destMap && *tupleKey {
+		value = csv.ReadToTupleMap(cr, *name, headers, strPks, kinds, db, *limit)
+	} else if dest == destMap {
 		value = csv.ReadToMap(cr, *name, headers, strPks, kinds, db, *limit)
 	} else if *invert {
 		value = csv.ReadToColumnar(cr, *name, headers, kinds, db, *limit)
@@ -46,9 +46,12 @@ func main() {
 
 	// I don't want to allocate a new types.Value every time someone calls zeroVal(), so instead have a map of canned Values to reference.
 	zeroVals := map[types.NomsKind]types.Value{
-		types.BoolKind:   types.Bool(false),
-		types.NumberKind: types.Number(0),
-		types.StringKind: types.String(""),
+		types.BoolKind:    types.Bool(false),
+		types.NumberKind:  types.Number(0),
+		types.IntKind:     types.Int(0),
+		types.UintKind:    types.Uint(0),
+		types.DecimalKind: types.Decimal{},
+		types.StringKind:  types.String(""),
 	}
 
 	zeroVal := func(t *types.Type) types.Value {
@@ -255,7 +255,20 @@ func primaryKeyValuesFromFields(fields types.ValueSlice, fieldOrder, pkIndices [
 // already read those and are pointing at the first data row.
 // If kinds is non-empty, it will be used to type the fields in the generated
 // structs; otherwise, they will be left as string-fields.
+// If there are several primary keys, rows are stored in nested Maps, one level
+// per key.
 func ReadToMap(r *csv.Reader, structName string, headersRaw []string, primaryKeys []string, kinds KindSlice, vrw types.ValueReadWriter, limit uint64) types.Map {
+	return readToMap(r, structName, headersRaw, primaryKeys, kinds, vrw, limit, false)
+}
+
+// ReadToTupleMap is like ReadToMap, but keys each row by a types.Tuple of its
+// primary key values instead of nesting Maps. Tuples are ordered by value, so
+// the resulting Map can be range scanned by any prefix of the primary keys.
+func ReadToTupleMap(r *csv.Reader, structName string, headersRaw []string, primaryKeys []string, kinds KindSlice, vrw types.ValueReadWriter, limit uint64) types.Map {
+	return readToMap(r, structName, headersRaw, primaryKeys, kinds, vrw, limit, true)
+}
+
+func readToMap(r *csv.Reader, structName string, headersRaw []string, primaryKeys []string, kinds KindSlice, vrw types.ValueReadWriter, limit uint64, tupleKeys bool) types.Map {
 	temp, fieldOrder, kindMap := MakeStructTemplateFromHeaders(headersRaw, structName, kinds)
 	pkIndices := getPkIndices(primaryKeys, headersRaw)
 	d.Chk.True(len(pkIndices) >= 1, "No primary key defined when reading into map")
@@ -273,6 +286,9 @@ func ReadToMap(r *csv.Reader, structName string, headersRaw []string, primaryKey
 
 		fields := readFieldsFromRow(row, headersRaw, fieldOrder, kindMap)
 		graphKeys, mapKey := primaryKeyValuesFromFields(fields, fieldOrder, pkIndices)
+		if tupleKeys {
+			graphKeys, mapKey = nil, types.NewTuple(append(graphKeys, mapKey)...)
+		}
 		st := temp.NewStruct(fields)
 		gb.MapSet(graphKeys, mapKey, st)
 	}
@@ -78,6 +78,35 @@ b,2,false
 	})))
 }
 
+func TestReadToTupleMap(t *testing.T) {
+	assert := assert.New(t)
+	storage := &chunks.MemoryStorage{}
+	db := datas.NewDatabase(storage.NewView())
+
+	dataString := `us,2,x
+eu,1,y
+us,1,z
+`
+	r := NewCSVReader(bytes.NewBufferString(dataString), ',')
+
+	headers := []string{"Region", "Day", "V"}
+	kinds := KindSlice{types.StringKind, types.NumberKind, types.StringKind}
+	m := ReadToTupleMap(r, "test", headers, []string{"Region", "Day"}, kinds, db, LIMIT)
+
+	assert.Equal(uint64(3), m.Len())
+	assert.True(types.MakeTupleType(types.StringType, types.NumberType).Equals(types.TypeOf(m).Desc.(types.CompoundDesc).ElemTypes[0]))
+
+	// Rows are ordered by region, then day.
+	vs := []string{}
+	m.IterAll(func(k, v types.Value) {
+		vs = append(vs, string(v.(types.Struct).Get("V").(types.String)))
+	})
+	assert.Equal([]string{"y", "z", "x"}, vs)
+
+	k, _ := m.IteratorFrom(types.NewTuple(types.String("us"))).Entry()
+	assert.True(types.NewTuple(types.String("us"), types.Number(1)).Equals(k))
+}
+
 func testTrailingHelper(t *testing.T, dataString string) {
 	assert := assert.New(t)
 	storage := &chunks.MemoryStorage{}
@@ -217,6 +246,38 @@ func TestDefaults(t *testing.T) {
 	assert.Equal(types.String(""), row.Get("D"))
 }
 
+func TestReadInts(t *testing.T) {
+	assert := assert.New(t)
+	storage := &chunks.MemoryStorage{}
+	db := datas.NewDatabase(storage.NewView())
+	dataString := "9007199254740993,18446744073709551615\n-1,\n"
+	r := NewCSVReader(bytes.NewBufferString(dataString), ',')
+	headers := []string{"I", "U"}
+	kinds := KindSlice{types.IntKind, types.UintKind}
+
+	l := ReadToList(r, "test", headers, kinds, db, LIMIT)
+	assert.Equal(uint64(2), l.Len())
+	row := l.Get(0).(types.Struct)
+	assert.Equal(types.Int(9007199254740993), row.Get("I"))
+	assert.Equal(types.Uint(18446744073709551615), row.Get("U"))
+	row = l.Get(1).(types.Struct)
+	assert.Equal(types.Int(-1), row.Get("I"))
+	assert.Equal(types.Uint(0), row.Get("U"))
+
+	_, err := StringToValue("-1", types.UintKind)
+	assert.Error(err)
+
+	v, err := StringToValue("2017-08-03T16:07:06.5-07:00", types.TimestampKind)
+	assert.NoError(err)
+	assert.Equal(types.Timestamp(1501801626500000000), v)
+
+	v, err = StringToValue("19.99", types.DecimalKind)
+	assert.NoError(err)
+	assert.Equal("19.99", v.(types.Decimal).String())
+	_, err = StringToValue("$19.99", types.DecimalKind)
+	assert.Error(err)
+}
+
 func TestBooleanStrings(t *testing.T) {
 	assert := assert.New(t)
 	storage := &chunks.MemoryStorage{}
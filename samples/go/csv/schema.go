@@ -10,6 +10,7 @@ import (
 	"io"
 	"math"
 	"strconv"
+	"time"
 
 	"github.com/attic-labs/noms/go/d"
 	"github.com/attic-labs/noms/go/types"
@@ -225,6 +226,43 @@ func StringToValue(s string, k types.NomsKind) (types.Value, error) {
 			return nil, fmt.Errorf("Could not parse '%s' into number (%s)", s, err)
 		}
 		return types.Number(fval), nil
+	case types.IntKind:
+		if s == "" {
+			return types.Int(0), nil
+		}
+		ival, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Could not parse '%s' into int (%s)", s, err)
+		}
+		return types.Int(ival), nil
+	case types.UintKind:
+		if s == "" {
+			return types.Uint(0), nil
+		}
+		uval, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Could not parse '%s' into uint (%s)", s, err)
+		}
+		return types.Uint(uval), nil
+	case types.TimestampKind:
+		tval, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil, fmt.Errorf("Could not parse '%s' into timestamp (%s)", s, err)
+		}
+		ts, err := types.NewTimestamp(tval)
+		if err != nil {
+			return nil, fmt.Errorf("Could not parse '%s' into timestamp (%s)", s, err)
+		}
+		return ts, nil
+	case types.DecimalKind:
+		if s == "" {
+			return types.Decimal{}, nil
+		}
+		dval, err := types.ParseDecimal(s)
+		if err != nil {
+			return nil, fmt.Errorf("Could not parse '%s' into decimal (%s)", s, err)
+		}
+		return dval, nil
 	case types.BoolKind:
 		// TODO: This should probably be configurable.
 		switch s {